@@ -1,26 +1,83 @@
 package main
 
 import (
+	"context"
+	"io"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/kelseyhightower/envconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"audio-service/pkg/codec"
 	"audio-service/pkg/converter"
+	"audio-service/pkg/mtls"
+	"audio-service/pkg/pcm"
 	"audio-service/pkg/playback"
 	"audio-service/pkg/player"
 	"audio-service/pkg/storage"
-	tcp "audio-service/pkg/tcp"
+	"audio-service/pkg/stream"
+	"audio-service/pkg/tcp"
+	"audio-service/pkg/udp"
+	"audio-service/pkg/wav"
 )
 
 type configuration struct {
 	Port        string `envconfig:"PORT" default:"8080"`
 	UDPBuffSize int    `envconfig:"UDP_BUFF_SIZE" default:"1024"`
+	// Transport of audio signal: tcp - raw bytes, stream - packets with sequence number, timestamp and format,
+	// udp - packets of stream over udp unicast or multicast
+	Transport string `envconfig:"TRANSPORT" default:"tcp"`
+	// JitterDelay of waiting for reordered udp packets before packet is lost
+	JitterDelay time.Duration `envconfig:"JITTER_DELAY" default:"60ms"`
+	// Storage of received audio signal: list - unbounded, played audio is dropped unless storage is created for replay, ring - bounded by StorageCapacity,
+	// disk - files in StorageDir kept after restart of player
+	Storage         string        `envconfig:"STORAGE" default:"list"`
+	StorageDir      string        `envconfig:"STORAGE_DIR" default:"storage"`
+	StorageCapacity time.Duration `envconfig:"STORAGE_CAPACITY" default:"10s"`
+	// StorageOverflow policy of full ring storage: block - receiving waits for playing, drop - oldest audio is dropped
+	StorageOverflow string `envconfig:"STORAGE_OVERFLOW" default:"block"`
+	// Device of playing: alsa - sound card, file - wav files in DeviceDir named by device, null - signal is dropped
+	Device    string `envconfig:"DEVICE" default:"alsa"`
+	DeviceDir string `envconfig:"DEVICE_DIR" default:"devices"`
+
+	// TLSCert and TLSKey of player, server must present certificate signed by TLSCA, empty - no tls
+	TLSCert string `envconfig:"TLS_CERT"`
+	TLSKey  string `envconfig:"TLS_KEY"`
+	TLSCA   string `envconfig:"TLS_CA"`
+	// TLSAllowed names of server certificates allowed to control player, empty - any certificate signed by TLSCA
+	TLSAllowed []string `envconfig:"TLS_ALLOWED"`
+}
+
+const (
+	transportStream = "stream"
+	transportUDP    = "udp"
+
+	storageRing = "ring"
+	storageDisk = "disk"
+
+	deviceFile = "file"
+	deviceNull = "null"
+)
+
+type storageCreator interface {
+	Create(uuid string) (io.ReadWriteCloser, error)
+}
+
+type playbackDevice interface {
+	Open(deviceName string, channels, rate, bitsPerSample, audioFormat int) (playback.Output, error)
+	Devices() ([]pcm.Device, error)
+}
+
+type audioTransport interface {
+	Receive(ctx context.Context, receivePort string, w io.Writer) error
 }
 
 func main() {
@@ -37,20 +94,50 @@ func main() {
 		os.Exit(1)
 	}
 
-	tcp := tcp.NewTCP(cfg.UDPBuffSize)
+	var transport audioTransport = tcp.NewTCP(cfg.UDPBuffSize)
+	switch cfg.Transport {
+	case transportStream:
+		transport = stream.NewStream(cfg.UDPBuffSize)
+	case transportUDP:
+		transport = udp.NewUDP(cfg.UDPBuffSize, cfg.JitterDelay)
+	}
 
 	converter := converter.NewConverter()
+
+	var device playbackDevice = playback.NewALSA(converter)
+	switch cfg.Device {
+	case deviceFile:
+		if device, err = playback.NewFile(wav.NewWAV(), cfg.DeviceDir); err != nil {
+			level.Error(logger).Log("msg", "failed to create device directory", "err", err)
+			os.Exit(1)
+		}
+	case deviceNull:
+		device = playback.NewNull()
+	}
+
 	playback := playback.NewPlayback(
+		device,
 		converter,
 		cfg.UDPBuffSize,
+		logger,
 	)
 
-	storage := storage.NewStorage()
+	var storageCreator storageCreator = storage.NewStorage()
+	switch cfg.Storage {
+	case storageRing:
+		storageCreator = storage.NewRing(cfg.StorageCapacity, cfg.StorageOverflow)
+	case storageDisk:
+		if storageCreator, err = storage.NewDisk(cfg.StorageDir); err != nil {
+			level.Error(logger).Log("msg", "failed to create storage directory", "err", err)
+			os.Exit(1)
+		}
+	}
 
 	p4r := player.NewPlayer(
-		tcp,
+		transport,
 		playback,
-		storage,
+		storageCreator,
+		codec.NewCodecs(),
 	)
 	p4r = player.NewLoggerMiddleware(logger, p4r)
 
@@ -61,8 +148,18 @@ func main() {
 	}
 	defer lis.Close()
 
-	server := grpc.NewServer()
+	var options []grpc.ServerOption
+	if cfg.TLSCert != "" {
+		creds, err := mtls.ServerCredentials(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA, cfg.TLSAllowed)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to load tls credentials", "err", err)
+			os.Exit(1)
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(options...)
 	player.RegisterPlayerServer(server, p4r)
+	healthpb.RegisterHealthServer(server, health.NewServer())
 
 	go server.Serve(lis)
 	level.Info(logger).Log("msg", "player start", "port", cfg.Port)
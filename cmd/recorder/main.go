@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io"
 	"net"
 	"os"
 	"os/signal"
@@ -10,16 +11,59 @@ import (
 	"github.com/go-kit/kit/log/level"
 	"github.com/kelseyhightower/envconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"audio-service/pkg/capture"
+	"audio-service/pkg/codec"
 	"audio-service/pkg/converter"
+	"audio-service/pkg/mtls"
+	"audio-service/pkg/pcm"
 	"audio-service/pkg/recorder"
+	"audio-service/pkg/resampler"
+	"audio-service/pkg/stream"
 	"audio-service/pkg/tcp"
+	"audio-service/pkg/udp"
+	"audio-service/pkg/wav"
 )
 
 type configuration struct {
 	Port        string `envconfig:"PORT" default:"8080"`
 	UDPBuffSize int    `envconfig:"UDP_BUFF_SIZE" default:"1024"`
+	// Transport of audio signal: tcp - raw bytes, stream - packets with sequence number, timestamp and format,
+	// udp - packets of stream over udp unicast or multicast
+	Transport string `envconfig:"TRANSPORT" default:"tcp"`
+	// Device of recording: alsa - sound card, tone - sine with ToneFrequency, noise - white noise,
+	// file - wav DeviceFile in loop, null - silence
+	Device        string  `envconfig:"DEVICE" default:"alsa"`
+	ToneFrequency float64 `envconfig:"TONE_FREQUENCY" default:"440"`
+	DeviceFile    string  `envconfig:"DEVICE_FILE" default:"test.wav"`
+
+	// TLSCert and TLSKey of recorder, server must present certificate signed by TLSCA, empty - no tls
+	TLSCert string `envconfig:"TLS_CERT"`
+	TLSKey  string `envconfig:"TLS_KEY"`
+	TLSCA   string `envconfig:"TLS_CA"`
+	// TLSAllowed names of server certificates allowed to control recorder, empty - any certificate signed by TLSCA
+	TLSAllowed []string `envconfig:"TLS_ALLOWED"`
+}
+
+const (
+	transportStream = "stream"
+	transportUDP    = "udp"
+
+	deviceTone  = "tone"
+	deviceNoise = "noise"
+	deviceFile  = "file"
+	deviceNull  = "null"
+)
+
+type captureDevice interface {
+	Open(deviceName string, channels, rate, bitsPerSample, audioFormat, size int) (capture.Input, error)
+	Devices() ([]pcm.Device, error)
+}
+
+type audioTransport interface {
+	TurnOnSender(dstAddr string) (io.WriteCloser, error)
 }
 
 func main() {
@@ -36,13 +80,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	tcp := tcp.NewTCP(cfg.UDPBuffSize)
+	var transport audioTransport = tcp.NewTCP(cfg.UDPBuffSize)
+	switch cfg.Transport {
+	case transportStream:
+		transport = stream.NewStream(cfg.UDPBuffSize)
+	case transportUDP:
+		transport = udp.NewUDP(cfg.UDPBuffSize, 0)
+	}
 
 	converter := converter.NewConverter()
-	capture := capture.NewCapture(converter, cfg.UDPBuffSize)
+
+	var device captureDevice = capture.NewALSA(converter)
+	switch cfg.Device {
+	case deviceTone:
+		device = capture.NewTone(converter, cfg.ToneFrequency)
+	case deviceNoise:
+		device = capture.NewNoise(converter)
+	case deviceFile:
+		if device, err = capture.NewFile(wav.NewWAV(), resampler.NewResampler(converter), cfg.DeviceFile); err != nil {
+			level.Error(logger).Log("msg", "failed to load device file", "err", err)
+			os.Exit(1)
+		}
+	case deviceNull:
+		device = capture.NewNull(converter)
+	}
+
+	capture := capture.NewCapture(device, cfg.UDPBuffSize)
 	r5r := recorder.NewRecorder(
-		tcp,
+		transport,
 		capture,
+		codec.NewCodecs(),
 	)
 	r5r = recorder.NewLoggerMiddleware(logger, r5r)
 
@@ -53,8 +120,18 @@ func main() {
 	}
 	defer lis.Close()
 
-	server := grpc.NewServer()
+	var options []grpc.ServerOption
+	if cfg.TLSCert != "" {
+		creds, err := mtls.ServerCredentials(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA, cfg.TLSAllowed)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to load tls credentials", "err", err)
+			os.Exit(1)
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(options...)
 	recorder.RegisterRecorderServer(server, r5r)
+	healthpb.RegisterHealthServer(server, health.NewServer())
 
 	go server.Serve(lis)
 	level.Info(logger).Log("msg", "recorder start", "port", cfg.Port)
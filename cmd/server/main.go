@@ -1,19 +1,36 @@
 package main
 
 import (
+	"context"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
 
+	"audio-service/pkg/audio"
+	"audio-service/pkg/codec"
+	"audio-service/pkg/converter"
+	"audio-service/pkg/cron"
+	"audio-service/pkg/flac"
+	"audio-service/pkg/mixer"
+	"audio-service/pkg/mp3"
+	"audio-service/pkg/mtls"
+	"audio-service/pkg/ogg"
 	"audio-service/pkg/player"
+	"audio-service/pkg/pool"
 	"audio-service/pkg/recorder"
+	"audio-service/pkg/resampler"
 	"audio-service/pkg/server"
 	"audio-service/pkg/server/httpserver"
+	"audio-service/pkg/stream"
 	"audio-service/pkg/tcp"
+	"audio-service/pkg/udp"
 	"audio-service/pkg/wav"
 )
 
@@ -25,11 +42,46 @@ type configuration struct {
 	RecorderPort string `envconfig:"RECODER_PORT" default:"8080"`
 
 	UDPBuffSize int `envconfig:"UDP_BUF_SIZE" default:"1024"`
+	// Transport of audio signal: tcp - raw bytes, stream - packets with sequence number, timestamp and format,
+	// udp - packets of stream over udp unicast or multicast
+	Transport string `envconfig:"TRANSPORT" default:"tcp"`
+	// JitterDelay of waiting for reordered udp packets before packet is lost
+	JitterDelay time.Duration `envconfig:"JITTER_DELAY" default:"60ms"`
+
+	// Codecs of signal to players and from recorders in order of preference: pcm, rice - lossless, adpcm - lossy
+	Codecs []string `envconfig:"CODECS" default:"pcm"`
+
+	ScheduleFile string `envconfig:"SCHEDULE_FILE" default:"schedule.json"`
+
+	// RPCTimeout deadline of rpc call to players and recorders
+	RPCTimeout time.Duration `envconfig:"RPC_TIMEOUT" default:"10s"`
+	// RPCIdleTimeout of unused connection to player or recorder
+	RPCIdleTimeout time.Duration `envconfig:"RPC_IDLE_TIMEOUT" default:"5m"`
+	// RPCMaxBackoff max delay between attempts to reconnect to player or recorder
+	RPCMaxBackoff time.Duration `envconfig:"RPC_MAX_BACKOFF" default:"30s"`
+	// TLSCert and TLSKey of server for connections to players and recorders, their certificates are verified by TLSCA,
+	// empty - no tls
+	TLSCert string `envconfig:"TLS_CERT"`
+	TLSKey  string `envconfig:"TLS_KEY"`
+	TLSCA   string `envconfig:"TLS_CA"`
+
+	// APIKeys of http api with roles: viewer, operator or admin, empty - requests are not authorized
+	APIKeys map[string]string `envconfig:"API_KEYS"`
 
 	AddrLayout   string `envconfig:"ADDRESS_LAYOUT" default:"%s:%s"`
 	DeviceLayout string `envconfig:"DEVICE_LAYOUT" default:"%s:%s"`
 }
 
+const (
+	transportStream = "stream"
+	transportUDP    = "udp"
+)
+
+type audioTransport interface {
+	Send(ctx context.Context, dstAddr string, r io.Reader) error
+	Receive(ctx context.Context, receivePort string, w io.Writer) error
+}
+
 func main() {
 	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout))
 	level.Info(logger).Log("msg", "initializing")
@@ -44,20 +96,74 @@ func main() {
 	}
 
 	wav := wav.NewWAV()
+	flac := flac.NewFLAC()
+	audio := audio.NewAudio(
+		[]audio.Encoder{
+			wav,
+			flac,
+		},
+		[]audio.Decoder{
+			wav,
+			mp3.NewMP3(),
+			flac,
+			ogg.NewOGG(),
+		},
+	)
+	converter := converter.NewConverter()
+	mixer := mixer.NewMixer(converter)
+	resampler := resampler.NewResampler(converter)
+	scheduler, err := cron.NewScheduler(cfg.ScheduleFile)
+	if err != nil {
+		level.Error(logger).Log("msg", "failed to load schedule", "err", err)
+		os.Exit(1)
+	}
+	defer scheduler.Stop()
+	security := grpc.WithInsecure()
+	if cfg.TLSCert != "" {
+		creds, err := mtls.ClientCredentials(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA)
+		if err != nil {
+			level.Error(logger).Log("msg", "failed to load tls credentials", "err", err)
+			os.Exit(1)
+		}
+		security = grpc.WithTransportCredentials(creds)
+	}
+	conns := pool.NewPool(
+		cfg.RPCTimeout,
+		cfg.RPCIdleTimeout,
+		cfg.RPCMaxBackoff,
+		security,
+	)
+	defer conns.Close()
 	player := player.NewClient(
 		cfg.AddrLayout,
 		cfg.PlayerPort,
+		conns,
 	)
 	recorder := recorder.NewClient(
 		cfg.AddrLayout,
 		cfg.RecorderPort,
+		conns,
 	)
-	tcp := tcp.NewTCP(cfg.UDPBuffSize)
+	var transport audioTransport = tcp.NewTCP(cfg.UDPBuffSize)
+	switch cfg.Transport {
+	case transportStream:
+		transport = stream.NewStream(cfg.UDPBuffSize)
+	case transportUDP:
+		transport = udp.NewUDP(cfg.UDPBuffSize, cfg.JitterDelay)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	svc := server.NewServer(
-		wav,
+		ctx,
+		audio,
+		mixer,
+		resampler,
+		scheduler,
 		recorder,
 		player,
-		tcp,
+		transport,
+		codec.NewCodecs(),
+		cfg.Codecs,
 
 		cfg.ServerIP,
 		cfg.AddrLayout,
@@ -65,7 +171,17 @@ func main() {
 	)
 	svc = server.NewLoggerMiddleware(svc, logger)
 
-	server := httpserver.NewServer(svc)
+	keys := make(map[string]httpserver.Role, len(cfg.APIKeys))
+	for key, name := range cfg.APIKeys {
+		if keys[key], err = httpserver.ParseRole(name); err != nil {
+			level.Error(logger).Log("msg", "failed to load api keys", "role", name, "err", err)
+			os.Exit(1)
+		}
+	}
+	if len(keys) == 0 {
+		level.Warn(logger).Log("msg", "api keys are not set, requests are not authorized and profiling is disabled")
+	}
+	server := httpserver.NewServer(svc, keys)
 
 	go func() {
 		level.Info(logger).Log("msg", "start server", "port", cfg.Port)
@@ -5,12 +5,16 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
 
+	"audio-service/pkg/codec"
 	"audio-service/pkg/player"
+	"audio-service/pkg/pool"
 	"audio-service/pkg/recorder"
 	"audio-service/pkg/server"
 	"audio-service/pkg/tcp"
@@ -25,6 +29,13 @@ type configuration struct {
 
 	UDPBuffSize int `envconfig:"UDP_BUF_SIZE" default:"1024"`
 
+	// RPCTimeout deadline of rpc call to players and recorders
+	RPCTimeout time.Duration `envconfig:"RPC_TIMEOUT" default:"10s"`
+	// RPCIdleTimeout of unused connection to player or recorder
+	RPCIdleTimeout time.Duration `envconfig:"RPC_IDLE_TIMEOUT" default:"5m"`
+	// RPCMaxBackoff max delay between attempts to reconnect to player or recorder
+	RPCMaxBackoff time.Duration `envconfig:"RPC_MAX_BACKOFF" default:"30s"`
+
 	AddrLayout   string `envconfig:"ADDRESS_LAYOUT" default:"%s:%s"`
 	DeviceLayout string `envconfig:"DEVICE_LAYOUT" default:"%s:%s"`
 }
@@ -43,27 +54,43 @@ func main() {
 	}
 
 	wav := wav.NewWAV()
+	conns := pool.NewPool(
+		cfg.RPCTimeout,
+		cfg.RPCIdleTimeout,
+		cfg.RPCMaxBackoff,
+		// example runs without tls
+		grpc.WithInsecure(),
+	)
+	defer conns.Close()
 	player := player.NewClient(
 		cfg.AddrLayout,
 		cfg.PlayerPort,
+		conns,
 	)
 	recorder := recorder.NewClient(
 		cfg.AddrLayout,
 		cfg.RecorderPort,
+		conns,
 	)
 	tcp := tcp.NewTCP(cfg.UDPBuffSize)
 	svc := server.NewServer(
+		context.Background(),
 		wav,
+		nil,
+		nil,
+		nil,
 		recorder,
 		player,
 		tcp,
+		codec.NewCodecs(),
+		nil,
 
 		cfg.ServerIP,
 		cfg.AddrLayout,
 		cfg.DeviceLayout,
 	)
 	svc = server.NewLoggerMiddleware(svc, logger)
-	uuid, _ := svc.PlayFromRecorder(context.Background(), "127.0.0.1", "8083", "hw:1,0", 2, 44100, "127.0.0.1", "hw:0,0")
+	uuid, _ := svc.PlayFromRecorder(context.Background(), "127.0.0.1", "8083", "hw:1,0", 2, 44100, 16, 1, "127.0.0.1", "hw:0,0")
 	level.Info(logger).Log("msg", "server start")
 
 	c := make(chan os.Signal, 1)
@@ -7,12 +7,18 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
 
+	"audio-service/pkg/codec"
+	"audio-service/pkg/converter"
 	"audio-service/pkg/player"
+	"audio-service/pkg/pool"
+	"audio-service/pkg/resampler"
 	"audio-service/pkg/server"
 	"audio-service/pkg/tcp"
 	"audio-service/pkg/wav"
@@ -26,6 +32,13 @@ type configuration struct {
 
 	UDPBuffSize int `envconfig:"UDP_BUF_SIZE" default:"1024"`
 
+	// RPCTimeout deadline of rpc call to players and recorders
+	RPCTimeout time.Duration `envconfig:"RPC_TIMEOUT" default:"10s"`
+	// RPCIdleTimeout of unused connection to player or recorder
+	RPCIdleTimeout time.Duration `envconfig:"RPC_IDLE_TIMEOUT" default:"5m"`
+	// RPCMaxBackoff max delay between attempts to reconnect to player or recorder
+	RPCMaxBackoff time.Duration `envconfig:"RPC_MAX_BACKOFF" default:"30s"`
+
 	AddrLayout   string `envconfig:"ADDRESS_LAYOUT" default:"%s:%s"`
 	DeviceLayout string `envconfig:"DEVICE_LAYOUT" default:"%s:%s"`
 }
@@ -74,16 +87,32 @@ func main() {
 	}
 
 	wav := wav.NewWAV()
+	resampler := resampler.NewResampler(converter.NewConverter())
+	conns := pool.NewPool(
+		cfg.RPCTimeout,
+		cfg.RPCIdleTimeout,
+		cfg.RPCMaxBackoff,
+		// example runs without tls
+		grpc.WithInsecure(),
+	)
+	defer conns.Close()
 	player := player.NewClient(
 		cfg.AddrLayout,
 		cfg.PlayerPort,
+		conns,
 	)
 	tcp := tcp.NewTCP(cfg.UDPBuffSize)
 	svc := server.NewServer(
+		context.Background(),
 		wav,
 		nil,
+		resampler,
+		nil,
+		nil,
 		player,
 		tcp,
+		codec.NewCodecs(),
+		nil,
 
 		cfg.ServerIP,
 		cfg.AddrLayout,
@@ -102,7 +131,7 @@ func main() {
 			fmt.Printf("player num %v not exist\n", num)
 		}
 		if !p.Start {
-			if uuid, _, _, _, err := svc.FilePlay(context.Background(), p.File, p.IP, p.Port, p.Device); err == nil {
+			if uuid, _, _, _, err := svc.FilePlay(context.Background(), p.File, p.IP, p.Port, p.Device, 0, 0); err == nil {
 				p.UUID = uuid
 				p.Start = true
 				playerConf[num] = p
@@ -5,11 +5,15 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/kit/log"
 	"github.com/go-kit/kit/log/level"
 	"github.com/kelseyhightower/envconfig"
+	"google.golang.org/grpc"
 
+	"audio-service/pkg/codec"
+	"audio-service/pkg/pool"
 	"audio-service/pkg/recorder"
 	"audio-service/pkg/server"
 	"audio-service/pkg/tcp"
@@ -24,6 +28,13 @@ type configuration struct {
 
 	UDPBuffSize int `envconfig:"UDP_BUF_SIZE" default:"1024"`
 
+	// RPCTimeout deadline of rpc call to players and recorders
+	RPCTimeout time.Duration `envconfig:"RPC_TIMEOUT" default:"10s"`
+	// RPCIdleTimeout of unused connection to player or recorder
+	RPCIdleTimeout time.Duration `envconfig:"RPC_IDLE_TIMEOUT" default:"5m"`
+	// RPCMaxBackoff max delay between attempts to reconnect to player or recorder
+	RPCMaxBackoff time.Duration `envconfig:"RPC_MAX_BACKOFF" default:"30s"`
+
 	AddrLayout   string `envconfig:"ADDRESS_LAYOUT" default:"%s:%s"`
 	DeviceLayout string `envconfig:"DEVICE_LAYOUT" default:"%s:%s"`
 
@@ -44,16 +55,31 @@ func main() {
 	}
 
 	wav := wav.NewWAV()
+	conns := pool.NewPool(
+		cfg.RPCTimeout,
+		cfg.RPCIdleTimeout,
+		cfg.RPCMaxBackoff,
+		// example runs without tls
+		grpc.WithInsecure(),
+	)
+	defer conns.Close()
 	recorder := recorder.NewClient(
 		cfg.AddrLayout,
 		cfg.RecorderPort,
+		conns,
 	)
 	tcp := tcp.NewTCP(cfg.UDPBuffSize)
 	svc := server.NewServer(
+		context.Background(),
 		wav,
+		nil,
+		nil,
+		nil,
 		recorder,
 		nil,
 		tcp,
+		codec.NewCodecs(),
+		nil,
 
 		cfg.ServerIP,
 		cfg.AddrLayout,
@@ -66,7 +92,7 @@ func main() {
 	pwd, _ := os.Getwd()
 	file := pwd + "/example/record-file/test.wav"
 	svc = server.NewLoggerMiddleware(svc, logger)
-	svc.StartFileRecording(context.Background(), recorderIP, recorderDevice, 2, 44100, receivePort, file)
+	svc.StartFileRecording(context.Background(), recorderIP, recorderDevice, 2, 44100, 16, 1, receivePort, file, "", 0, 0)
 	level.Info(logger).Log("msg", "server start")
 
 	c := make(chan os.Signal, 1)
@@ -9,7 +9,10 @@ require (
 	github.com/geoirb/wav v0.0.0-20201024112027-9b8b6075c12c
 	github.com/go-kit/kit v0.10.0
 	github.com/golang/protobuf v1.4.3
+	github.com/hajimehoshi/go-mp3 v0.3.1
+	github.com/jfreymuth/oggvorbis v1.0.5
 	github.com/kelseyhightower/envconfig v1.4.0
+	github.com/mewkiz/flac v1.0.7
 	github.com/myesui/uuid v1.0.0 // indirect
 	github.com/twinj/uuid v1.0.0
 	github.com/valyala/fasthttp v1.17.0
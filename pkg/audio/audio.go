@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrUnknownFormat no decoder matches data of file or no encoder has extension of file
+var ErrUnknownFormat = errors.New("unknown format of file")
+
+// Decoder of audio files
+type Decoder interface {
+	// Match return true if data is file of decoder format, it is checked by magic bytes
+	Match(data []byte) bool
+	Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error)
+}
+
+// Encoder of audio files
+type Encoder interface {
+	// Extension of files with dot
+	Extension() string
+	Writer(fileName string, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (io.WriteCloser, error)
+}
+
+// Audio files of registered formats
+type Audio struct {
+	encoders []Encoder
+	decoders []Decoder
+}
+
+// Reader of samples of file decoded by first decoder matching data
+func (a *Audio) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error) {
+	for _, d := range a.decoders {
+		if d.Match(data) {
+			return d.Reader(data)
+		}
+	}
+	err = ErrUnknownFormat
+	return
+}
+
+// Writer of file in format of encoder with extension of fileName, first encoder is used for other files
+func (a *Audio) Writer(fileName string, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (io.WriteCloser, error) {
+	e, err := a.encoder(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return e.Writer(fileName, channels, rate, bitsPerSample, audioFormat)
+}
+
+func (a *Audio) encoder(fileName string) (Encoder, error) {
+	for _, e := range a.encoders {
+		if strings.HasSuffix(strings.ToLower(fileName), e.Extension()) {
+			return e, nil
+		}
+	}
+	if len(a.encoders) == 0 {
+		return nil, ErrUnknownFormat
+	}
+	return a.encoders[0], nil
+}
+
+// NewAudio files are written by encoders and read by decoders in order of registration
+func NewAudio(encoders []Encoder, decoders []Decoder) *Audio {
+	return &Audio{
+		encoders: encoders,
+		decoders: decoders,
+	}
+}
@@ -0,0 +1,123 @@
+//go:build cgo
+// +build cgo
+
+package capture
+
+import (
+	alsa "github.com/cocoonlife/goalsa"
+
+	"audio-service/pkg/pcm"
+)
+
+var formatList = map[int]alsa.Format{
+	8:  alsa.FormatS8,
+	16: alsa.FormatS16LE,
+	24: alsa.FormatS24LE,
+	32: alsa.FormatS32LE,
+}
+
+// ALSA opener of sound card devices
+type ALSA struct {
+	converter converter
+}
+
+type alsaInput struct {
+	device *alsa.CaptureDevice
+	read   func() ([]byte, error)
+}
+
+// Read samples from device
+func (i *alsaInput) Read() ([]byte, error) {
+	return i.read()
+}
+
+// Close device
+func (i *alsaInput) Close() error {
+	i.device.Close()
+	return nil
+}
+
+// Open alsa capture device
+func (a *ALSA) Open(deviceName string, channels, rate, bitsPerSample, audioFormat, size int) (Input, error) {
+	device, err := alsa.NewCaptureDevice(
+		deviceName,
+		channels,
+		alsaFormat(bitsPerSample, audioFormat),
+		rate,
+		alsa.BufferParams{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &alsaInput{
+		device: device,
+		read:   a.reader(device, bitsPerSample, audioFormat, size),
+	}, nil
+}
+
+// reader return function reading size samples from device in format and converting them to bytes
+func (a *ALSA) reader(in *alsa.CaptureDevice, bitsPerSample, audioFormat, size int) func() ([]byte, error) {
+	if audioFormat == audioFormatFloat {
+		samples := make([]float32, size)
+		return func() ([]byte, error) {
+			n, err := in.Read(samples)
+			return a.converter.Float32ToByte(samples[:n]), err
+		}
+	}
+
+	switch bitsPerSample {
+	case 8:
+		samples := make([]int8, size)
+		return func() ([]byte, error) {
+			n, err := in.Read(samples)
+			return a.converter.Int8ToByte(samples[:n]), err
+		}
+	case 24:
+		samples := make([]int32, size)
+		return func() ([]byte, error) {
+			n, err := in.Read(samples)
+			return a.converter.Int24ToByte(samples[:n]), err
+		}
+	case 32:
+		samples := make([]int32, size)
+		return func() ([]byte, error) {
+			n, err := in.Read(samples)
+			return a.converter.Int32ToByte(samples[:n]), err
+		}
+	}
+	samples := make([]int16, size)
+	return func() ([]byte, error) {
+		n, err := in.Read(samples)
+		return a.converter.ToByte(samples[:n]), err
+	}
+}
+
+// Devices return capture pcm devices with supported rates, channels and formats, busy devices are listed without them
+func (a *ALSA) Devices() ([]pcm.Device, error) {
+	return pcm.List(pcm.Capture, probe)
+}
+
+// probe open device with configuration and close it
+func probe(name string, channels int, format pcm.Format, rate int) bool {
+	in, err := alsa.NewCaptureDevice(name, channels, alsaFormat(format.BitsPerSample, format.AudioFormat), rate, alsa.BufferParams{})
+	if err != nil {
+		return false
+	}
+	in.Close()
+	return true
+}
+
+// alsaFormat of samples with bitsPerSample or float samples
+func alsaFormat(bitsPerSample, audioFormat int) alsa.Format {
+	if audioFormat == audioFormatFloat {
+		return alsa.FormatFloatLE
+	}
+	return formatList[bitsPerSample]
+}
+
+// NewALSA ...
+func NewALSA(converter converter) *ALSA {
+	return &ALSA{
+		converter: converter,
+	}
+}
@@ -4,29 +4,58 @@ import (
 	"context"
 	"io"
 
-	alsa "github.com/cocoonlife/goalsa"
+	"audio-service/pkg/pcm"
 )
 
+// audioFormat of float samples as in wav header
+const audioFormatFloat = 3
+
+// bitsPerSampleList supported by devices
+var bitsPerSampleList = map[int]bool{8: true, 16: true, 24: true, 32: true}
+
 type converter interface {
 	ToByte([]int16) []byte
+	Int8ToByte([]int8) []byte
+	Int24ToByte([]int32) []byte
+	Int32ToByte([]int32) []byte
+	Float32ToByte([]float32) []byte
+	FromFloat64(src []float64, bitsPerSample, audioFormat int) []byte
+}
+
+// Input of samples from device, read blocks until device records samples
+type Input interface {
+	Read() ([]byte, error)
+	Close() error
+}
+
+// opener of devices: sound card or its replacement for working without sound card
+type opener interface {
+	Open(deviceName string, channels, rate, bitsPerSample, audioFormat, size int) (Input, error)
+	Devices() ([]pcm.Device, error)
 }
 
 // Capture device
 type Capture struct {
-	converter converter
+	opener opener
 
 	buffSize int
 }
 
-// Record audio signals
-func (c *Capture) Record(ctx context.Context, deviceName string, channels, rate int, dest io.WriteCloser) (err error) {
-	in, err := alsa.NewCaptureDevice(
-		deviceName,
-		channels,
-		alsa.FormatS16LE,
-		rate,
-		alsa.BufferParams{},
-	)
+// Devices return capture devices with supported rates, channels and formats
+func (c *Capture) Devices() ([]pcm.Device, error) {
+	return c.opener.Devices()
+}
+
+// Record audio signals.
+// Samples are written in dest as little-endian signed integer with bitsPerSample (8 bits samples are unsigned as in wav)
+// or float32 if audioFormat is 3.
+func (c *Capture) Record(ctx context.Context, deviceName string, channels, rate, bitsPerSample, audioFormat int, dest io.WriteCloser) (err error) {
+	if !bitsPerSampleList[bitsPerSample] || audioFormat == audioFormatFloat && bitsPerSample != 32 {
+		err = ErrFormatNotExist
+		return
+	}
+
+	in, err := c.opener.Open(deviceName, channels, rate, bitsPerSample, audioFormat, c.buffSize)
 	if err != nil {
 		return
 	}
@@ -36,14 +65,13 @@ func (c *Capture) Record(ctx context.Context, deviceName string, channels, rate
 			in.Close()
 			dest.Close()
 		}()
-		samples := make([]int16, c.buffSize)
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			default:
-				if n, err := in.Read(samples); err == nil {
-					if _, err := dest.Write(c.converter.ToByte(samples[:n])); err != nil {
+				if samples, err := in.Read(); err == nil {
+					if _, err := dest.Write(samples); err != nil {
 						return
 					}
 				}
@@ -53,10 +81,10 @@ func (c *Capture) Record(ctx context.Context, deviceName string, channels, rate
 	return
 }
 
-// NewCapture ..
-func NewCapture(converter converter, buffSize int) *Capture {
+// NewCapture devices are opened by opener, buffSize is samples read from device at once
+func NewCapture(opener opener, buffSize int) *Capture {
 	return &Capture{
-		converter: converter,
-		buffSize:  buffSize,
+		opener:   opener,
+		buffSize: buffSize,
 	}
 }
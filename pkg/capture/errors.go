@@ -0,0 +1,12 @@
+package capture
+
+import (
+	"errors"
+)
+
+var (
+	// ErrFormatNotExist not exist format for alsa capture device
+	ErrFormatNotExist = errors.New("format for alsa not exist")
+	// ErrEmptyFile wav file of file device has no samples
+	ErrEmptyFile = errors.New("file has no samples")
+)
@@ -0,0 +1,116 @@
+package capture
+
+import (
+	"io"
+	"io/ioutil"
+
+	"audio-service/pkg/pacer"
+	"audio-service/pkg/pcm"
+)
+
+type wavReader interface {
+	Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error)
+}
+
+type resampler interface {
+	Convert(r io.Reader, channels, rate, bitsPerSample, audioFormat, dstChannels, dstRate, dstBitsPerSample, dstAudioFormat int) io.Reader
+}
+
+// File opener of devices recording wav file in loop in place of sound card
+type File struct {
+	wav       wavReader
+	resampler resampler
+	fileName  string
+	data      []byte
+}
+
+type fileInput struct {
+	// open return reader of file from beginning in format of device
+	open   func() (io.Reader, error)
+	r      io.Reader
+	buffer []byte
+	pacer  *pacer.Pacer
+
+	frameSize int
+}
+
+// Read samples of file in real time, file is read again from beginning at the end
+func (i *fileInput) Read() ([]byte, error) {
+	n := 0
+	for n < len(i.buffer) {
+		l, err := io.ReadFull(i.r, i.buffer[n:])
+		n += l
+		if err == nil {
+			break
+		}
+		if err != io.EOF && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+		if i.r, err = i.open(); err != nil {
+			return nil, err
+		}
+	}
+	i.pacer.Wait(n / i.frameSize)
+	return i.buffer[:n], nil
+}
+
+// Close ...
+func (i *fileInput) Close() error {
+	return nil
+}
+
+// Open device, samples of file are converted to format of device, size samples are read at once
+func (f *File) Open(deviceName string, channels, rate, bitsPerSample, audioFormat, size int) (Input, error) {
+	open := func() (io.Reader, error) {
+		r, fChannels, fRate, fBitsPerSample, fAudioFormat, err := f.wav.Reader(f.data)
+		if err != nil {
+			return nil, err
+		}
+		return f.resampler.Convert(r, int(fChannels), int(fRate), int(fBitsPerSample), int(fAudioFormat), channels, rate, bitsPerSample, audioFormat), nil
+	}
+	r, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	frameSize := channels * bitsPerSample / 8
+	size = size * bitsPerSample / 8
+	if size < frameSize {
+		size = frameSize
+	}
+	return &fileInput{
+		open:      open,
+		r:         r,
+		buffer:    make([]byte, size-size%frameSize),
+		pacer:     pacer.NewPacer(rate),
+		frameSize: frameSize,
+	}, nil
+}
+
+// Devices return virtual device, any name of device can be recorded
+func (f *File) Devices() ([]pcm.Device, error) {
+	return []pcm.Device{
+		pcm.Virtual("default", "wav file "+f.fileName),
+	}, nil
+}
+
+// NewFile file is read in memory, it must be wav file with samples
+func NewFile(wav wavReader, resampler resampler, fileName string) (*File, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	r, _, _, _, _, err := wav.Reader(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.ReadFull(r, make([]byte, 1)); err != nil {
+		return nil, ErrEmptyFile
+	}
+	return &File{
+		wav:       wav,
+		resampler: resampler,
+		fileName:  fileName,
+		data:      data,
+	}, nil
+}
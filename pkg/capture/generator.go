@@ -0,0 +1,112 @@
+package capture
+
+import (
+	"math"
+	"math/rand"
+
+	"audio-service/pkg/pacer"
+	"audio-service/pkg/pcm"
+)
+
+// amplitude of generated signal, headroom protects from clipping after mixing
+const amplitude = 0.5
+
+// Generator opener of devices generating test signal in place of sound card
+type Generator struct {
+	converter   converter
+	description string
+	frequency   float64
+	// signal return sample in range [-1, 1] at phase in range [0, 1) of period
+	signal func(phase float64) float64
+}
+
+type generatorInput struct {
+	converter     converter
+	signal        func(phase float64) float64
+	bitsPerSample int
+	audioFormat   int
+	channels      int
+
+	phase   float64
+	step    float64
+	samples []float64
+	pacer   *pacer.Pacer
+}
+
+// Read generated samples in real time, channels have the same signal
+func (i *generatorInput) Read() ([]byte, error) {
+	for frame := 0; frame < len(i.samples); frame += i.channels {
+		sample := i.signal(i.phase)
+		for channel := 0; channel < i.channels; channel++ {
+			i.samples[frame+channel] = sample
+		}
+		i.phase += i.step
+		i.phase -= math.Floor(i.phase)
+	}
+	i.pacer.Wait(len(i.samples) / i.channels)
+	return i.converter.FromFloat64(i.samples, i.bitsPerSample, i.audioFormat), nil
+}
+
+// Close ...
+func (i *generatorInput) Close() error {
+	return nil
+}
+
+// Open device, size samples are generated at once
+func (g *Generator) Open(deviceName string, channels, rate, bitsPerSample, audioFormat, size int) (Input, error) {
+	if size < channels {
+		size = channels
+	}
+	return &generatorInput{
+		converter:     g.converter,
+		signal:        g.signal,
+		bitsPerSample: bitsPerSample,
+		audioFormat:   audioFormat,
+		channels:      channels,
+
+		step:    g.frequency / float64(rate),
+		samples: make([]float64, size-size%channels),
+		pacer:   pacer.NewPacer(rate),
+	}, nil
+}
+
+// Devices return virtual device, any name of device can be recorded
+func (g *Generator) Devices() ([]pcm.Device, error) {
+	return []pcm.Device{
+		pcm.Virtual("default", g.description),
+	}, nil
+}
+
+// NewTone generator of sine with frequency in Hz
+func NewTone(converter converter, frequency float64) *Generator {
+	return &Generator{
+		converter:   converter,
+		description: "tone generator",
+		frequency:   frequency,
+		signal: func(phase float64) float64 {
+			return amplitude * math.Sin(2*math.Pi*phase)
+		},
+	}
+}
+
+// NewNoise generator of white noise
+func NewNoise(converter converter) *Generator {
+	return &Generator{
+		converter:   converter,
+		description: "noise generator",
+		signal: func(phase float64) float64 {
+			return amplitude * (2*rand.Float64() - 1)
+		},
+	}
+}
+
+// NewNull generator of silence
+func NewNull(converter converter) *Generator {
+	return &Generator{
+		converter:   converter,
+		description: "null device",
+		signal: func(phase float64) float64 {
+			return 0
+		},
+	}
+}
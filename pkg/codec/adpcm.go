@@ -0,0 +1,162 @@
+package codec
+
+import (
+	"encoding/binary"
+)
+
+// ADPCM name of lossy codec
+const ADPCM = "adpcm"
+
+const adpcmID = 2
+
+var (
+	adpcmIndex = [16]int{-1, -1, -1, -1, 2, 4, 6, 8, -1, -1, -1, -1, 2, 4, 6, 8}
+	adpcmStep  = [89]int32{
+		7, 8, 9, 10, 11, 12, 13, 14, 16, 17,
+		19, 21, 23, 25, 28, 31, 34, 37, 41, 45,
+		50, 55, 60, 66, 73, 80, 88, 97, 107, 118,
+		130, 143, 157, 173, 190, 209, 230, 253, 279, 307,
+		337, 371, 408, 449, 494, 544, 598, 658, 724, 796,
+		876, 963, 1060, 1166, 1282, 1411, 1552, 1707, 1878, 2066,
+		2272, 2499, 2749, 3024, 3327, 3660, 4026, 4428, 4871, 5358,
+		5894, 6484, 7132, 7845, 8630, 9493, 10442, 11487, 12635, 13899,
+		15289, 16818, 18500, 20350, 22385, 24623, 27086, 29794, 32767,
+	}
+)
+
+// adpcm lossy IMA ADPCM codec of 16 bits samples, 4 bits per sample.
+// Channel: first sample (2 bytes little-endian), step index (1 byte), 4 bits codes of next frames.
+type adpcm struct{}
+
+type adpcmState struct {
+	predictor int32
+	index     int
+}
+
+// Encode 16 bits samples
+func (c *adpcm) Encode(samples []byte, format Format) (data []byte, err error) {
+	if format.BitsPerSample != 16 || format.AudioFormat == formatFloat {
+		return nil, ErrNotSupported
+	}
+	values := toInt32(samples, format.BitsPerSample)
+	frames := len(values) / format.Channels
+
+	w := &bitWriter{}
+	for ch := 0; ch < format.Channels; ch++ {
+		state := adpcmState{
+			predictor: values[ch],
+			index:     initialIndex(values, ch, format.Channels),
+		}
+		header := make([]byte, 3)
+		binary.LittleEndian.PutUint16(header, uint16(state.predictor))
+		header[2] = byte(state.index)
+		w.data = append(w.data, header...)
+
+		for i := 1; i < frames; i++ {
+			w.writeBits(uint64(state.encode(values[i*format.Channels+ch])), 4)
+		}
+		w.bytes()
+	}
+	return w.data, nil
+}
+
+// Decode 16 bits samples
+func (c *adpcm) Decode(data []byte, format Format, frames int) (samples []byte, err error) {
+	if format.BitsPerSample != 16 || format.AudioFormat == formatFloat {
+		return nil, ErrNotSupported
+	}
+	values := make([]int32, frames*format.Channels)
+	// codes of channel are padded to whole byte
+	channelSize := 3 + frames/2
+	if len(data) != channelSize*format.Channels {
+		return nil, ErrWrongBlock
+	}
+
+	for ch := 0; ch < format.Channels; ch++ {
+		channel := data[ch*channelSize : (ch+1)*channelSize]
+		state := adpcmState{
+			predictor: int32(int16(binary.LittleEndian.Uint16(channel))),
+			index:     int(channel[2]),
+		}
+		if state.index >= len(adpcmStep) {
+			return nil, ErrWrongBlock
+		}
+		values[ch] = state.predictor
+
+		r := &bitReader{data: channel[3:]}
+		for i := 1; i < frames; i++ {
+			code, _ := r.readBits(4)
+			values[i*format.Channels+ch] = state.decode(byte(code))
+		}
+	}
+	return fromInt32(values, format.BitsPerSample), nil
+}
+
+// encode sample to 4 bits code, state is updated as by decoder
+func (s *adpcmState) encode(sample int32) (code byte) {
+	diff := sample - s.predictor
+	if diff < 0 {
+		code = 8
+		diff = -diff
+	}
+	step := adpcmStep[s.index]
+	for bit := byte(4); bit != 0; bit >>= 1 {
+		if diff >= step {
+			code |= bit
+			diff -= step
+		}
+		step >>= 1
+	}
+	s.decode(code)
+	return
+}
+
+// decode 4 bits code to sample
+func (s *adpcmState) decode(code byte) int32 {
+	step := adpcmStep[s.index]
+	delta := step >> 3
+	if code&4 != 0 {
+		delta += step
+	}
+	if code&2 != 0 {
+		delta += step >> 1
+	}
+	if code&1 != 0 {
+		delta += step >> 2
+	}
+	if code&8 != 0 {
+		s.predictor -= delta
+	} else {
+		s.predictor += delta
+	}
+	switch {
+	case s.predictor > 32767:
+		s.predictor = 32767
+	case s.predictor < -32768:
+		s.predictor = -32768
+	}
+
+	s.index += adpcmIndex[code]
+	switch {
+	case s.index < 0:
+		s.index = 0
+	case s.index >= len(adpcmStep):
+		s.index = len(adpcmStep) - 1
+	}
+	return s.predictor
+}
+
+// initialIndex of step close to difference of first samples of channel, block starts without adaptation
+func initialIndex(values []int32, ch, channels int) (index int) {
+	var diff int32
+	if len(values) > ch+channels {
+		diff = values[ch+channels] - values[ch]
+		if diff < 0 {
+			diff = -diff
+		}
+	}
+	for index < len(adpcmStep)-1 && adpcmStep[index] < diff {
+		index++
+	}
+	return
+}
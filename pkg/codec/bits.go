@@ -0,0 +1,103 @@
+package codec
+
+import (
+	"encoding/binary"
+)
+
+type bitWriter struct {
+	data []byte
+	acc  byte
+	n    uint
+}
+
+// writeBits write n low bits of v, high bit first
+func (w *bitWriter) writeBits(v uint64, n uint) {
+	for n > 0 {
+		n--
+		w.acc = w.acc<<1 | byte(v>>n&1)
+		if w.n++; w.n == 8 {
+			w.data = append(w.data, w.acc)
+			w.acc, w.n = 0, 0
+		}
+	}
+}
+
+// bytes return written bits, last byte is padded with zero bits
+func (w *bitWriter) bytes() []byte {
+	if w.n != 0 {
+		w.data = append(w.data, w.acc<<(8-w.n))
+		w.acc, w.n = 0, 0
+	}
+	return w.data
+}
+
+type bitReader struct {
+	data []byte
+	// pos in bits
+	pos int
+}
+
+// readBits read n bits, high bit first
+func (r *bitReader) readBits(n uint) (v uint64, err error) {
+	if r.pos+int(n) > len(r.data)*8 {
+		return 0, ErrWrongBlock
+	}
+	for ; n > 0; n-- {
+		v = v<<1 | uint64(r.data[r.pos/8]>>(7-uint(r.pos%8))&1)
+		r.pos++
+	}
+	return
+}
+
+// toInt32 convert interleaved integer samples to int32, 8 bits samples are unsigned
+func toInt32(data []byte, bitsPerSample int) (samples []int32) {
+	size := bitsPerSample / 8
+	samples = make([]int32, 0, len(data)/size)
+	for i := 0; i+size <= len(data); i += size {
+		var s int32
+		switch size {
+		case 1:
+			s = int32(data[i]) - 128
+		case 2:
+			s = int32(int16(binary.LittleEndian.Uint16(data[i:])))
+		case 3:
+			s = int32(uint32(data[i])<<8|uint32(data[i+1])<<16|uint32(data[i+2])<<24) >> 8
+		case 4:
+			s = int32(binary.LittleEndian.Uint32(data[i:]))
+		}
+		samples = append(samples, s)
+	}
+	return
+}
+
+// fromInt32 convert samples to bytes of integer samples in bitsPerSample
+func fromInt32(samples []int32, bitsPerSample int) (data []byte) {
+	size := bitsPerSample / 8
+	data = make([]byte, len(samples)*size)
+	for i, s := range samples {
+		b := data[i*size:]
+		switch size {
+		case 1:
+			b[0] = byte(s + 128)
+		case 2:
+			binary.LittleEndian.PutUint16(b, uint16(s))
+		case 3:
+			b[0], b[1], b[2] = byte(s), byte(s>>8), byte(s>>16)
+		case 4:
+			binary.LittleEndian.PutUint32(b, uint32(s))
+		}
+	}
+	return
+}
+
+// isInteger format of integer samples supported by codecs
+func isInteger(format Format) bool {
+	if format.AudioFormat == formatFloat {
+		return false
+	}
+	switch format.BitsPerSample {
+	case 8, 16, 24, 32:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,343 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrUnknownCodec codec with name is not registered
+	ErrUnknownCodec = errors.New("unknown codec")
+	// ErrNotSupported format of samples is not supported by codec, block is sent without compression
+	ErrNotSupported = errors.New("format is not supported")
+	// ErrWrongBlock block can not be decoded
+	ErrWrongBlock = errors.New("wrong block")
+)
+
+// PCM samples are sent as is without blocks, supported by all senders and receivers
+const PCM = "pcm"
+
+const (
+	// magic first byte of block, receiver finds next block by it after lost data
+	magic      = 0xa5
+	headerSize = 12
+	// rawID of block with not compressed samples
+	rawID = 0
+	// maxFrames in one block
+	maxFrames = 4096
+
+	formatPCM   = 1
+	formatFloat = 3
+)
+
+// Format of samples in block
+type Format struct {
+	Channels      int
+	BitsPerSample int
+	// AudioFormat as in wav header: 1 - PCM, 3 - IEEE float
+	AudioFormat int
+}
+
+func (f Format) frameSize() int {
+	if size := f.Channels * f.BitsPerSample / 8; size > 0 {
+		return size
+	}
+	return 1
+}
+
+// newFormat audioFormat is PCM if not set
+func newFormat(channels, bitsPerSample, audioFormat int) Format {
+	if audioFormat == 0 {
+		audioFormat = formatPCM
+	}
+	return Format{
+		Channels:      channels,
+		BitsPerSample: bitsPerSample,
+		AudioFormat:   audioFormat,
+	}
+}
+
+// Codec compress block of whole sample frames
+type Codec interface {
+	// Encode samples in format, ErrNotSupported if codec does not support format
+	Encode(samples []byte, format Format) (data []byte, err error)
+	// Decode frames of samples in format
+	Decode(data []byte, format Format, frames int) (samples []byte, err error)
+}
+
+// Codecs registered by name.
+// Encoded signal is sequence of blocks, each block is decoded independently of others.
+// Block header is 12 bytes:
+// magic, codec id, audioFormat, bitsPerSample, channels, reserved (1 byte each),
+// frames (2 big-endian), length of data (4 big-endian).
+type Codecs struct {
+	ids    map[string]byte
+	codecs map[byte]Codec
+}
+
+// Register codec with name and id of its blocks, id 0 is reserved for not compressed blocks
+func (c *Codecs) Register(name string, id byte, codec Codec) {
+	c.ids[name] = id
+	c.codecs[id] = codec
+}
+
+// Choose first supported codec from names in order of preference, PCM if none is supported
+func (c *Codecs) Choose(names []string) string {
+	for _, name := range names {
+		if _, isExist := c.ids[name]; isExist || name == PCM {
+			return name
+		}
+	}
+	return PCM
+}
+
+// Encoder of samples written to w in blocks of codec with name
+func (c *Codecs) Encoder(name string, w io.WriteCloser, channels, bitsPerSample, audioFormat int) (io.WriteCloser, error) {
+	if name == PCM || name == "" {
+		return w, nil
+	}
+	id, isExist := c.ids[name]
+	if !isExist {
+		return nil, ErrUnknownCodec
+	}
+	return &encoder{
+		w:      w,
+		id:     id,
+		codec:  c.codecs[id],
+		format: newFormat(channels, bitsPerSample, audioFormat),
+	}, nil
+}
+
+// Reader return blocks of codec with name encoded from samples of r.
+// Each read returns whole blocks if p is bigger than header and one frame.
+func (c *Codecs) Reader(name string, r io.Reader, channels, bitsPerSample, audioFormat int) (io.Reader, error) {
+	if name == PCM || name == "" {
+		return r, nil
+	}
+	id, isExist := c.ids[name]
+	if !isExist {
+		return nil, ErrUnknownCodec
+	}
+	return &reader{
+		r:      r,
+		id:     id,
+		codec:  c.codecs[id],
+		format: newFormat(channels, bitsPerSample, audioFormat),
+	}, nil
+}
+
+// Decoder of blocks written to decoder, samples are written to w
+func (c *Codecs) Decoder(name string, w io.WriteCloser) (io.WriteCloser, error) {
+	if name == PCM || name == "" {
+		return w, nil
+	}
+	if _, isExist := c.ids[name]; !isExist {
+		return nil, ErrUnknownCodec
+	}
+	return &decoder{
+		w:      w,
+		codecs: c.codecs,
+	}, nil
+}
+
+type encoder struct {
+	w      io.WriteCloser
+	id     byte
+	codec  Codec
+	format Format
+	// incomplete frame of last write
+	rest []byte
+}
+
+// Write samples, whole frames are encoded and written in blocks
+func (e *encoder) Write(data []byte) (n int, err error) {
+	frameSize := e.format.frameSize()
+	samples := append(e.rest, data...)
+	for len(samples) >= frameSize {
+		size := len(samples) - len(samples)%frameSize
+		if max := maxFrames * frameSize; size > max {
+			size = max
+		}
+		if _, err = e.w.Write(encodeBlock(e.id, e.codec, e.format, samples[:size])); err != nil {
+			return
+		}
+		samples = samples[size:]
+	}
+	e.rest = append([]byte(nil), samples...)
+	return len(data), nil
+}
+
+// Close w
+func (e *encoder) Close() error {
+	return e.w.Close()
+}
+
+type reader struct {
+	r      io.Reader
+	id     byte
+	codec  Codec
+	format Format
+
+	raw []byte
+	// bytes of incomplete frame at start of raw
+	rest int
+	// encoded block not read yet
+	block []byte
+}
+
+// Read encoded blocks
+func (r *reader) Read(p []byte) (n int, err error) {
+	if len(r.block) == 0 {
+		frameSize := r.format.frameSize()
+		size := len(p) - headerSize
+		size -= size % frameSize
+		if size < frameSize {
+			size = frameSize
+		}
+		if max := maxFrames * frameSize; size > max {
+			size = max
+		}
+		if len(r.raw) < size {
+			raw := make([]byte, size)
+			copy(raw, r.raw[:r.rest])
+			r.raw = raw
+		}
+
+		l := r.rest
+		for l < frameSize {
+			var m int
+			if m, err = r.r.Read(r.raw[l:size]); err != nil {
+				return
+			}
+			l += m
+		}
+		whole := l - l%frameSize
+		r.block = encodeBlock(r.id, r.codec, r.format, r.raw[:whole])
+		r.rest = copy(r.raw, r.raw[whole:l])
+	}
+	n = copy(p, r.block)
+	r.block = r.block[n:]
+	return
+}
+
+type decoder struct {
+	w      io.WriteCloser
+	codecs map[byte]Codec
+	// received data of incomplete block
+	data []byte
+}
+
+// Write encoded data, decoded samples of whole blocks are written to w.
+// Data not belonging to block is skipped.
+func (d *decoder) Write(p []byte) (n int, err error) {
+	data := append(d.data, p...)
+	for {
+		i := bytes.IndexByte(data, magic)
+		if i < 0 {
+			data = data[:0]
+			break
+		}
+		data = data[i:]
+		if len(data) < headerSize {
+			break
+		}
+
+		id, format, frames, length := parseHeader(data)
+		codec, isExist := d.codecs[id]
+		if (!isExist && id != rawID) || !isValid(data, format, frames, length) {
+			data = data[1:]
+			continue
+		}
+		if len(data) < headerSize+length {
+			break
+		}
+
+		samples, err := decodeBlock(id, codec, format, frames, data[headerSize:headerSize+length])
+		if err != nil {
+			data = data[1:]
+			continue
+		}
+		data = data[headerSize+length:]
+		if _, err = d.w.Write(samples); err != nil {
+			return 0, err
+		}
+	}
+	d.data = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// Close w
+func (d *decoder) Close() error {
+	return d.w.Close()
+}
+
+// encodeBlock samples by codec, block is not compressed if codec does not support format or does not reduce size
+func encodeBlock(id byte, codec Codec, format Format, samples []byte) []byte {
+	data, err := codec.Encode(samples, format)
+	if err != nil || len(data) >= len(samples) {
+		id, data = rawID, samples
+	}
+
+	block := make([]byte, headerSize+len(data))
+	block[0] = magic
+	block[1] = id
+	block[2] = byte(format.AudioFormat)
+	block[3] = byte(format.BitsPerSample)
+	block[4] = byte(format.Channels)
+	binary.BigEndian.PutUint16(block[6:], uint16(len(samples)/format.frameSize()))
+	binary.BigEndian.PutUint32(block[8:], uint32(len(data)))
+	copy(block[headerSize:], data)
+	return block
+}
+
+func parseHeader(block []byte) (id byte, format Format, frames, length int) {
+	id = block[1]
+	format = Format{
+		AudioFormat:   int(block[2]),
+		BitsPerSample: int(block[3]),
+		Channels:      int(block[4]),
+	}
+	frames = int(binary.BigEndian.Uint16(block[6:]))
+	length = int(binary.BigEndian.Uint32(block[8:]))
+	return
+}
+
+// isValid header of block, encoded data is never bigger than samples
+func isValid(block []byte, format Format, frames, length int) bool {
+	switch {
+	case block[5] != 0, format.Channels == 0, frames == 0, frames > maxFrames:
+		return false
+	case format.AudioFormat != formatPCM && format.AudioFormat != formatFloat:
+		return false
+	case format.BitsPerSample%8 != 0 || format.BitsPerSample == 0 || format.BitsPerSample > 64:
+		return false
+	}
+	return length <= frames*format.frameSize()
+}
+
+func decodeBlock(id byte, codec Codec, format Format, frames int, data []byte) (samples []byte, err error) {
+	size := frames * format.frameSize()
+	if id == rawID {
+		if len(data) != size {
+			return nil, ErrWrongBlock
+		}
+		return append([]byte(nil), data...), nil
+	}
+	if samples, err = codec.Decode(data, format, frames); err == nil && len(samples) != size {
+		err = ErrWrongBlock
+	}
+	return
+}
+
+// NewCodecs with lossless rice and lossy adpcm codecs
+func NewCodecs() *Codecs {
+	c := &Codecs{
+		ids:    make(map[string]byte),
+		codecs: make(map[byte]Codec),
+	}
+	c.Register(Rice, riceID, &rice{})
+	c.Register(ADPCM, adpcmID, &adpcm{})
+	return c
+}
@@ -0,0 +1,167 @@
+package codec
+
+import (
+	"math/bits"
+)
+
+// Rice name of lossless codec
+const Rice = "rice"
+
+const (
+	riceID = 1
+
+	// maxOrder of fixed linear predictor
+	maxOrder = 3
+	// escape quotient, residual with bigger quotient is written in 64 bits after escape
+	escape = 24
+)
+
+// rice lossless codec.
+// Each channel is predicted by fixed polynomial predictor of order 0-3 as in FLAC,
+// residuals are written in Rice code.
+// Channel: order (2 bits), rice parameter (6 bits), residuals of all frames.
+type rice struct{}
+
+// Encode integer samples
+func (c *rice) Encode(samples []byte, format Format) (data []byte, err error) {
+	if !isInteger(format) {
+		return nil, ErrNotSupported
+	}
+	values := toInt32(samples, format.BitsPerSample)
+	frames := len(values) / format.Channels
+
+	w := &bitWriter{}
+	channel := make([]int64, frames)
+	for ch := 0; ch < format.Channels; ch++ {
+		for i := range channel {
+			channel[i] = int64(values[i*format.Channels+ch])
+		}
+
+		order, residuals := bestResiduals(channel)
+		k := riceParameter(residuals)
+		w.writeBits(uint64(order), 2)
+		w.writeBits(uint64(k), 6)
+		for _, r := range residuals {
+			writeRice(w, zigzag(r), k)
+		}
+	}
+	return w.bytes(), nil
+}
+
+// Decode integer samples
+func (c *rice) Decode(data []byte, format Format, frames int) (samples []byte, err error) {
+	if !isInteger(format) {
+		return nil, ErrNotSupported
+	}
+	values := make([]int32, frames*format.Channels)
+
+	r := &bitReader{data: data}
+	channel := make([]int64, frames)
+	for ch := 0; ch < format.Channels; ch++ {
+		var order, k uint64
+		if order, err = r.readBits(2); err != nil {
+			return
+		}
+		if k, err = r.readBits(6); err != nil {
+			return
+		}
+		for i := range channel {
+			var u uint64
+			if u, err = readRice(r, uint(k)); err != nil {
+				return
+			}
+			channel[i] = predict(channel, i, int(order)) + unzigzag(u)
+			values[i*format.Channels+ch] = int32(channel[i])
+		}
+	}
+	return fromInt32(values, format.BitsPerSample), nil
+}
+
+// predict sample i by previous samples, order is decreased for first samples
+func predict(channel []int64, i, order int) int64 {
+	if i < order {
+		order = i
+	}
+	switch order {
+	case 1:
+		return channel[i-1]
+	case 2:
+		return 2*channel[i-1] - channel[i-2]
+	case 3:
+		return 3*channel[i-1] - 3*channel[i-2] + channel[i-3]
+	}
+	return 0
+}
+
+// bestResiduals return order of predictor with least sum of residuals and residuals
+func bestResiduals(channel []int64) (order int, residuals []int64) {
+	var best uint64
+	for o := 0; o <= maxOrder; o++ {
+		r := make([]int64, len(channel))
+		var sum uint64
+		for i := range channel {
+			r[i] = channel[i] - predict(channel, i, o)
+			sum += zigzag(r[i])
+		}
+		if residuals == nil || sum < best {
+			order, residuals, best = o, r, sum
+		}
+	}
+	return
+}
+
+// riceParameter estimated by mean of residuals
+func riceParameter(residuals []int64) uint {
+	if len(residuals) == 0 {
+		return 0
+	}
+	var sum uint64
+	for _, r := range residuals {
+		sum += zigzag(r)
+	}
+	k := uint(bits.Len64(sum / uint64(len(residuals))))
+	if k > 0 {
+		k--
+	}
+	return k
+}
+
+func writeRice(w *bitWriter, u uint64, k uint) {
+	q := u >> k
+	if q >= escape {
+		w.writeBits(1<<escape-1, escape)
+		w.writeBits(u, 64)
+		return
+	}
+	w.writeBits(1<<q-1, uint(q))
+	w.writeBits(0, 1)
+	w.writeBits(u, k)
+}
+
+func readRice(r *bitReader, k uint) (u uint64, err error) {
+	var q uint64
+	for ; q < escape; q++ {
+		var bit uint64
+		if bit, err = r.readBits(1); err != nil {
+			return
+		}
+		if bit == 0 {
+			break
+		}
+	}
+	if q == escape {
+		return r.readBits(64)
+	}
+	if u, err = r.readBits(k); err != nil {
+		return
+	}
+	return q<<k | u, nil
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func unzigzag(u uint64) int64 {
+	return int64(u>>1) ^ -int64(u&1)
+}
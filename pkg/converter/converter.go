@@ -2,13 +2,39 @@ package converter
 
 import (
 	"encoding/binary"
+	"math"
 )
 
-const bytePerInt16 = 2
+// audio formats of samples, values as in wav header
+const (
+	// FormatPCM integer samples
+	FormatPCM = 1
+	// FormatFloat IEEE float samples
+	FormatFloat = 3
+)
+
+const (
+	bytePerInt16   = 2
+	bytePerInt24   = 3
+	bytePerInt32   = 4
+	bytePerFloat32 = 4
+
+	// 8 bits samples are unsigned in wav data
+	offsetInt8 = 128
+)
 
 // Converter ...
 type Converter struct{}
 
+// ToInt8 convert array unsigned 8 bits samples to array int8
+func (c *Converter) ToInt8(src []byte) (dst []int8) {
+	dst = make([]int8, 0, len(src))
+	for _, b := range src {
+		dst = append(dst, int8(int(b)-offsetInt8))
+	}
+	return
+}
+
 // ToInt16 convert array byte to array int16
 func (c *Converter) ToInt16(src []byte) (dst []int16) {
 	dst = make([]int16, 0, len(src)/bytePerInt16)
@@ -21,6 +47,42 @@ func (c *Converter) ToInt16(src []byte) (dst []int16) {
 	return
 }
 
+// ToInt24 convert array packed 24 bits samples to array int32 with sign extension
+func (c *Converter) ToInt24(src []byte) (dst []int32) {
+	dst = make([]int32, 0, len(src)/bytePerInt24)
+	for i := 0; i <= len(src)-bytePerInt24; i += bytePerInt24 {
+		dst = append(
+			dst,
+			int32(uint32(src[i])<<8|uint32(src[i+1])<<16|uint32(src[i+2])<<24)>>8,
+		)
+	}
+	return
+}
+
+// ToInt32 convert array byte to array int32
+func (c *Converter) ToInt32(src []byte) (dst []int32) {
+	dst = make([]int32, 0, len(src)/bytePerInt32)
+	for i := 0; i <= len(src)-bytePerInt32; i += bytePerInt32 {
+		dst = append(
+			dst,
+			int32(binary.LittleEndian.Uint32(src[i:i+bytePerInt32])),
+		)
+	}
+	return
+}
+
+// ToFloat32 convert array byte to array float32
+func (c *Converter) ToFloat32(src []byte) (dst []float32) {
+	dst = make([]float32, 0, len(src)/bytePerFloat32)
+	for i := 0; i <= len(src)-bytePerFloat32; i += bytePerFloat32 {
+		dst = append(
+			dst,
+			math.Float32frombits(binary.LittleEndian.Uint32(src[i:i+bytePerFloat32])),
+		)
+	}
+	return
+}
+
 // ToByte convert array int16 to array byte
 func (c *Converter) ToByte(src []int16) (dst []byte) {
 	dst = make([]byte, 0, len(src)*bytePerInt16)
@@ -32,6 +94,122 @@ func (c *Converter) ToByte(src []int16) (dst []byte) {
 	return
 }
 
+// Int8ToByte convert array int8 to array unsigned 8 bits samples
+func (c *Converter) Int8ToByte(src []int8) (dst []byte) {
+	dst = make([]byte, 0, len(src))
+	for _, i := range src {
+		dst = append(dst, byte(int(i)+offsetInt8))
+	}
+	return
+}
+
+// Int24ToByte convert array int32 with 24 bits samples to array packed 24 bits samples
+func (c *Converter) Int24ToByte(src []int32) (dst []byte) {
+	dst = make([]byte, 0, len(src)*bytePerInt24)
+	for _, i := range src {
+		dst = append(dst, byte(i), byte(i>>8), byte(i>>16))
+	}
+	return
+}
+
+// Int32ToByte convert array int32 to array byte
+func (c *Converter) Int32ToByte(src []int32) (dst []byte) {
+	dst = make([]byte, 0, len(src)*bytePerInt32)
+	b := make([]byte, bytePerInt32)
+	for _, i := range src {
+		binary.LittleEndian.PutUint32(b, uint32(i))
+		dst = append(dst, b...)
+	}
+	return
+}
+
+// Float32ToByte convert array float32 to array byte
+func (c *Converter) Float32ToByte(src []float32) (dst []byte) {
+	dst = make([]byte, 0, len(src)*bytePerFloat32)
+	b := make([]byte, bytePerFloat32)
+	for _, f := range src {
+		binary.LittleEndian.PutUint32(b, math.Float32bits(f))
+		dst = append(dst, b...)
+	}
+	return
+}
+
+// ToFloat64 convert array byte with samples in audioFormat and bitsPerSample to array float64 in range [-1, 1]
+func (c *Converter) ToFloat64(src []byte, bitsPerSample, audioFormat int) (dst []float64) {
+	if audioFormat == FormatFloat {
+		for _, f := range c.ToFloat32(src) {
+			dst = append(dst, float64(f))
+		}
+		return
+	}
+
+	scale := math.Ldexp(1, bitsPerSample-1)
+	switch bitsPerSample {
+	case 8:
+		for _, i := range c.ToInt8(src) {
+			dst = append(dst, float64(i)/scale)
+		}
+	case 16:
+		for _, i := range c.ToInt16(src) {
+			dst = append(dst, float64(i)/scale)
+		}
+	case 24:
+		for _, i := range c.ToInt24(src) {
+			dst = append(dst, float64(i)/scale)
+		}
+	case 32:
+		for _, i := range c.ToInt32(src) {
+			dst = append(dst, float64(i)/scale)
+		}
+	}
+	return
+}
+
+// FromFloat64 convert array float64 in range [-1, 1] to array byte with samples in audioFormat and bitsPerSample
+// Samples out of range are clipped.
+func (c *Converter) FromFloat64(src []float64, bitsPerSample, audioFormat int) (dst []byte) {
+	if audioFormat == FormatFloat {
+		samples := make([]float32, 0, len(src))
+		for _, f := range src {
+			samples = append(samples, float32(f))
+		}
+		return c.Float32ToByte(samples)
+	}
+
+	scale := math.Ldexp(1, bitsPerSample-1)
+	switch bitsPerSample {
+	case 8:
+		samples := make([]int8, 0, len(src))
+		for _, f := range src {
+			samples = append(samples, int8(clip(f*scale, math.MinInt8, math.MaxInt8)))
+		}
+		return c.Int8ToByte(samples)
+	case 16:
+		samples := make([]int16, 0, len(src))
+		for _, f := range src {
+			samples = append(samples, int16(clip(f*scale, math.MinInt16, math.MaxInt16)))
+		}
+		return c.ToByte(samples)
+	case 24:
+		samples := make([]int32, 0, len(src))
+		for _, f := range src {
+			samples = append(samples, int32(clip(f*scale, -scale, scale-1)))
+		}
+		return c.Int24ToByte(samples)
+	case 32:
+		samples := make([]int32, 0, len(src))
+		for _, f := range src {
+			samples = append(samples, int32(clip(f*scale, math.MinInt32, math.MaxInt32)))
+		}
+		return c.Int32ToByte(samples)
+	}
+	return
+}
+
+func clip(sample, min, max float64) float64 {
+	return math.Max(min, math.Min(max, sample))
+}
+
 // NewConverter ...
 func NewConverter() *Converter {
 	return &Converter{}
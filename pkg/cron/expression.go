@@ -0,0 +1,160 @@
+package cron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrWrongExpression cron expression can not be parsed
+var ErrWrongExpression = errors.New("wrong cron expression")
+
+// years searched for next time of expression, expression without time in this range never fires
+const searchYears = 5
+
+type field struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minutes = field{min: 0, max: 59}
+	hours   = field{min: 0, max: 23}
+	days    = field{min: 1, max: 31}
+	months  = field{
+		min: 1, max: 12,
+		names: map[string]int{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		},
+	}
+	// 7 is sunday as 0
+	weekdays = field{
+		min: 0, max: 7,
+		names: map[string]int{
+			"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+		},
+	}
+)
+
+// Expression standard cron expression with five fields: minute, hour, day of month, month, day of week.
+// Fields support *, lists (1,3), ranges (1-5), steps (*/15, 0-30/5) and names of months and days of week.
+type Expression struct {
+	minute, hour, day, month, weekday uint64
+	// day and weekday are "*", if only one of them is restricted
+	// time must match it, otherwise time must match any of them
+	anyDay, anyWeekday bool
+}
+
+// Next return first time after t matching expression, zero time if there is no such time
+func (e *Expression) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	last := t.Year() + searchYears
+	for t.Year() <= last {
+		switch {
+		case e.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !e.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case e.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case e.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (e *Expression) matchDay(t time.Time) bool {
+	day := e.day&(1<<uint(t.Day())) != 0
+	weekday := e.weekday&(1<<uint(t.Weekday())) != 0
+	if e.anyDay || e.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+// Parse cron expression
+func Parse(spec string) (e *Expression, err error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, ErrWrongExpression
+	}
+
+	e = &Expression{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	if e.minute, err = minutes.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if e.hour, err = hours.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if e.day, err = days.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if e.month, err = months.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if e.weekday, err = weekdays.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if e.weekday&(1<<7) != 0 {
+		e.weekday |= 1
+	}
+	return
+}
+
+// parse field of expression to bit set of values
+func (f field) parse(s string) (bits uint64, err error) {
+	for _, item := range strings.Split(s, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, ErrWrongExpression
+			}
+			item = item[:i]
+		}
+
+		from, to := f.min, f.max
+		switch i := strings.Index(item, "-"); {
+		case item == "*":
+		case i >= 0:
+			if from, err = f.value(item[:i]); err != nil {
+				return
+			}
+			if to, err = f.value(item[i+1:]); err != nil {
+				return
+			}
+		default:
+			if from, err = f.value(item); err != nil {
+				return
+			}
+			if step == 1 {
+				to = from
+			}
+		}
+		if from > to {
+			return 0, ErrWrongExpression
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return
+}
+
+func (f field) value(s string) (v int, err error) {
+	if v, isExist := f.names[strings.ToLower(s)]; isExist {
+		return v, nil
+	}
+	if v, err = strconv.Atoi(s); err != nil || v < f.min || v > f.max {
+		return 0, ErrWrongExpression
+	}
+	return
+}
@@ -0,0 +1,297 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twinj/uuid"
+)
+
+// errors
+var (
+	ErrJobNotFound   = errors.New("job not found")
+	ErrWrongSchedule = errors.New("job is never run")
+)
+
+const maxWait = time.Minute
+
+// results of run
+const (
+	resultOK     = "ok"
+	resultMissed = "missed"
+)
+
+// Job task run by schedule
+type Job struct {
+	ID string `json:"id"`
+	// Cron expression of repeated job, empty for one-time job
+	Cron string `json:"cron,omitempty"`
+	// At time of one-time job
+	At   time.Time       `json:"at"`
+	Task json.RawMessage `json:"task"`
+
+	// NextRun zero if job is not run anymore
+	NextRun    time.Time `json:"nextRun"`
+	LastRun    time.Time `json:"lastRun"`
+	LastResult string    `json:"lastResult"`
+}
+
+// Runner run task of job with id
+type Runner func(id string, task []byte) (err error)
+
+// Scheduler run jobs at time of their schedule.
+// Jobs are stored in file and are loaded on creating scheduler.
+type Scheduler struct {
+	mutex       sync.Mutex
+	jobs        map[string]*Job
+	expressions map[string]*Expression
+
+	fileName string
+	run      Runner
+	wake     chan struct{}
+	stop     chan struct{}
+}
+
+// Start running jobs with run
+func (s *Scheduler) Start(run Runner) {
+	s.mutex.Lock()
+	s.run = run
+	s.mutex.Unlock()
+
+	go s.loop()
+}
+
+// Stop running jobs
+func (s *Scheduler) Stop() {
+	close(s.stop)
+}
+
+func (s *Scheduler) loop() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		next := s.runDue(now)
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		// jobs are checked at least every maxWait to follow changes of wall clock
+		if next.IsZero() || next.Sub(now) > maxWait {
+			next = now.Add(maxWait)
+		}
+		timer.Reset(next.Sub(now))
+	}
+}
+
+// runDue run jobs with next run before now, return nearest next run of jobs
+func (s *Scheduler) runDue(now time.Time) (next time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	isRun := false
+	for id, job := range s.jobs {
+		if job.NextRun.IsZero() {
+			continue
+		}
+		if !job.NextRun.After(now) {
+			job.LastRun = now
+			job.NextRun = s.next(id, now)
+			go s.runJob(id, job.Task)
+			isRun = true
+		}
+		if !job.NextRun.IsZero() && (next.IsZero() || job.NextRun.Before(next)) {
+			next = job.NextRun
+		}
+	}
+	if isRun {
+		s.save()
+	}
+	return
+}
+
+func (s *Scheduler) runJob(id string, task []byte) {
+	result := resultOK
+	if err := s.run(id, task); err != nil {
+		result = err.Error()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if job, isExist := s.jobs[id]; isExist {
+		job.LastResult = result
+		s.save()
+	}
+}
+
+// next return time of next run of job after t
+func (s *Scheduler) next(id string, t time.Time) time.Time {
+	if e, isExist := s.expressions[id]; isExist {
+		return e.Next(t)
+	}
+	if job := s.jobs[id]; job.At.After(t) {
+		return job.At
+	}
+	return time.Time{}
+}
+
+// Add job running task by cron expression spec or once at time at if spec is empty
+func (s *Scheduler) Add(spec string, at time.Time, task []byte) (job Job, err error) {
+	job = Job{
+		ID:   uuid.NewV4().String(),
+		Cron: spec,
+		At:   at,
+		Task: task,
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if spec != "" {
+		var e *Expression
+		if e, err = Parse(spec); err != nil {
+			return
+		}
+		job.At = time.Time{}
+		job.NextRun = e.Next(time.Now())
+		s.expressions[job.ID] = e
+	} else if at.After(time.Now()) {
+		job.NextRun = at
+	}
+	if job.NextRun.IsZero() {
+		delete(s.expressions, job.ID)
+		err = ErrWrongSchedule
+		return
+	}
+
+	s.jobs[job.ID] = &job
+	if err = s.save(); err != nil {
+		delete(s.jobs, job.ID)
+		delete(s.expressions, job.ID)
+		return
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return
+}
+
+// Remove job with id
+func (s *Scheduler) Remove(id string) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, isExist := s.jobs[id]; !isExist {
+		return ErrJobNotFound
+	}
+	delete(s.jobs, id)
+	delete(s.expressions, id)
+	return s.save()
+}
+
+// Jobs return all jobs sorted by next run, jobs not run anymore are at the end
+func (s *Scheduler) Jobs() (jobs []Job) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	jobs = make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].NextRun.IsZero() || jobs[j].NextRun.IsZero() {
+			return !jobs[i].NextRun.IsZero()
+		}
+		return jobs[i].NextRun.Before(jobs[j].NextRun)
+	})
+	return
+}
+
+// save jobs to file
+func (s *Scheduler) save() (err error) {
+	if s.fileName == "" {
+		return
+	}
+
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	data, err := json.MarshalIndent(jobs, "", "\t")
+	if err != nil {
+		return
+	}
+
+	tmp := s.fileName + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	return os.Rename(tmp, s.fileName)
+}
+
+// load jobs from file, one-time jobs missed while scheduler was not running are not run
+func (s *Scheduler) load() (err error) {
+	data, err := ioutil.ReadFile(s.fileName)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+
+	var jobs []*Job
+	if err = json.Unmarshal(data, &jobs); err != nil {
+		return
+	}
+
+	now := time.Now()
+	for _, job := range jobs {
+		s.jobs[job.ID] = job
+		if job.Cron != "" {
+			var e *Expression
+			if e, err = Parse(job.Cron); err != nil {
+				return
+			}
+			s.expressions[job.ID] = e
+		} else if !job.NextRun.IsZero() && !job.At.After(now) {
+			job.LastResult = resultMissed
+		}
+		job.NextRun = s.next(job.ID, now)
+	}
+	return
+}
+
+// NewScheduler return scheduler with jobs stored in fileName, jobs are not stored if fileName is empty
+func NewScheduler(fileName string) (s *Scheduler, err error) {
+	s = &Scheduler{
+		jobs:        make(map[string]*Job),
+		expressions: make(map[string]*Expression),
+
+		fileName: fileName,
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+	if fileName != "" {
+		err = s.load()
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+// Types of events
+const (
+	Started  = "started"
+	Stopped  = "stopped"
+	Underrun = "underrun"
+	EOF      = "eof"
+	Error    = "error"
+)
+
+// Sources of events
+const (
+	Player   = "player"
+	Recorder = "recorder"
+)
+
+// Event on device of player or recorder
+type Event struct {
+	// Source and IP of player or recorder, they are set by receiver of events
+	Source string
+	IP     string
+
+	Type        string
+	DeviceName  string
+	StorageUUID string
+	// Message of error
+	Message string
+	Time    time.Time
+}
+
+// Bus delivers published events to all subscribers.
+// Subscriber which does not read events misses events not fitting in its buffer.
+type Bus struct {
+	buffSize int
+
+	mutex       sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// Publish event to subscribers, zero time of event is set to now
+func (b *Bus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for events := range b.subscribers {
+		select {
+		case events <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe on events published after it, events are closed by cancel
+func (b *Bus) Subscribe() (events <-chan Event, cancel func()) {
+	c := make(chan Event, b.buffSize)
+
+	b.mutex.Lock()
+	b.subscribers[c] = struct{}{}
+	b.mutex.Unlock()
+
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			delete(b.subscribers, c)
+			b.mutex.Unlock()
+			close(c)
+		})
+	}
+	return c, cancel
+}
+
+// NewBus buffSize is number of events buffered for each subscriber
+func NewBus(buffSize int) *Bus {
+	return &Bus{
+		buffSize:    buffSize,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
@@ -0,0 +1,124 @@
+package flac
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mewkiz/flac"
+)
+
+const (
+	flacAudioFormat = 1
+	extension       = ".flac"
+)
+
+// FLAC audio file
+type FLAC struct{}
+
+// Match data starts with flac marker
+func (f *FLAC) Match(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("fLaC"))
+}
+
+// Reader flac file, samples are decoded to PCM little-endian as in wav.
+// Samples of bits not multiple of 8 are aligned to high bits of whole bytes.
+func (f *FLAC) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error) {
+	stream, err := flac.New(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	channels = uint16(stream.Info.NChannels)
+	rate = stream.Info.SampleRate
+	bitsPerSample = uint16(stream.Info.BitsPerSample+7) / 8 * 8
+	audioFormat = flacAudioFormat
+	r = &reader{
+		stream: stream,
+		size:   int(bitsPerSample / 8),
+		shift:  uint(bitsPerSample) - uint(stream.Info.BitsPerSample),
+	}
+	return
+}
+
+type reader struct {
+	stream *flac.Stream
+	// size of sample in bytes
+	size  int
+	shift uint
+	// decoded samples not read yet
+	data []byte
+}
+
+// Read decoded samples, frames of flac are decoded on demand
+func (r *reader) Read(p []byte) (n int, err error) {
+	for len(r.data) == 0 {
+		if err = r.decode(); err != nil {
+			return
+		}
+	}
+	n = copy(p, r.data)
+	r.data = r.data[n:]
+	return
+}
+
+func (r *reader) decode() error {
+	frame, err := r.stream.ParseNext()
+	if err != nil {
+		return err
+	}
+	if len(frame.Subframes) == 0 {
+		return nil
+	}
+
+	frames := len(frame.Subframes[0].Samples)
+	channels := len(frame.Subframes)
+	data := make([]byte, frames*channels*r.size)
+	for i := 0; i < frames; i++ {
+		for ch, subframe := range frame.Subframes {
+			s := subframe.Samples[i] << r.shift
+			b := data[(i*channels+ch)*r.size:]
+			switch r.size {
+			case 1:
+				// 8 bits samples are unsigned in wav data
+				b[0] = byte(s + 128)
+			case 2:
+				binary.LittleEndian.PutUint16(b, uint16(s))
+			case 3:
+				b[0], b[1], b[2] = byte(s), byte(s>>8), byte(s>>16)
+			case 4:
+				binary.LittleEndian.PutUint32(b, uint32(s))
+			}
+		}
+	}
+	r.data = data
+	return nil
+}
+
+// Writer flac file with integer samples up to 24 bits, samples are in wav format.
+// Frames are written to file as samples come, header is valid after each frame.
+func (f *FLAC) Writer(fileName string, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (wc io.WriteCloser, err error) {
+	if !strings.HasSuffix(fileName, extension) {
+		fileName += extension
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return
+	}
+	if wc, err = newWriter(file, channels, rate, bitsPerSample, audioFormat); err != nil {
+		file.Close()
+		os.Remove(fileName)
+	}
+	return
+}
+
+// Extension of flac files
+func (f *FLAC) Extension() string {
+	return extension
+}
+
+// NewFLAC return handler flac file
+func NewFLAC() *FLAC {
+	return &FLAC{}
+}
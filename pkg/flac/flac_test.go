@@ -0,0 +1,111 @@
+package flac
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	channels = 2
+	rate     = 48000
+	// frames of two flac blocks and incomplete block
+	frames = 2*blockSize + 100
+)
+
+// signals return generator of samples of signal with bitsPerSample.
+// Tone has small residuals of 4 bits rice coding, noise and random walk have big residuals of rice2 coding,
+// residuals of random walk are predicted from previous sample.
+var signals = map[string]func(bitsPerSample int) func(i int) int64{
+	"tone": func(bitsPerSample int) func(i int) int64 {
+		amplitude := float64(int64(1)<<uint(bitsPerSample-1) - 1)
+		return func(i int) int64 {
+			return int64(amplitude * math.Sin(2*math.Pi*440*float64(i)/rate))
+		}
+	},
+	"noise": func(bitsPerSample int) func(i int) int64 {
+		random := rand.New(rand.NewSource(1))
+		return func(i int) int64 {
+			return random.Int63n(int64(1)<<uint(bitsPerSample)) - int64(1)<<uint(bitsPerSample-1)
+		}
+	},
+	"walk": func(bitsPerSample int) func(i int) int64 {
+		random := rand.New(rand.NewSource(1))
+		max := int64(1)<<uint(bitsPerSample-1) - 1
+		step := int64(1) << uint(bitsPerSample-4)
+		var s int64
+		return func(i int) int64 {
+			s += random.Int63n(2*step+1) - step
+			if s > max || s < -max {
+				s /= 2
+			}
+			return s
+		}
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, bitsPerSample := range []int{8, 16, 24} {
+		for name, signal := range signals {
+			bitsPerSample, signal := bitsPerSample, signal
+			t.Run(fmt.Sprintf("%s/%d", name, bitsPerSample), func(t *testing.T) {
+				samples := wavSamples(bitsPerSample, signal(bitsPerSample))
+				file := filepath.Join(t.TempDir(), "test.flac")
+
+				f := NewFLAC()
+				w, err := f.Writer(file, channels, rate, uint16(bitsPerSample), flacAudioFormat)
+				if err != nil {
+					t.Fatalf("failed to create writer: %v", err)
+				}
+				if _, err = w.Write(samples); err != nil {
+					t.Fatalf("failed to write samples: %v", err)
+				}
+				if err = w.Close(); err != nil {
+					t.Fatalf("failed to close writer: %v", err)
+				}
+
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					t.Fatalf("failed to read file: %v", err)
+				}
+				r, rChannels, rRate, rBitsPerSample, _, err := f.Reader(data)
+				if err != nil {
+					t.Fatalf("failed to create reader: %v", err)
+				}
+				if rChannels != channels || rRate != rate || int(rBitsPerSample) != bitsPerSample {
+					t.Fatalf("read format %d channels %d Hz %d bits differs from written", rChannels, rRate, rBitsPerSample)
+				}
+				decoded, err := ioutil.ReadAll(r)
+				if err != nil {
+					t.Fatalf("failed to decode samples: %v", err)
+				}
+				if !bytes.Equal(decoded, samples) {
+					t.Fatalf("decoded %d bytes differ from %d written bytes", len(decoded), len(samples))
+				}
+			})
+		}
+	}
+}
+
+// wavSamples of signal in wav format, samples of signal are written to channels in turn
+func wavSamples(bitsPerSample int, signal func(i int) int64) []byte {
+	size := bitsPerSample / 8
+	data := make([]byte, 0, frames*channels*size)
+	for i := 0; i < frames*channels; i++ {
+		s := signal(i / channels)
+		switch size {
+		case 1:
+			// 8 bits samples are unsigned in wav data
+			data = append(data, byte(s+128))
+		case 2:
+			data = append(data, byte(s), byte(s>>8))
+		case 3:
+			data = append(data, byte(s), byte(s>>8), byte(s>>16))
+		}
+	}
+	return data
+}
@@ -0,0 +1,335 @@
+package flac
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"os"
+)
+
+// ErrFormatNotSupported flac stores only integer samples up to 24 bits
+var ErrFormatNotSupported = errors.New("format is not supported by flac")
+
+const (
+	// blockSize in frames of each flac frame, last frame can be shorter
+	blockSize = 4096
+	// maxOrder of fixed predictor
+	maxOrder = 4
+	// maxRiceParameter of 4 bits rice coding, bigger parameters are written in 5 bits of rice2 coding
+	maxRiceParameter = 14
+	// maxRice2Parameter of 5 bits rice2 coding, 31 is escape code
+	maxRice2Parameter = 30
+
+	streamInfoSize = 34
+	// totalSamplesOffset in file of byte with low bits of bits per sample and high bits of total samples:
+	// signature (4), block header (4), block sizes (4), frame sizes (6), rate, channels and bits per sample (3.5)
+	totalSamplesOffset = 4 + 4 + 4 + 6 + 3
+)
+
+// sampleSizeCodes in frame header by bits per sample
+var sampleSizeCodes = map[int]uint64{
+	8:  0x1,
+	16: 0x4,
+	24: 0x6,
+}
+
+// writer of flac file, samples are encoded by fixed predictors with rice coded residuals.
+// Total samples in streaminfo are updated after each frame, so file is valid if process dies before closing.
+type writer struct {
+	file          *os.File
+	channels      int
+	bitsPerSample int
+	// sampleSize in bytes of wav sample
+	sampleSize int
+
+	// samples of incomplete block
+	data   []byte
+	frame  uint64
+	frames uint64
+}
+
+// Write samples in wav format, whole blocks are encoded to flac frames
+func (w *writer) Write(data []byte) (n int, err error) {
+	w.data = append(w.data, data...)
+	size := blockSize * w.channels * w.sampleSize
+	for len(w.data) >= size {
+		if err = w.writeFrame(w.data[:size]); err != nil {
+			return
+		}
+		w.data = w.data[size:]
+	}
+	w.data = append([]byte(nil), w.data...)
+	return len(data), nil
+}
+
+// Close write incomplete block and close file
+func (w *writer) Close() (err error) {
+	frameSize := w.channels * w.sampleSize
+	if size := len(w.data) - len(w.data)%frameSize; size != 0 {
+		err = w.writeFrame(w.data[:size])
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return
+}
+
+func (w *writer) writeFrame(data []byte) (err error) {
+	samples := w.samples(data)
+	frames := len(samples[0])
+
+	b := &bitWriter{}
+	// header: sync code, fixed block size, block size in 16 bits at end of header,
+	// sample rate from streaminfo, independent channels, sample size
+	b.writeBits(0xfff8, 16)
+	b.writeBits(0x7, 4)
+	b.writeBits(0, 4)
+	b.writeBits(uint64(w.channels-1), 4)
+	b.writeBits(sampleSizeCodes[w.bitsPerSample], 3)
+	b.writeBits(0, 1)
+	writeUTF8(b, w.frame)
+	b.writeBits(uint64(frames-1), 16)
+	b.writeBits(uint64(crc8(b.data)), 8)
+
+	for _, channel := range samples {
+		writeSubframe(b, channel, w.bitsPerSample)
+	}
+	b.align()
+	b.writeBits(uint64(crc16(b.data)), 16)
+
+	if _, err = w.file.Write(b.data); err != nil {
+		return
+	}
+	w.frame++
+	w.frames += uint64(frames)
+	return w.updateTotal()
+}
+
+// samples of channels from interleaved wav samples
+func (w *writer) samples(data []byte) [][]int64 {
+	frames := len(data) / (w.channels * w.sampleSize)
+	samples := make([][]int64, w.channels)
+	for ch := range samples {
+		samples[ch] = make([]int64, frames)
+	}
+	for i := 0; i < frames; i++ {
+		for ch := 0; ch < w.channels; ch++ {
+			b := data[(i*w.channels+ch)*w.sampleSize:]
+			var s int64
+			switch w.sampleSize {
+			case 1:
+				// 8 bits samples are unsigned in wav data
+				s = int64(b[0]) - 128
+			case 2:
+				s = int64(int16(binary.LittleEndian.Uint16(b)))
+			case 3:
+				s = int64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8)
+			}
+			samples[ch][i] = s
+		}
+	}
+	return samples
+}
+
+// updateTotal samples in streaminfo, 36 bits shared with low 4 bits of bits per sample
+func (w *writer) updateTotal() (err error) {
+	total := make([]byte, 5)
+	total[0] = byte(w.bitsPerSample-1)<<4 | byte(w.frames>>32&0xf)
+	binary.BigEndian.PutUint32(total[1:], uint32(w.frames))
+	_, err = w.file.WriteAt(total, totalSamplesOffset)
+	return
+}
+
+func newWriter(file *os.File, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (w *writer, err error) {
+	if audioFormat != flacAudioFormat || bitsPerSample%8 != 0 || bitsPerSample == 0 || bitsPerSample > 24 || channels == 0 || channels > 8 {
+		return nil, ErrFormatNotSupported
+	}
+
+	b := &bitWriter{}
+	b.writeBits(uint64(binary.BigEndian.Uint32([]byte("fLaC"))), 32)
+	// last metadata block, type streaminfo
+	b.writeBits(1, 1)
+	b.writeBits(0, 7)
+	b.writeBits(streamInfoSize, 24)
+	// min and max block size
+	b.writeBits(blockSize, 16)
+	b.writeBits(blockSize, 16)
+	// min and max frame size are unknown
+	b.writeBits(0, 24)
+	b.writeBits(0, 24)
+	b.writeBits(uint64(rate), 20)
+	b.writeBits(uint64(channels-1), 3)
+	b.writeBits(uint64(bitsPerSample-1), 5)
+	// total samples are updated after each frame
+	b.writeBits(0, 36)
+	// md5 of samples is not calculated
+	b.writeBits(0, 64)
+	b.writeBits(0, 64)
+
+	if _, err = file.Write(b.data); err != nil {
+		return
+	}
+	w = &writer{
+		file:          file,
+		channels:      int(channels),
+		bitsPerSample: int(bitsPerSample),
+		sampleSize:    int(bitsPerSample / 8),
+	}
+	return
+}
+
+// writeSubframe with fixed predictor of order giving least residuals
+func writeSubframe(b *bitWriter, samples []int64, bitsPerSample int) {
+	order, residuals := bestResiduals(samples)
+	// zero bit, type fixed with order, no wasted bits
+	b.writeBits(uint64(0x08|order)<<1, 8)
+	for _, s := range samples[:order] {
+		b.writeBits(uint64(s), uint(bitsPerSample))
+	}
+
+	// rice or rice2 coding with one partition
+	k := riceParameter(residuals)
+	if k > maxRiceParameter {
+		if k > maxRice2Parameter {
+			k = maxRice2Parameter
+		}
+		b.writeBits(1, 2)
+		b.writeBits(0, 4)
+		b.writeBits(uint64(k), 5)
+	} else {
+		b.writeBits(0, 2)
+		b.writeBits(0, 4)
+		b.writeBits(uint64(k), 4)
+	}
+	for _, r := range residuals {
+		u := zigzag(r)
+		for q := u >> k; q > 0; q-- {
+			b.writeBits(0, 1)
+		}
+		b.writeBits(1, 1)
+		b.writeBits(u, k)
+	}
+}
+
+// bestResiduals return order of fixed predictor with least sum of residuals and residuals after warm-up samples
+func bestResiduals(samples []int64) (order int, residuals []int64) {
+	var best uint64
+	for o := 0; o <= maxOrder && o < len(samples); o++ {
+		r := make([]int64, 0, len(samples)-o)
+		var sum uint64
+		for i := o; i < len(samples); i++ {
+			residual := samples[i] - predict(samples, i, o)
+			r = append(r, residual)
+			sum += zigzag(residual)
+		}
+		if o == 0 || sum < best {
+			order, residuals, best = o, r, sum
+		}
+	}
+	return
+}
+
+// predict sample i by fixed polynomial predictor of order
+func predict(s []int64, i, order int) int64 {
+	switch order {
+	case 1:
+		return s[i-1]
+	case 2:
+		return 2*s[i-1] - s[i-2]
+	case 3:
+		return 3*s[i-1] - 3*s[i-2] + s[i-3]
+	case 4:
+		return 4*s[i-1] - 6*s[i-2] + 4*s[i-3] - s[i-4]
+	}
+	return 0
+}
+
+// riceParameter estimated by mean of residuals
+func riceParameter(residuals []int64) uint {
+	if len(residuals) == 0 {
+		return 0
+	}
+	var sum uint64
+	for _, r := range residuals {
+		sum += zigzag(r)
+	}
+	k := uint(bits.Len64(sum / uint64(len(residuals))))
+	if k > 0 {
+		k--
+	}
+	return k
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// writeUTF8 number coded as in utf-8 extended to 36 bits
+func writeUTF8(b *bitWriter, v uint64) {
+	if v < 0x80 {
+		b.writeBits(v, 8)
+		return
+	}
+	n := uint(2)
+	for v >= 1<<(5*n+1) {
+		n++
+	}
+	// first byte is n ones, zero and high bits of v
+	b.writeBits(uint64(0xff00)>>n&0xff|v>>(6*(n-1)), 8)
+	for i := int(n) - 2; i >= 0; i-- {
+		b.writeBits(0x80|v>>(6*uint(i))&0x3f, 8)
+	}
+}
+
+type bitWriter struct {
+	data []byte
+	acc  byte
+	n    uint
+}
+
+// writeBits write n low bits of v, high bit first
+func (w *bitWriter) writeBits(v uint64, n uint) {
+	for n > 0 {
+		n--
+		w.acc = w.acc<<1 | byte(v>>n&1)
+		if w.n++; w.n == 8 {
+			w.data = append(w.data, w.acc)
+			w.acc, w.n = 0, 0
+		}
+	}
+}
+
+// align to byte by zero bits
+func (w *bitWriter) align() {
+	if w.n != 0 {
+		w.writeBits(0, 8-w.n)
+	}
+}
+
+func crc8(data []byte) (crc byte) {
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return
+}
+
+func crc16(data []byte) (crc uint16) {
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,264 @@
+package harness
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"audio-service/pkg/audio"
+	"audio-service/pkg/capture"
+	"audio-service/pkg/codec"
+	"audio-service/pkg/converter"
+	"audio-service/pkg/cron"
+	"audio-service/pkg/flac"
+	"audio-service/pkg/mixer"
+	"audio-service/pkg/mp3"
+	"audio-service/pkg/ogg"
+	"audio-service/pkg/playback"
+	"audio-service/pkg/player"
+	"audio-service/pkg/pool"
+	"audio-service/pkg/recorder"
+	"audio-service/pkg/resampler"
+	"audio-service/pkg/server"
+	"audio-service/pkg/server/httpclient"
+	"audio-service/pkg/server/httpserver"
+	"audio-service/pkg/storage"
+	"audio-service/pkg/stream"
+	"audio-service/pkg/tcp"
+	"audio-service/pkg/udp"
+	"audio-service/pkg/wav"
+)
+
+// IP of server, player and recorder in harness
+const IP = "127.0.0.1"
+
+// layout of addresses as in configuration of server
+const addrLayout = "%s:%s"
+
+const (
+	transportStream = "stream"
+	transportUDP    = "udp"
+
+	rpcTimeout    = 10 * time.Second
+	rpcMaxBackoff = time.Second
+)
+
+type audioTransport interface {
+	Send(ctx context.Context, dstAddr string, r io.Reader) error
+	Receive(ctx context.Context, receivePort string, w io.Writer) error
+	TurnOnSender(dstAddr string) (io.WriteCloser, error)
+}
+
+// Config of harness, zero values are defaults of services
+type Config struct {
+	// Transport of audio signal: tcp, stream or udp
+	Transport string
+	// JitterDelay of waiting for reordered udp packets on player
+	JitterDelay time.Duration
+	// Codecs of signal to player and from recorder in order of preference, pcm if empty
+	Codecs []string
+	// ToneFrequency of recorder device in Hz
+	ToneFrequency float64
+	// BuffSize of sending and playing
+	BuffSize int
+	Logger   log.Logger
+}
+
+// Harness server, player and recorder in one process on loopback ports.
+// Player plays on Sink, recorder records tone with ToneFrequency on any device.
+type Harness struct {
+	// Client of server
+	Client httpclient.Client
+	// Sink signal played on player
+	Sink *Sink
+
+	dir     string
+	closers []func()
+}
+
+// File write wav file with samples in directory of harness and return name of file for server
+func (h *Harness) File(name string, channels, rate, bitsPerSample, audioFormat int, samples []byte) (fileName string, err error) {
+	wav := wav.NewWAV()
+	if !strings.HasSuffix(name, wav.Extension()) {
+		name += wav.Extension()
+	}
+	fileName = filepath.Join(h.dir, name)
+	w, err := wav.Writer(fileName, uint16(channels), uint32(rate), uint16(bitsPerSample), uint16(audioFormat))
+	if err != nil {
+		return
+	}
+	if _, err = w.Write(samples); err != nil {
+		w.Close()
+		return
+	}
+	err = w.Close()
+	return
+}
+
+// Port return free loopback port for receiving on player
+func (h *Harness) Port() (port string, err error) {
+	ln, err := net.Listen("tcp", IP+":0")
+	if err != nil {
+		return
+	}
+	defer ln.Close()
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port), nil
+}
+
+// Close stop services and remove files of harness
+func (h *Harness) Close() {
+	for i := len(h.closers) - 1; i >= 0; i-- {
+		h.closers[i]()
+	}
+	os.RemoveAll(h.dir)
+}
+
+// New start server, player and recorder
+func New(cfg Config) (h *Harness, err error) {
+	if cfg.JitterDelay == 0 {
+		cfg.JitterDelay = 60 * time.Millisecond
+	}
+	if cfg.Codecs == nil {
+		cfg.Codecs = []string{"pcm"}
+	}
+	if cfg.ToneFrequency == 0 {
+		cfg.ToneFrequency = 440
+	}
+	if cfg.BuffSize == 0 {
+		cfg.BuffSize = 1024
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.NewNopLogger()
+	}
+
+	h = &Harness{
+		Sink: NewSink(),
+	}
+	if h.dir, err = ioutil.TempDir("", "harness"); err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			h.Close()
+		}
+	}()
+
+	converter := converter.NewConverter()
+	resampler := resampler.NewResampler(converter)
+
+	playerPort, err := h.serve(func(s *grpc.Server) {
+		p4r := player.NewPlayer(
+			transport(cfg),
+			playback.NewPlayback(h.Sink, converter, cfg.BuffSize, cfg.Logger),
+			storage.NewStorage(),
+			codec.NewCodecs(),
+		)
+		player.RegisterPlayerServer(s, player.NewLoggerMiddleware(cfg.Logger, p4r))
+	})
+	if err != nil {
+		return
+	}
+
+	recorderPort, err := h.serve(func(s *grpc.Server) {
+		r5r := recorder.NewRecorder(
+			transport(cfg),
+			capture.NewCapture(capture.NewTone(converter, cfg.ToneFrequency), cfg.BuffSize),
+			codec.NewCodecs(),
+		)
+		recorder.RegisterRecorderServer(s, recorder.NewLoggerMiddleware(cfg.Logger, r5r))
+	})
+	if err != nil {
+		return
+	}
+
+	scheduler, err := cron.NewScheduler("")
+	if err != nil {
+		return
+	}
+	h.closers = append(h.closers, scheduler.Stop)
+
+	conns := pool.NewPool(rpcTimeout, 0, rpcMaxBackoff, grpc.WithInsecure())
+	h.closers = append(h.closers, conns.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.closers = append(h.closers, cancel)
+
+	wav := wav.NewWAV()
+	flac := flac.NewFLAC()
+	svc := server.NewServer(
+		ctx,
+		audio.NewAudio(
+			[]audio.Encoder{
+				wav,
+				flac,
+			},
+			[]audio.Decoder{
+				wav,
+				mp3.NewMP3(),
+				flac,
+				ogg.NewOGG(),
+			},
+		),
+		mixer.NewMixer(converter),
+		resampler,
+		scheduler,
+		recorder.NewClient(addrLayout, recorderPort, conns),
+		player.NewClient(addrLayout, playerPort, conns),
+		transport(cfg),
+		codec.NewCodecs(),
+		cfg.Codecs,
+
+		IP,
+		addrLayout,
+		addrLayout,
+	)
+	svc = server.NewLoggerMiddleware(svc, cfg.Logger)
+
+	ln, err := net.Listen("tcp", IP+":0")
+	if err != nil {
+		return
+	}
+	httpServer := httpserver.NewServer(svc, nil)
+	go httpServer.Serve(ln)
+	h.closers = append(h.closers, func() {
+		httpServer.Shutdown()
+	})
+
+	h.Client = httpclient.NewClient(ln.Addr().String(), "")
+	return
+}
+
+// serve start grpc server on loopback port
+func (h *Harness) serve(register func(s *grpc.Server)) (port string, err error) {
+	ln, err := net.Listen("tcp", IP+":0")
+	if err != nil {
+		return
+	}
+	s := grpc.NewServer()
+	register(s)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(ln)
+	h.closers = append(h.closers, s.Stop)
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port), nil
+}
+
+func transport(cfg Config) audioTransport {
+	switch cfg.Transport {
+	case transportStream:
+		return stream.NewStream(cfg.BuffSize)
+	case transportUDP:
+		return udp.NewUDP(cfg.BuffSize, cfg.JitterDelay)
+	}
+	return tcp.NewTCP(cfg.BuffSize)
+}
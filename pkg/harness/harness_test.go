@@ -0,0 +1,192 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"audio-service/pkg/server"
+)
+
+const (
+	channels      = 2
+	rate          = 48000
+	bitsPerSample = 16
+	audioFormat   = 1
+
+	fileDevice     = "file"
+	recorderDevice = "recorder"
+	// recordedDevice any device of tone generator on recorder
+	recordedDevice = "default"
+
+	// multicastGroup of administratively scoped addresses
+	multicastGroup = "239.0.0.1"
+	// maxSkipped bytes of tenth of second skipped by synchronized player
+	maxSkipped = rate * channels * bitsPerSample / 8 / 10
+
+	toneFrequency = 440
+	// minCorrelation of tone received from recorder with generated tone
+	minCorrelation = 0.9
+	timeout        = 10 * time.Second
+)
+
+var transports = []string{"tcp", "stream", "udp"}
+
+func TestFilePlay(t *testing.T) {
+	for _, transport := range transports {
+		t.Run(transport, func(t *testing.T) {
+			h := newHarness(t, transport)
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			samples := make([]byte, rate*channels*bitsPerSample/8)
+			rand.New(rand.NewSource(1)).Read(samples)
+			file, err := h.File("file", channels, rate, bitsPerSample, audioFormat, samples)
+			if err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			port, err := h.Port()
+			if err != nil {
+				t.Fatalf("failed to get port: %v", err)
+			}
+
+			if _, _, _, _, err = h.Client.FilePlay(ctx, file, IP, port, fileDevice, 0, 0); err != nil {
+				t.Fatalf("failed to play file: %v", err)
+			}
+			played, err := h.Sink.WaitEnd(ctx, fileDevice)
+			if err != nil {
+				t.Fatalf("failed to wait end of playing: %v", err)
+			}
+			if !bytes.Equal(played, samples) {
+				t.Fatalf("played %d bytes differ from %d bytes of file from byte %d", len(played), len(samples), mismatch(played, samples))
+			}
+		})
+	}
+}
+
+func TestRecorderStream(t *testing.T) {
+	for _, transport := range transports {
+		t.Run(transport, func(t *testing.T) {
+			h := newHarness(t, transport)
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			port, err := h.Port()
+			if err != nil {
+				t.Fatalf("failed to get port: %v", err)
+			}
+			uuid, err := h.Client.PlayFromRecorder(ctx, IP, port, recorderDevice, 1, rate, bitsPerSample, audioFormat, IP, recordedDevice)
+			if err != nil {
+				t.Fatalf("failed to play from recorder: %v", err)
+			}
+			defer h.Client.StopFromRecorder(context.Background(), IP, port, recorderDevice, uuid, IP, recordedDevice)
+
+			// half of second of mono signal
+			played, err := h.Sink.WaitSize(ctx, recorderDevice, rate*bitsPerSample/8/2)
+			if err != nil {
+				t.Fatalf("failed to wait played signal: %v", err)
+			}
+			signal := Channel(played, 1, 0, bitsPerSample, audioFormat)
+			reference := Tone(toneFrequency, rate, len(signal))
+			// lag of one period covers any phase of tone
+			if c := Correlation(signal, reference, rate/toneFrequency+1); c < minCorrelation {
+				t.Fatalf("correlation %.3f of played tone is less than %.3f", c, minCorrelation)
+			}
+		})
+	}
+}
+
+func TestMixRecorder(t *testing.T) {
+	for _, codec := range []string{"pcm", "rice", "adpcm"} {
+		t.Run(codec, func(t *testing.T) {
+			h := newHarness(t, "tcp", codec)
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			receivePort, err := h.Port()
+			if err != nil {
+				t.Fatalf("failed to get port: %v", err)
+			}
+			port, err := h.Port()
+			if err != nil {
+				t.Fatalf("failed to get port: %v", err)
+			}
+			sources := []server.MixSource{{RecorderIP: IP, RecorderDeviceName: recordedDevice, ReceivePort: receivePort, Gain: 1}}
+			uuid, err := h.Client.MixPlay(ctx, sources, IP, port, recorderDevice, 1, rate)
+			if err != nil {
+				t.Fatalf("failed to mix recorder: %v", err)
+			}
+			defer h.Client.MixStop(context.Background(), IP, port, uuid)
+
+			// half of second of mono signal
+			played, err := h.Sink.WaitSize(ctx, recorderDevice, rate*bitsPerSample/8/2)
+			if err != nil {
+				t.Fatalf("failed to wait played signal: %v", err)
+			}
+			signal := Channel(played, 1, 0, bitsPerSample, audioFormat)
+			reference := Tone(toneFrequency, rate, len(signal))
+			if c := Correlation(signal, reference, rate/toneFrequency+1); c < minCorrelation {
+				t.Fatalf("correlation %.3f of played tone is less than %.3f", c, minCorrelation)
+			}
+		})
+	}
+}
+
+func TestMulticastPlay(t *testing.T) {
+	h := newHarness(t, "udp")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	samples := make([]byte, rate*channels*bitsPerSample/8)
+	rand.New(rand.NewSource(1)).Read(samples)
+	file, err := h.File("file", channels, rate, bitsPerSample, audioFormat, samples)
+	if err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	port, err := h.Port()
+	if err != nil {
+		t.Fatalf("failed to get port: %v", err)
+	}
+	groupAddr := multicastGroup + ":" + port
+
+	players := []server.PlayerDevice{{PlayerIP: IP, PlayerDeviceName: fileDevice}}
+	if _, _, err = h.Client.MulticastPlay(ctx, file, groupAddr, players, 0, 0); err != nil {
+		t.Fatalf("failed to play file in multicast group: %v", err)
+	}
+	defer h.Client.MulticastStop(context.Background(), groupAddr)
+
+	played, err := h.Sink.WaitEnd(ctx, fileDevice)
+	if err != nil {
+		t.Fatalf("failed to wait end of playing: %v", err)
+	}
+	// player late after startAt skips samples from start of file
+	if skipped := len(samples) - len(played); skipped < 0 || skipped > maxSkipped || !bytes.Equal(played, samples[skipped:]) {
+		t.Fatalf("played %d bytes are not end of %d bytes of file", len(played), len(samples))
+	}
+}
+
+// newHarness started for test with transport and codecs of server, harness is closed at end of test
+func newHarness(t *testing.T, transport string, codecs ...string) *Harness {
+	h, err := New(Config{
+		Transport:     transport,
+		Codecs:        codecs,
+		ToneFrequency: toneFrequency,
+	})
+	if err != nil {
+		t.Fatalf("failed to start harness: %v", err)
+	}
+	t.Cleanup(h.Close)
+	return h
+}
+
+// mismatch return index of first different byte
+func mismatch(a, b []byte) int {
+	for i := range a {
+		if i >= len(b) || a[i] != b[i] {
+			return i
+		}
+	}
+	return len(a)
+}
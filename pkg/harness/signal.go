@@ -0,0 +1,53 @@
+package harness
+
+import (
+	"math"
+
+	"audio-service/pkg/converter"
+)
+
+// Tone return frames of sine with frequency in Hz and amplitude 1
+func Tone(frequency float64, rate, frames int) []float64 {
+	samples := make([]float64, frames)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * frequency * float64(i) / float64(rate))
+	}
+	return samples
+}
+
+// Channel return samples of channel from interleaved signal in bytes as float64 in range [-1, 1]
+func Channel(data []byte, channels, channel, bitsPerSample, audioFormat int) []float64 {
+	frameSize := channels * bitsPerSample / 8
+	samples := converter.NewConverter().ToFloat64(data[:len(data)-len(data)%frameSize], bitsPerSample, audioFormat)
+	channelSamples := make([]float64, 0, len(samples)/channels)
+	for i := channel; i < len(samples); i += channels {
+		channelSamples = append(channelSamples, samples[i])
+	}
+	return channelSamples
+}
+
+// Correlation return max of normalized cross-correlation of signal shifted by lag from 0 to maxLag with reference.
+// Correlation is 1 if signal is reference multiplied by positive gain,
+// it compares signals which are not bit-exact after recording and sending.
+func Correlation(signal, reference []float64, maxLag int) (max float64) {
+	for lag := 0; lag <= maxLag && lag < len(signal); lag++ {
+		shifted := signal[lag:]
+		n := len(shifted)
+		if len(reference) < n {
+			n = len(reference)
+		}
+		var sr, ss, rr float64
+		for i := 0; i < n; i++ {
+			sr += shifted[i] * reference[i]
+			ss += shifted[i] * shifted[i]
+			rr += reference[i] * reference[i]
+		}
+		if ss == 0 || rr == 0 {
+			continue
+		}
+		if c := sr / math.Sqrt(ss*rr); c > max {
+			max = c
+		}
+	}
+	return
+}
@@ -0,0 +1,123 @@
+package harness
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"audio-service/pkg/pcm"
+	"audio-service/pkg/playback"
+)
+
+// poll period of waiting for played signal
+const poll = 10 * time.Millisecond
+
+// Sink playback device keeping played signal in memory, signal is played without pacing
+type Sink struct {
+	mutex   sync.Mutex
+	devices map[string]*sinkDevice
+}
+
+// sinkDevice signal of last playing on device
+type sinkDevice struct {
+	data    []byte
+	playing bool
+}
+
+type sinkOutput struct {
+	sink   *Sink
+	device *sinkDevice
+}
+
+// Write samples in memory
+func (o *sinkOutput) Write(buffer []byte) (err error) {
+	o.sink.mutex.Lock()
+	o.device.data = append(o.device.data, buffer...)
+	o.sink.mutex.Unlock()
+	return
+}
+
+// Close end playing on device
+func (o *sinkOutput) Close() error {
+	o.sink.mutex.Lock()
+	o.device.playing = false
+	o.sink.mutex.Unlock()
+	return nil
+}
+
+// Open device, signal of previous playing on device is dropped
+func (s *Sink) Open(deviceName string, channels, rate, bitsPerSample, audioFormat int) (playback.Output, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	d := &sinkDevice{
+		playing: true,
+	}
+	s.devices[deviceName] = d
+	return &sinkOutput{
+		sink:   s,
+		device: d,
+	}, nil
+}
+
+// Devices return virtual device, any name of device can be played
+func (s *Sink) Devices() ([]pcm.Device, error) {
+	return []pcm.Device{
+		pcm.Virtual("default", "sink of harness"),
+	}, nil
+}
+
+// Played return signal played on deviceName, isEnded is true if playing is ended
+func (s *Sink) Played(deviceName string) (data []byte, isEnded bool) {
+	data, isEnded, _ = s.played(deviceName)
+	return
+}
+
+func (s *Sink) played(deviceName string) (data []byte, isEnded, isExist bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	d, isExist := s.devices[deviceName]
+	if !isExist {
+		return
+	}
+	return append([]byte(nil), d.data...), !d.playing, true
+}
+
+// WaitEnd wait end of playing on deviceName and return played signal
+func (s *Sink) WaitEnd(ctx context.Context, deviceName string) (data []byte, err error) {
+	return s.wait(ctx, deviceName, func(data []byte, isEnded bool) bool {
+		return isEnded
+	})
+}
+
+// WaitSize wait size bytes played on deviceName and return played signal
+func (s *Sink) WaitSize(ctx context.Context, deviceName string, size int) (data []byte, err error) {
+	return s.wait(ctx, deviceName, func(data []byte, isEnded bool) bool {
+		return len(data) >= size
+	})
+}
+
+func (s *Sink) wait(ctx context.Context, deviceName string, isDone func(data []byte, isEnded bool) bool) ([]byte, error) {
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+
+	for {
+		data, isEnded, isExist := s.played(deviceName)
+		if isExist && isDone(data, isEnded) {
+			return data, nil
+		}
+		select {
+		case <-ctx.Done():
+			return data, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewSink ...
+func NewSink() *Sink {
+	return &Sink{
+		devices: make(map[string]*sinkDevice),
+	}
+}
@@ -0,0 +1,115 @@
+package mixer
+
+import (
+	"io"
+	"math"
+)
+
+const bytePerSample = 2
+
+type converter interface {
+	ToInt16([]byte) []int16
+	ToByte([]int16) []byte
+}
+
+type source struct {
+	r    io.Reader
+	gain float64
+	done bool
+}
+
+type mix struct {
+	converter converter
+
+	sources []*source
+	buff    []byte
+	sum     []float64
+}
+
+// Read mixed signal.
+// The same count of bytes is read from every source, so all sources stay aligned by sample frame.
+// Finished sources are mixed as silence, io.EOF is returned when all sources are finished.
+func (m *mix) Read(p []byte) (n int, err error) {
+	size := len(p) - len(p)%bytePerSample
+	if size == 0 {
+		return 0, io.ErrShortBuffer
+	}
+	if cap(m.buff) < size {
+		m.buff = make([]byte, size)
+		m.sum = make([]float64, size/bytePerSample)
+	}
+	buff, sum := m.buff[:size], m.sum[:size/bytePerSample]
+	for i := range sum {
+		sum[i] = 0
+	}
+
+	for _, s := range m.sources {
+		if s.done {
+			continue
+		}
+		l, err := io.ReadFull(s.r, buff)
+		if err != nil {
+			s.done = true
+		}
+		l -= l % bytePerSample
+		for i, sample := range m.converter.ToInt16(buff[:l]) {
+			sum[i] += float64(sample) * s.gain
+		}
+		if l > n {
+			n = l
+		}
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+
+	samples := make([]int16, n/bytePerSample)
+	for i := range samples {
+		samples[i] = clip(sum[i])
+	}
+	copy(p, m.converter.ToByte(samples))
+	return
+}
+
+// clip protects sum of signals from int16 overflow
+func clip(sample float64) int16 {
+	switch {
+	case sample > math.MaxInt16:
+		return math.MaxInt16
+	case sample < math.MinInt16:
+		return math.MinInt16
+	}
+	return int16(sample)
+}
+
+// Mixer overlay audio signals
+type Mixer struct {
+	converter converter
+}
+
+// Reader return reader of signal mixed from sources sample by sample with gains.
+// All sources must be S16LE with the same channels and rate.
+func (m *Mixer) Reader(sources []io.Reader, gains []float64) io.Reader {
+	mix := &mix{
+		converter: m.converter,
+		sources:   make([]*source, 0, len(sources)),
+	}
+	for i, r := range sources {
+		gain := 1.0
+		if i < len(gains) {
+			gain = gains[i]
+		}
+		mix.sources = append(mix.sources, &source{
+			r:    r,
+			gain: gain,
+		})
+	}
+	return mix
+}
+
+// NewMixer ...
+func NewMixer(converter converter) *Mixer {
+	return &Mixer{
+		converter: converter,
+	}
+}
@@ -0,0 +1,45 @@
+package mp3
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+const (
+	// decoder always returns 16 bits stereo
+	mp3Channels      = 2
+	mp3BitsPerSample = 16
+	mp3AudioFormat   = 1
+)
+
+// MP3 audio file
+type MP3 struct{}
+
+// Match data starts with ID3 tag or mpeg audio frame sync
+func (m *MP3) Match(data []byte) bool {
+	if bytes.HasPrefix(data, []byte("ID3")) {
+		return true
+	}
+	return len(data) > 1 && data[0] == 0xff && data[1]&0xe0 == 0xe0
+}
+
+// Reader mp3 file, samples are decoded to 16 bits stereo PCM
+func (m *MP3) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error) {
+	decoder, err := mp3.NewDecoder(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	r = decoder
+	channels = mp3Channels
+	rate = uint32(decoder.SampleRate())
+	bitsPerSample = mp3BitsPerSample
+	audioFormat = mp3AudioFormat
+	return
+}
+
+// NewMP3 return handler mp3 file
+func NewMP3() *MP3 {
+	return &MP3{}
+}
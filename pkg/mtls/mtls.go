@@ -0,0 +1,96 @@
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	// ErrWrongCA file of certificate authority has no PEM certificates
+	ErrWrongCA = errors.New("wrong certificate authority")
+	// ErrNotAllowed certificate of peer is signed by CA but its name is not allowed
+	ErrNotAllowed = errors.New("peer is not allowed")
+)
+
+// ServerCredentials of player or recorder.
+// Clients must present certificate signed by CA in caFile,
+// allowed names of client certificates (common name or DNS name), empty - any certificate signed by CA.
+func ServerCredentials(certFile, keyFile, caFile string, allowed []string) (credentials.TransportCredentials, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := loadCA(caFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates:          []tls.Certificate{certificate},
+		ClientAuth:            tls.RequireAndVerifyClientCert,
+		ClientCAs:             ca,
+		MinVersion:            tls.VersionTLS12,
+		VerifyPeerCertificate: allow(allowed),
+	}), nil
+}
+
+// ClientCredentials of server for connections to players and recorders.
+// Certificates of players and recorders are verified by CA in caFile and must contain their addresses.
+func ClientCredentials(certFile, keyFile, caFile string) (credentials.TransportCredentials, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := loadCA(caFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      ca,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+func loadCA(caFile string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	ca := x509.NewCertPool()
+	if !ca.AppendCertsFromPEM(data) {
+		return nil, ErrWrongCA
+	}
+	return ca, nil
+}
+
+// allow return verification of verified peer certificate by allowed names
+func allow(allowed []string) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	names := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		names[name] = struct{}{}
+	}
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			if len(chain) == 0 {
+				continue
+			}
+			peer := chain[0]
+			if _, isExist := names[peer.Subject.CommonName]; isExist {
+				return nil
+			}
+			for _, name := range peer.DNSNames {
+				if _, isExist := names[name]; isExist {
+					return nil
+				}
+			}
+		}
+		return ErrNotAllowed
+	}
+}
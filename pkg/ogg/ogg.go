@@ -0,0 +1,72 @@
+package ogg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/jfreymuth/oggvorbis"
+)
+
+const (
+	// samples are decoded to IEEE float
+	oggBitsPerSample = 32
+	oggAudioFormat   = 3
+
+	bytePerFloat32 = 4
+)
+
+// OGG audio file with vorbis stream
+type OGG struct{}
+
+// Match data starts with ogg page
+func (o *OGG) Match(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("OggS"))
+}
+
+// Reader ogg vorbis file, samples are decoded to 32 bits float
+func (o *OGG) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error) {
+	vorbis, err := oggvorbis.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	channels = uint16(vorbis.Channels())
+	rate = uint32(vorbis.SampleRate())
+	bitsPerSample = oggBitsPerSample
+	audioFormat = oggAudioFormat
+	r = &reader{
+		vorbis: vorbis,
+	}
+	return
+}
+
+type reader struct {
+	vorbis  *oggvorbis.Reader
+	samples []float32
+}
+
+// Read decoded samples, p is filled by whole samples
+func (r *reader) Read(p []byte) (n int, err error) {
+	size := len(p) / bytePerFloat32
+	if size < r.vorbis.Channels() {
+		size = r.vorbis.Channels()
+	}
+	if len(r.samples) < size {
+		r.samples = make([]float32, size)
+	}
+	l, err := r.vorbis.Read(r.samples[:size])
+	if l*bytePerFloat32 > len(p) {
+		// p is less than one frame
+		return 0, io.ErrShortBuffer
+	}
+	for i, s := range r.samples[:l] {
+		binary.LittleEndian.PutUint32(p[i*bytePerFloat32:], math.Float32bits(s))
+	}
+	return l * bytePerFloat32, err
+}
+
+// NewOGG return handler ogg file
+func NewOGG() *OGG {
+	return &OGG{}
+}
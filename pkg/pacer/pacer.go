@@ -0,0 +1,40 @@
+package pacer
+
+import (
+	"time"
+)
+
+// maxLag of frames behind real time, longer lag is pause and pacing is started again
+const maxLag = 100 * time.Millisecond
+
+// Pacer blocks passing of frames for time of their playing or recording as sound card does
+type Pacer struct {
+	rate   int
+	start  time.Time
+	frames int64
+}
+
+// Wait time of passing frames, pacing is started again after pause in passing
+func (p *Pacer) Wait(frames int) {
+	if p.rate == 0 {
+		return
+	}
+	now := time.Now()
+	if p.start.IsZero() || p.end().Add(maxLag).Before(now) {
+		p.start, p.frames = now, 0
+	}
+	p.frames += int64(frames)
+	time.Sleep(p.end().Sub(now))
+}
+
+// end of passing of frames
+func (p *Pacer) end() time.Time {
+	return p.start.Add(time.Duration(p.frames) * time.Second / time.Duration(p.rate))
+}
+
+// NewPacer with rate of frames, pacer with zero rate does not block
+func NewPacer(rate int) *Pacer {
+	return &Pacer{
+		rate: rate,
+	}
+}
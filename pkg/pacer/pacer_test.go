@@ -0,0 +1,63 @@
+package pacer
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	rate = 1000
+	// tolerance of sleeping of pacer
+	tolerance = 20 * time.Millisecond
+)
+
+func TestWait(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []int
+		pause  time.Duration
+		want   time.Duration
+	}{
+		{name: "one", frames: []int{50}, want: 50 * time.Millisecond},
+		{name: "sequence", frames: []int{20, 20, 20}, want: 60 * time.Millisecond},
+		// lag longer than maxLag is pause, frames before pause are not waited again
+		{name: "pause", frames: []int{20, 20}, pause: 2 * maxLag, want: 40*time.Millisecond + 2*maxLag},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPacer(rate)
+			start := time.Now()
+			for i, frames := range tt.frames {
+				if i != 0 {
+					time.Sleep(tt.pause)
+				}
+				p.Wait(frames)
+			}
+			if elapsed := time.Since(start); elapsed < tt.want-tolerance || elapsed > tt.want+tolerance {
+				t.Fatalf("elapsed %s, want %s", elapsed, tt.want)
+			}
+		})
+	}
+}
+
+// TestLag frames are waited in total without pauses shorter than maxLag
+func TestLag(t *testing.T) {
+	p := NewPacer(rate)
+	start := time.Now()
+	p.Wait(50)
+	time.Sleep(maxLag / 2)
+	p.Wait(100)
+	if elapsed, want := time.Since(start), 150*time.Millisecond; elapsed < want-tolerance || elapsed > want+tolerance {
+		t.Fatalf("elapsed %s, want %s", elapsed, want)
+	}
+}
+
+func TestZeroRate(t *testing.T) {
+	p := NewPacer(0)
+	start := time.Now()
+	p.Wait(rate)
+	if elapsed := time.Since(start); elapsed > tolerance {
+		t.Fatalf("pacer with zero rate waited %s", elapsed)
+	}
+}
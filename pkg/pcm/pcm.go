@@ -0,0 +1,172 @@
+package pcm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Streams of devices
+const (
+	Playback = "playback"
+	Capture  = "capture"
+)
+
+// audioFormatFloat of float samples as in wav header
+const audioFormatFloat = 3
+
+// procPCM list of pcm devices of all cards
+const procPCM = "/proc/asound/pcm"
+
+// procStatus of first substream of pcm device: card, device and p for playback or c for capture
+const procStatus = "/proc/asound/card%d/pcm%d%s/sub0/status"
+
+// statusClosed of substream not opened by anybody
+const statusClosed = "closed"
+
+// candidates of capabilities probed on device
+var (
+	candidateRates    = []int{8000, 11025, 16000, 22050, 32000, 44100, 48000, 88200, 96000, 176400, 192000}
+	candidateChannels = []int{1, 2, 3, 4, 5, 6, 7, 8}
+	candidateFormats  = []Format{
+		{BitsPerSample: 8, AudioFormat: 1},
+		{BitsPerSample: 16, AudioFormat: 1},
+		{BitsPerSample: 24, AudioFormat: 1},
+		{BitsPerSample: 32, AudioFormat: 1},
+		{BitsPerSample: 32, AudioFormat: audioFormatFloat},
+	}
+)
+
+// Format of samples as in wav header: AudioFormat 1 - PCM, 3 - IEEE float
+type Format struct {
+	BitsPerSample int
+	AudioFormat   int
+}
+
+// Device pcm device of sound card
+type Device struct {
+	// Name of device for opening: hw:card,device
+	Name        string
+	Card        int
+	Device      int
+	Description string
+	// Rates, Channels and Formats supported by device, they are empty if device is busy
+	Rates    []int
+	Channels []int
+	Formats  []Format
+}
+
+// Opener open device of stream with configuration and close it, return true if configuration is supported
+type Opener func(name string, channels int, format Format, rate int) bool
+
+// Virtual device without sound card, it supports all candidates of capabilities
+func Virtual(name, description string) Device {
+	return Device{
+		Name:        name,
+		Description: description,
+		Rates:       append([]int(nil), candidateRates...),
+		Channels:    append([]int(nil), candidateChannels...),
+		Formats:     append([]Format(nil), candidateFormats...),
+	}
+}
+
+// List return pcm devices of stream with capabilities.
+// Capabilities are probed by open, opening of busy hw device blocks,
+// so devices which substream is not closed are not probed and listed without capabilities.
+func List(stream string, open Opener) (devices []Device, err error) {
+	f, err := os.Open(procPCM)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	if devices, err = parse(f, stream); err != nil {
+		return
+	}
+	for i := range devices {
+		if !isBusy(devices[i], stream) {
+			probe(&devices[i], open)
+		}
+	}
+	return
+}
+
+// isBusy check status of substream of device, device is busy if status is unknown
+func isBusy(d Device, stream string) bool {
+	direction := "p"
+	if stream == Capture {
+		direction = "c"
+	}
+	status, err := ioutil.ReadFile(fmt.Sprintf(procStatus, d.Card, d.Device, direction))
+	return err != nil || strings.TrimSpace(string(status)) != statusClosed
+}
+
+// parse lines of /proc/asound/pcm: "00-00: id : name : playback 1 : capture 1"
+func parse(r io.Reader, stream string) (devices []Device, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) < 3 {
+			continue
+		}
+		var card, device int
+		if _, err := fmt.Sscanf(strings.TrimSpace(fields[0]), "%d-%d", &card, &device); err != nil {
+			continue
+		}
+		for _, f := range fields[3:] {
+			if s := strings.Fields(f); len(s) == 2 && s[0] == stream {
+				devices = append(devices, Device{
+					Name:        "hw:" + strconv.Itoa(card) + "," + strconv.Itoa(device),
+					Card:        card,
+					Device:      device,
+					Description: strings.TrimSpace(fields[2]),
+				})
+			}
+		}
+	}
+	return devices, scanner.Err()
+}
+
+// probe capabilities of device.
+// Base configuration is the first supported combination of candidates,
+// then each of rate, channels and format is changed while others are from base.
+func probe(d *Device, open Opener) {
+	channels, format, rate, isExist := base(d.Name, open)
+	if !isExist {
+		return
+	}
+
+	for _, r := range candidateRates {
+		if open(d.Name, channels, format, r) {
+			d.Rates = append(d.Rates, r)
+		}
+	}
+	for _, c := range candidateChannels {
+		if open(d.Name, c, format, rate) {
+			d.Channels = append(d.Channels, c)
+		}
+	}
+	for _, f := range candidateFormats {
+		if open(d.Name, channels, f, rate) {
+			d.Formats = append(d.Formats, f)
+		}
+	}
+}
+
+// base return first supported configuration of device, stereo and mono are tried first
+func base(name string, open Opener) (channels int, format Format, rate int, isExist bool) {
+	for _, format = range candidateFormats {
+		for _, channels = range []int{2, 1} {
+			for _, rate = range candidateRates {
+				if open(name, channels, format, rate) {
+					return channels, format, rate, true
+				}
+			}
+		}
+	}
+	return
+}
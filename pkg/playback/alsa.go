@@ -0,0 +1,109 @@
+//go:build cgo
+// +build cgo
+
+package playback
+
+import (
+	alsa "github.com/cocoonlife/goalsa"
+
+	"audio-service/pkg/pcm"
+)
+
+var formatList = map[int]alsa.Format{
+	8:  alsa.FormatS8,
+	16: alsa.FormatS16LE,
+	24: alsa.FormatS24LE,
+	32: alsa.FormatS32LE,
+}
+
+// ALSA opener of sound card devices
+type ALSA struct {
+	converter converter
+}
+
+type alsaOutput struct {
+	device        *alsa.PlaybackDevice
+	converter     converter
+	bitsPerSample int
+	audioFormat   int
+}
+
+// Write samples on device, device is prepared again by alsa after underrun
+func (o *alsaOutput) Write(buffer []byte) (err error) {
+	if _, err = o.device.Write(o.samples(buffer)); err == alsa.ErrUnderrun {
+		err = ErrUnderrun
+	}
+	return
+}
+
+// samples convert bytes to slice with type for alsa format
+func (o *alsaOutput) samples(src []byte) interface{} {
+	if o.audioFormat == audioFormatFloat {
+		return o.converter.ToFloat32(src)
+	}
+	switch o.bitsPerSample {
+	case 8:
+		return o.converter.ToInt8(src)
+	case 24:
+		return o.converter.ToInt24(src)
+	case 32:
+		return o.converter.ToInt32(src)
+	}
+	return o.converter.ToInt16(src)
+}
+
+// Close device
+func (o *alsaOutput) Close() error {
+	o.device.Close()
+	return nil
+}
+
+// Open alsa playback device
+func (a *ALSA) Open(deviceName string, channels, rate, bitsPerSample, audioFormat int) (Output, error) {
+	device, err := alsa.NewPlaybackDevice(
+		deviceName,
+		channels,
+		alsaFormat(bitsPerSample, audioFormat),
+		rate,
+		alsa.BufferParams{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &alsaOutput{
+		device:        device,
+		converter:     a.converter,
+		bitsPerSample: bitsPerSample,
+		audioFormat:   audioFormat,
+	}, nil
+}
+
+// Devices return playback pcm devices with supported rates, channels and formats, busy devices are listed without them
+func (a *ALSA) Devices() ([]pcm.Device, error) {
+	return pcm.List(pcm.Playback, probe)
+}
+
+// probe open device with configuration and close it
+func probe(name string, channels int, format pcm.Format, rate int) bool {
+	out, err := alsa.NewPlaybackDevice(name, channels, alsaFormat(format.BitsPerSample, format.AudioFormat), rate, alsa.BufferParams{})
+	if err != nil {
+		return false
+	}
+	out.Close()
+	return true
+}
+
+// alsaFormat of samples with bitsPerSample or float samples
+func alsaFormat(bitsPerSample, audioFormat int) alsa.Format {
+	if audioFormat == audioFormatFloat {
+		return alsa.FormatFloatLE
+	}
+	return formatList[bitsPerSample]
+}
+
+// NewALSA ...
+func NewALSA(converter converter) *ALSA {
+	return &ALSA{
+		converter: converter,
+	}
+}
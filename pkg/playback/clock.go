@@ -0,0 +1,102 @@
+package playback
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// period of measuring drift of playing from wall clock
+	driftPeriod = time.Second
+	// drift less than driftThreshold is not corrected
+	driftThreshold = 2 * time.Millisecond
+	// max correction per period, big correction is spread over several periods to be inaudible
+	maxCorrection = 2 * time.Millisecond
+)
+
+// clock keeps playing in sync with wall clock from start.
+// Samples written on device are counted, device plays them at its own rate,
+// drift from wall clock is corrected by dropping or repeating one frame per write.
+type clock struct {
+	start time.Time
+	rate  int
+
+	// frames read from storage since start
+	frames    int64
+	nextCheck time.Time
+	// offset of frames from wall clock at first check, it is latency of device buffer
+	baseline   int64
+	isMeasured bool
+	// frames to correct, > 0 - frames to repeat, < 0 - frames to drop
+	correction int64
+}
+
+// wait start of playing
+func (c *clock) wait(ctx context.Context) {
+	timer := time.NewTimer(time.Until(c.start))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// late return frames to skip if playing is started after start, they are counted as read
+func (c *clock) late(now time.Time) int {
+	frames := int64(now.Sub(c.start).Seconds() * float64(c.rate))
+	if frames <= 0 {
+		return 0
+	}
+	c.frames += frames
+	return int(frames)
+}
+
+// adjust count frames read from storage and return frames to correct in this write:
+// 1 - repeat one frame, -1 - drop one frame, 0 - write as is
+func (c *clock) adjust(frames int, now time.Time) int {
+	c.frames += int64(frames)
+
+	if now.After(c.nextCheck) {
+		c.nextCheck = now.Add(driftPeriod)
+		offset := c.frames - int64(now.Sub(c.start).Seconds()*float64(c.rate))
+		if !c.isMeasured {
+			c.baseline, c.isMeasured = offset, true
+		} else {
+			c.correction = c.limit(offset - c.baseline)
+		}
+	}
+
+	switch {
+	case c.correction > 0:
+		c.correction--
+		return 1
+	case c.correction < 0:
+		c.correction++
+		return -1
+	}
+	return 0
+}
+
+// limit drift to correction per period, drift less than threshold is not corrected
+func (c *clock) limit(drift int64) int64 {
+	threshold := int64(driftThreshold.Seconds() * float64(c.rate))
+	max := int64(maxCorrection.Seconds() * float64(c.rate))
+	switch {
+	case drift > -threshold && drift < threshold:
+		return 0
+	case drift > max:
+		return max
+	case drift < -max:
+		return -max
+	}
+	return drift
+}
+
+func newClock(start time.Time, rate int) *clock {
+	return &clock{
+		start:     start,
+		rate:      rate,
+		nextCheck: start.Add(driftPeriod),
+	}
+}
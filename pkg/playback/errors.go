@@ -7,4 +7,10 @@ import (
 var (
 	// ErrFormatNotExist not exist format for alsa playback device
 	ErrFormatNotExist = errors.New("format for alsa not exist")
+	// ErrWrongChannels number of channels is not positive
+	ErrWrongChannels = errors.New("number of channels must be positive")
+	// ErrUnderrun device played all samples before next write, device is prepared for next write
+	ErrUnderrun = errors.New("underrun")
+	// ErrWrongVolume volume level is negative
+	ErrWrongVolume = errors.New("volume level must not be negative")
 )
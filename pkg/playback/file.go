@@ -0,0 +1,75 @@
+package playback
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"audio-service/pkg/pacer"
+	"audio-service/pkg/pcm"
+)
+
+type wavWriter interface {
+	Writer(fileName string, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (io.WriteCloser, error)
+}
+
+// File opener of devices writing played signal in wav files named by device in directory
+type File struct {
+	wav wavWriter
+	dir string
+}
+
+type fileOutput struct {
+	file      io.WriteCloser
+	pacer     *pacer.Pacer
+	frameSize int
+}
+
+// Write samples in file in real time
+func (o *fileOutput) Write(buffer []byte) (err error) {
+	o.pacer.Wait(len(buffer) / o.frameSize)
+	_, err = o.file.Write(buffer)
+	return
+}
+
+// Close file
+func (o *fileOutput) Close() error {
+	return o.file.Close()
+}
+
+// Open device, file of previous playing on device is overwritten
+func (f *File) Open(deviceName string, channels, rate, bitsPerSample, audioFormat int) (Output, error) {
+	file, err := f.wav.Writer(f.fileName(deviceName), uint16(channels), uint32(rate), uint16(bitsPerSample), uint16(audioFormat))
+	if err != nil {
+		return nil, err
+	}
+	return &fileOutput{
+		file:      file,
+		pacer:     pacer.NewPacer(rate),
+		frameSize: channels * bitsPerSample / 8,
+	}, nil
+}
+
+// fileName of device in directory, separators of path are replaced in name of device
+func (f *File) fileName(deviceName string) string {
+	return filepath.Join(f.dir, strings.ReplaceAll(deviceName, string(filepath.Separator), "_"))
+}
+
+// Devices return virtual device, any name of device can be played
+func (f *File) Devices() ([]pcm.Device, error) {
+	return []pcm.Device{
+		pcm.Virtual("default", "wav files in "+f.dir),
+	}, nil
+}
+
+// NewFile directory of files is created if it does not exist
+func NewFile(wav wavWriter, dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &File{
+		wav: wav,
+		dir: dir,
+	}, nil
+}
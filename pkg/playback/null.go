@@ -0,0 +1,45 @@
+package playback
+
+import (
+	"audio-service/pkg/pacer"
+	"audio-service/pkg/pcm"
+)
+
+// Null opener of devices dropping played signal
+type Null struct{}
+
+type nullOutput struct {
+	pacer     *pacer.Pacer
+	frameSize int
+}
+
+// Write drop samples in real time
+func (o *nullOutput) Write(buffer []byte) (err error) {
+	o.pacer.Wait(len(buffer) / o.frameSize)
+	return
+}
+
+// Close ...
+func (o *nullOutput) Close() error {
+	return nil
+}
+
+// Open device
+func (n *Null) Open(deviceName string, channels, rate, bitsPerSample, audioFormat int) (Output, error) {
+	return &nullOutput{
+		pacer:     pacer.NewPacer(rate),
+		frameSize: channels * bitsPerSample / 8,
+	}, nil
+}
+
+// Devices return virtual device, any name of device can be played
+func (n *Null) Devices() ([]pcm.Device, error) {
+	return []pcm.Device{
+		pcm.Virtual("default", "null device"),
+	}, nil
+}
+
+// NewNull ...
+func NewNull() *Null {
+	return &Null{}
+}
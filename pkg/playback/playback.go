@@ -3,68 +3,258 @@ package playback
 import (
 	"context"
 	"io"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	alsa "github.com/cocoonlife/goalsa"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+
+	"audio-service/pkg/pcm"
+	"audio-service/pkg/volume"
+)
+
+const (
+	// audioFormat of float samples as in wav header
+	audioFormatFloat = 3
+	// writeRetries of samples after error of device, playing is ended after them
+	writeRetries = 3
+	// writeRetryDelay after error of device which is not underrun
+	writeRetryDelay = 10 * time.Millisecond
+	// skipWait before next reading of empty storage while late samples are skipped
+	skipWait = 10 * time.Millisecond
 )
 
-// var formatList map[int]alsa.Format = map[int]alsa.Format{
-// 	// 16: alsa.FormatS16LE,
-// 	24: alsa.FormatS24LE,
-// 	32: alsa.FormatS32LE,
-// }
+// bitsPerSampleList supported by devices
+var bitsPerSampleList = map[int]bool{8: true, 16: true, 24: true, 32: true}
 
 type converter interface {
+	ToInt8([]byte) []int8
 	ToInt16([]byte) []int16
+	ToInt24([]byte) []int32
+	ToInt32([]byte) []int32
+	ToFloat32([]byte) []float32
+	ToFloat64(src []byte, bitsPerSample, audioFormat int) []float64
+	FromFloat64(src []float64, bitsPerSample, audioFormat int) []byte
+}
+
+// Output of samples on device, write blocks while device plays previous samples
+type Output interface {
+	Write(buffer []byte) (err error)
+	Close() error
+}
+
+// opener of devices: sound card or its replacement for working without sound card
+type opener interface {
+	Open(deviceName string, channels, rate, bitsPerSample, audioFormat int) (Output, error)
+	Devices() ([]pcm.Device, error)
 }
 
 // Playback device
 type Playback struct {
+	opener    opener
 	converter converter
 	buffSize  int
+	logger    log.Logger
+
+	volumeMutex sync.Mutex
+	volume      map[string]*volume.Volume
+
+	xrunsMutex sync.Mutex
+	xruns      map[string]*xruns
+}
+
+// xruns counters of device from start of player
+type xruns struct {
+	underruns uint64
+	errors    uint64
+}
+
+// Xruns return underruns and other errors of writing on deviceName from start of player
+func (d *Playback) Xruns(deviceName string) (underruns, errors uint64) {
+	x := d.deviceXruns(deviceName)
+	return atomic.LoadUint64(&x.underruns), atomic.LoadUint64(&x.errors)
+}
+
+func (d *Playback) deviceXruns(deviceName string) *xruns {
+	d.xrunsMutex.Lock()
+	defer d.xrunsMutex.Unlock()
+
+	x, isExist := d.xruns[deviceName]
+	if !isExist {
+		x = &xruns{}
+		d.xruns[deviceName] = x
+	}
+	return x
+}
+
+// Devices return playback devices with supported rates, channels and formats
+func (d *Playback) Devices() ([]pcm.Device, error) {
+	return d.opener.Devices()
+}
+
+// SetVolume set volume level on deviceName, 1 - original loudness
+func (d *Playback) SetVolume(deviceName string, level float64) (err error) {
+	if level < 0 {
+		return ErrWrongVolume
+	}
+	d.deviceVolume(deviceName).Set(level)
+	return
+}
+
+// Mute or unmute deviceName
+func (d *Playback) Mute(deviceName string, mute bool) (err error) {
+	d.deviceVolume(deviceName).Mute(mute)
+	return
+}
+
+func (d *Playback) deviceVolume(deviceName string) *volume.Volume {
+	d.volumeMutex.Lock()
+	defer d.volumeMutex.Unlock()
+
+	v, isExist := d.volume[deviceName]
+	if !isExist {
+		v = volume.NewVolume()
+		d.volume[deviceName] = v
+	}
+	return v
 }
 
-// Play audio on deviceName
-func (d *Playback) Play(ctx context.Context, deviceName string, channels, rate, bitsPerSample int, r io.Reader) (err error) {
-	// format, isExist := formatList[bitsPerSample]
-	// if !isExist {
-	// 	err = ErrFormatNotExist
-	// 	return
-	// }
-
-	out, err := alsa.NewPlaybackDevice(
-		deviceName,
-		channels,
-		alsa.FormatS16LE,
-		rate,
-		alsa.BufferParams{},
-	)
+// Play audio on deviceName.
+// Samples in r are little-endian signed integer with bitsPerSample (8 bits samples are unsigned as in wav)
+// or float32 if audioFormat is 3.
+// Not zero startAt delays playing until startAt and keeps playing in sync with wall clock.
+// Playing is ended and device is closed when ctx is done, r returns io.EOF or other error or device fails, then done is closed.
+// underrun is called on each underrun of device.
+func (d *Playback) Play(ctx context.Context, deviceName string, channels, rate, bitsPerSample, audioFormat int, startAt time.Time, r io.Reader, underrun func()) (done <-chan struct{}, err error) {
+	if !bitsPerSampleList[bitsPerSample] || audioFormat == audioFormatFloat && bitsPerSample != 32 {
+		err = ErrFormatNotExist
+		return
+	}
+	frameSize := channels * bitsPerSample / 8
+	if frameSize <= 0 {
+		err = ErrWrongChannels
+		return
+	}
+
+	out, err := d.opener.Open(deviceName, channels, rate, bitsPerSample, audioFormat)
 	if err != nil {
 		return
 	}
 
+	volume := d.deviceVolume(deviceName)
+	xruns := d.deviceXruns(deviceName)
+	ended := make(chan struct{})
 	go func() {
-		<-ctx.Done()
-		out.Close()
-	}()
+		defer close(ended)
+		defer out.Close()
 
-	go func() {
-		samples := make([]byte, d.buffSize)
-		for {
-			if l, err := r.Read(samples); err == nil {
-				out.Write(d.converter.ToInt16(samples[:l]))
+		samples := make([]byte, d.buffSize+frameSize)
+
+		var clock *clock
+		if !startAt.IsZero() {
+			clock = newClock(startAt, rate)
+			clock.wait(ctx)
+			skip(ctx, r, clock.late(time.Now())*frameSize, samples)
+		}
+
+		rest := 0
+		for ctx.Err() == nil {
+			l, err := r.Read(samples[rest:])
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				level.Error(d.logger).Log("msg", "playing is ended", "device", deviceName, "err", err)
+				return
 			}
+			l += rest
+			size := l - l%frameSize
+			buffer := samples[:size]
+			if clock != nil && size != 0 {
+				switch clock.adjust(size/frameSize, time.Now()) {
+				case 1:
+					// full slice expression makes copy not to overwrite rest of samples
+					buffer = append(buffer[:size:size], buffer[size-frameSize:]...)
+				case -1:
+					buffer = buffer[:size-frameSize]
+				}
+			}
+			// storage without data returns nothing, alsa can not write empty buffer
+			if len(buffer) != 0 {
+				if !volume.Passthrough() {
+					signal := d.converter.ToFloat64(buffer, bitsPerSample, audioFormat)
+					volume.Apply(signal, channels, rate)
+					buffer = d.converter.FromFloat64(signal, bitsPerSample, audioFormat)
+				}
+				if err := d.write(out, deviceName, xruns, buffer, underrun); err != nil {
+					level.Error(d.logger).Log("msg", "playing is ended", "device", deviceName, "err", err)
+					return
+				}
+			}
+			rest = copy(samples, samples[size:l])
 		}
 	}()
-	return
+	return ended, nil
 }
 
-// NewPlayback ...
+// write samples to device.
+// Device is prepared again after underrun, so samples are written again.
+// Other errors are retried writeRetries times.
+func (d *Playback) write(out Output, deviceName string, xruns *xruns, buffer []byte, underrun func()) (err error) {
+	for retry := 0; ; retry++ {
+		if err = out.Write(buffer); err == nil {
+			return
+		}
+		if err == ErrUnderrun {
+			level.Warn(d.logger).Log("msg", "underrun", "device", deviceName, "underruns", atomic.AddUint64(&xruns.underruns, 1))
+			underrun()
+		} else {
+			level.Warn(d.logger).Log("msg", "write error", "device", deviceName, "errors", atomic.AddUint64(&xruns.errors, 1), "err", err)
+			time.Sleep(writeRetryDelay)
+		}
+		if retry == writeRetries {
+			return
+		}
+	}
+}
+
+// skip size bytes of r using buffer, skipping is ended by error of r
+func skip(ctx context.Context, r io.Reader, size int, buffer []byte) {
+	for size > 0 && ctx.Err() == nil {
+		if size < len(buffer) {
+			buffer = buffer[:size]
+		}
+		l, err := r.Read(buffer)
+		if err != nil {
+			return
+		}
+		if l == 0 {
+			// storage is empty, waiting for received signal
+			select {
+			case <-ctx.Done():
+			case <-time.After(skipWait):
+			}
+			continue
+		}
+		size -= l
+	}
+}
+
+// NewPlayback devices are opened by opener, underruns and errors of devices are logged by logger
 func NewPlayback(
+	opener opener,
 	converter converter,
 	buffSize int,
+	logger log.Logger,
 ) *Playback {
 	return &Playback{
+		opener:    opener,
 		converter: converter,
 		buffSize:  buffSize,
+		logger:    logger,
+
+		volume: make(map[string]*volume.Volume),
+		xruns:  make(map[string]*xruns),
 	}
 }
@@ -3,28 +3,39 @@ package player
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
 )
 
+// pool of grpc connections shared by clients
+type pool interface {
+	Call(ctx context.Context, addr string) (callCtx context.Context, cc *grpc.ClientConn, done func(), err error)
+	Stream(addr string) (cc *grpc.ClientConn, done func(), err error)
+}
+
 // Client rpc player
 type Client struct {
 	hostLayout  string
 	controlPort string
+	conns       pool
+}
+
+func (c *Client) addr(ip string) string {
+	return fmt.Sprintf(c.hostLayout, ip, c.controlPort)
 }
 
 // State return all busy ports, devices on player and existing storage
 func (c *Client) State(ctx context.Context, ip string) (ports, storages, devices []string, err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, ip, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
 	if res, err := NewPlayerClient(conn).
 		State(
@@ -40,18 +51,18 @@ func (c *Client) State(ctx context.Context, ip string) (ports, storages, devices
 // UUID of the storage existing on the player
 // if the storage with UUID does not exist or the UUID is zero, a new storage will be created on the player
 // The signal will be stored in the storage sUUID
-func (c *Client) ReceiveStart(ctx context.Context, ip, port string, uuid *string) (sUUID string, err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, ip, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+// codecs of signal in order of preference, player returns chosen codec, pcm if codecs are not supported
+// New storage keeps content for rewind and replay if replay is set.
+func (c *Client) ReceiveStart(ctx context.Context, ip, port string, uuid *string, codecs []string, replay bool) (sUUID, codec string, err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 	req := &StartReceiveRequest{
-		Port: port,
+		Port:   port,
+		Codecs: codecs,
+		Replay: replay,
 	}
 	if uuid != nil {
 		req.StorageUUID = &wrapperspb.StringValue{
@@ -64,22 +75,18 @@ func (c *Client) ReceiveStart(ctx context.Context, ip, port string, uuid *string
 			ctx,
 			req,
 		); err == nil {
-		sUUID = res.StorageUUID
+		sUUID, codec = res.StorageUUID, res.Codec
 	}
 	return
 }
 
 // ReceiveStop rpc request to player with ip for stop receive signal from server on port.
 func (c *Client) ReceiveStop(ctx context.Context, ip, port string) (err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, ip, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
 	_, err = NewPlayerClient(conn).
 		ReceiveStop(
@@ -91,42 +98,42 @@ func (c *Client) ReceiveStop(ctx context.Context, ip, port string) (err error) {
 }
 
 // Play rpc request to player with ip for play audio signal from storage with UUID on deviceName
-// channels, rate - playback options
-func (c *Client) Play(ctx context.Context, ip, UUID, deviceName string, channels, rate, bitsPerSample uint32) (err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, ip, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+// channels, rate, bitsPerSample, audioFormat - playback options
+// not zero startAt - time to start playing, player keeps playing in sync with its wall clock
+func (c *Client) Play(ctx context.Context, ip, UUID, deviceName string, channels, rate, bitsPerSample, audioFormat uint32, startAt time.Time) (err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
+
+	req := &StartPlayRequest{
+		DeviceName:    deviceName,
+		Channels:      channels,
+		Rate:          rate,
+		BitsPerSample: bitsPerSample,
+		StorageUUID:   UUID,
+		AudioFormat:   audioFormat,
+	}
+	if !startAt.IsZero() {
+		req.StartAt = startAt.UnixNano()
+	}
 
 	_, err = NewPlayerClient(conn).
 		Play(
 			ctx,
-			&StartPlayRequest{
-				DeviceName:    deviceName,
-				Channels:      channels,
-				Rate:          rate,
-				BitsPerSample: bitsPerSample,
-				StorageUUID:   UUID,
-			})
+			req,
+		)
 	return
 }
 
 // Stop rpc request to player with ip for stop audio
 func (c *Client) Stop(ctx context.Context, playerIP, deviceName string) (err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, playerIP, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(playerIP))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
 	_, err = NewPlayerClient(conn).
 		Stop(
@@ -138,17 +145,81 @@ func (c *Client) Stop(ctx context.Context, playerIP, deviceName string) (err err
 	return
 }
 
+// Wait rpc request to player with ip for waiting end of playing of storage with uuid on deviceName,
+// playing ended before waiting is found by uuid.
+// finished is true if storage was played to end, false if playing was stopped.
+func (c *Client) Wait(ctx context.Context, ip, deviceName, uuid string) (finished bool, err error) {
+	// waiting lasts until end of playing, deadline of call is not applied
+	conn, done, err := c.conns.Stream(c.addr(ip))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	res, err := NewPlayerClient(conn).
+		Wait(
+			ctx,
+			&WaitRequest{
+				DeviceName:  deviceName,
+				StorageUUID: uuid,
+			},
+		)
+	if err != nil {
+		return
+	}
+	finished = res.Finished
+	return
+}
+
+// Rewind rpc request to player with ip for playing audio on deviceName from beginning of storage
+func (c *Client) Rewind(ctx context.Context, ip, deviceName string) (err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	_, err = NewPlayerClient(conn).
+		Rewind(
+			ctx,
+			&RewindRequest{
+				DeviceName: deviceName,
+			},
+		)
+	return
+}
+
+// Replay rpc request to player with ip for playing again last played storage on deviceName
+// not zero startAt delays playing until startAt
+func (c *Client) Replay(ctx context.Context, ip, deviceName string, startAt time.Time) (err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	req := &ReplayRequest{
+		DeviceName: deviceName,
+	}
+	if !startAt.IsZero() {
+		req.StartAt = startAt.UnixNano()
+	}
+
+	_, err = NewPlayerClient(conn).
+		Replay(
+			ctx,
+			req,
+		)
+	return
+}
+
 // ClearStorage rpc request to player with ip for clear audio storage with UUID
 func (c *Client) ClearStorage(ctx context.Context, ip, UUID string) (err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, ip, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
 	_, err = NewPlayerClient(conn).
 		ClearStorage(
@@ -160,10 +231,136 @@ func (c *Client) ClearStorage(ctx context.Context, ip, UUID string) (err error)
 	return
 }
 
-// NewClient ...
-func NewClient(hostLayout, controlPort string) *Client {
+// SetVolume rpc request to player with ip for set volume level on deviceName
+// 1 - original loudness
+func (c *Client) SetVolume(ctx context.Context, ip, deviceName string, volume float32) (err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	_, err = NewPlayerClient(conn).
+		SetVolume(
+			ctx,
+			&SetVolumeRequest{
+				DeviceName: deviceName,
+				Volume:     volume,
+			},
+		)
+	return
+}
+
+// Mute rpc request to player with ip for mute or unmute deviceName
+func (c *Client) Mute(ctx context.Context, ip, deviceName string, mute bool) (err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	_, err = NewPlayerClient(conn).
+		Mute(
+			ctx,
+			&MuteRequest{
+				DeviceName: deviceName,
+				Mute:       mute,
+			},
+		)
+	return
+}
+
+// Events rpc streaming of events on devices of player with ip.
+// events are closed when streaming is ended by ctx or by error of connection.
+func (c *Client) Events(ctx context.Context, ip string) (events <-chan event.Event, err error) {
+	conn, done, err := c.conns.Stream(c.addr(ip))
+	if err != nil {
+		return
+	}
+
+	stream, err := NewPlayerClient(conn).
+		Events(
+			ctx,
+			&EventsRequest{},
+		)
+	if err != nil {
+		done()
+		return
+	}
+
+	e := make(chan event.Event)
+	go func() {
+		defer func() {
+			close(e)
+			done()
+		}()
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case e <- event.Event{
+				Source:      event.Player,
+				IP:          ip,
+				Type:        res.Type,
+				DeviceName:  res.DeviceName,
+				StorageUUID: res.StorageUUID,
+				Message:     res.Message,
+				Time:        time.Unix(0, res.Time),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return e, nil
+}
+
+// ListDevices rpc request to player with ip for playback pcm devices with supported rates, channels and formats
+func (c *Client) ListDevices(ctx context.Context, ip string) (devices []pcm.Device, err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	res, err := NewPlayerClient(conn).
+		ListDevices(
+			ctx,
+			&ListDevicesRequest{},
+		)
+	if err != nil {
+		return
+	}
+	devices = make([]pcm.Device, 0, len(res.Devices))
+	for _, d := range res.Devices {
+		device := pcm.Device{
+			Name:        d.Name,
+			Description: d.Description,
+		}
+		for _, rate := range d.Rates {
+			device.Rates = append(device.Rates, int(rate))
+		}
+		for _, channels := range d.Channels {
+			device.Channels = append(device.Channels, int(channels))
+		}
+		for _, f := range d.Formats {
+			device.Formats = append(device.Formats, pcm.Format{
+				BitsPerSample: int(f.BitsPerSample),
+				AudioFormat:   int(f.AudioFormat),
+			})
+		}
+		devices = append(devices, device)
+	}
+	return
+}
+
+// NewClient conns is pool of connections, it can be shared by player and recorder clients
+func NewClient(hostLayout, controlPort string, conns pool) *Client {
 	return &Client{
 		hostLayout:  hostLayout,
 		controlPort: controlPort,
+		conns:       conns,
 	}
 }
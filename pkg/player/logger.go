@@ -56,6 +56,35 @@ func (l *loggerMiddleware) Stop(ctx context.Context, in *StopPlayRequest) (out *
 	return
 }
 
+// Wait log
+func (l *loggerMiddleware) Wait(ctx context.Context, in *WaitRequest) (out *WaitResponse, err error) {
+	l.logger.Log("Wait", "start", "in", in.String())
+	if out, err = l.server.Wait(ctx, in); err != nil {
+		l.logger.Log("Wait", "err", "in", in.String(), "err", err.Error())
+		return
+	}
+	l.logger.Log("Wait", "end", "in", in.String(), "out", out.String())
+	return
+}
+
+// Rewind log
+func (l *loggerMiddleware) Rewind(ctx context.Context, in *RewindRequest) (out *RewindResponse, err error) {
+	l.logger.Log("Rewind", "start", "in", in.String())
+	if out, err = l.server.Rewind(ctx, in); err != nil {
+		l.logger.Log("Rewind", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
+// Replay log
+func (l *loggerMiddleware) Replay(ctx context.Context, in *ReplayRequest) (out *ReplayResponse, err error) {
+	l.logger.Log("Replay", "start", "in", in.String())
+	if out, err = l.server.Replay(ctx, in); err != nil {
+		l.logger.Log("Replay", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
 // ClearStorage log
 func (l *loggerMiddleware) ClearStorage(ctx context.Context, in *ClearStorageRequest) (out *ClearStorageResponse, err error) {
 	l.logger.Log("ClearStorage", "start", "in", in.String())
@@ -65,6 +94,42 @@ func (l *loggerMiddleware) ClearStorage(ctx context.Context, in *ClearStorageReq
 	return
 }
 
+// SetVolume log
+func (l *loggerMiddleware) SetVolume(ctx context.Context, in *SetVolumeRequest) (out *SetVolumeResponse, err error) {
+	l.logger.Log("SetVolume", "start", "in", in.String())
+	if out, err = l.server.SetVolume(ctx, in); err != nil {
+		l.logger.Log("SetVolume", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
+// Mute log
+func (l *loggerMiddleware) Mute(ctx context.Context, in *MuteRequest) (out *MuteResponse, err error) {
+	l.logger.Log("Mute", "start", "in", in.String())
+	if out, err = l.server.Mute(ctx, in); err != nil {
+		l.logger.Log("Mute", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
+// Events log
+func (l *loggerMiddleware) Events(in *EventsRequest, stream Player_EventsServer) (err error) {
+	l.logger.Log("Events", "start", "in", in.String())
+	if err = l.server.Events(in, stream); err != nil {
+		l.logger.Log("Events", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
+// ListDevices log
+func (l *loggerMiddleware) ListDevices(ctx context.Context, in *ListDevicesRequest) (out *ListDevicesResponse, err error) {
+	l.logger.Log("ListDevices", "start", "in", in.String())
+	if out, err = l.server.ListDevices(ctx, in); err != nil {
+		l.logger.Log("ListDevices", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
 // NewLoggerMiddleware ...
 func NewLoggerMiddleware(
 	logger log.Logger,
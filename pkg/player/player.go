@@ -2,55 +2,222 @@ package player
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/twinj/uuid"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
+	"audio-service/pkg/stream"
+)
+
+const (
+	// eventsBuffSize events buffered for each subscriber
+	eventsBuffSize = 64
+	// deviceUnderrunMessage of underrun event of playback device
+	deviceUnderrunMessage = "device underrun"
+)
+
+var (
+	// ErrNoStats transport of player has no statistics of receiving
+	ErrNoStats = errors.New("transport has no statistics")
+	// ErrFormatMismatch format of playing differs from format of received signal
+	ErrFormatMismatch = errors.New("format of playing differs from received signal")
+	// ErrNoReplay storages of player can not keep content for replay
+	ErrNoReplay = errors.New("storage can not be replayed")
 )
 
 type storageCreator interface {
-	List() io.ReadWriteCloser
+	Create(uuid string) (io.ReadWriteCloser, error)
+}
+
+// replayCreator creator of storages keeping content after reading to play it several times
+type replayCreator interface {
+	CreateReplay(uuid string) (io.ReadWriteCloser, error)
+}
+
+// loader creator of persistent storages, existing storages are opened on start of player
+type loader interface {
+	Storages() (uuids []string)
+}
+
+// cursorer storage keeping content after reading, each playing reads it from beginning
+type cursorer interface {
+	Cursor() io.Reader
+}
+
+// rewinder cursor of storage which can be moved to beginning
+type rewinder interface {
+	Rewind()
+}
+
+// filler storage with known fill level
+type filler interface {
+	Fill() (size, capacity int, duration time.Duration, dropped uint64)
+}
+
+// finisher storage which knows end of receiving, reading of drained storage is ended after Finish
+type finisher interface {
+	Finish()
+}
+
+// formatSetter storage with capacity depending on format of samples
+type formatSetter interface {
+	SetFormat(channels, rate, bitsPerSample, audioFormat int)
 }
 
 type tcp interface {
 	Receive(ctx context.Context, receivePort string, storage io.Writer) error
 }
 
+// statser transport with statistics of receiving on port
+type statser interface {
+	Stats(receivePort string) (stream.Stats, error)
+}
+
+type decoder interface {
+	Choose(names []string) string
+	Decoder(name string, w io.WriteCloser) (io.WriteCloser, error)
+}
+
 type device interface {
-	Play(ctx context.Context, deviceName string, channels, rate, bitsPerSample int, r io.Reader) (err error)
+	Play(ctx context.Context, deviceName string, channels, rate, bitsPerSample, audioFormat int, startAt time.Time, r io.Reader, underrun func()) (done <-chan struct{}, err error)
+	SetVolume(deviceName string, level float64) (err error)
+	Mute(deviceName string, mute bool) (err error)
+	Xruns(deviceName string) (underruns, errors uint64)
+	Devices() ([]pcm.Device, error)
 }
 
 type player struct {
 	receivingMutex sync.Mutex
-	receivingPort  map[string]func()
+	receivingPort  map[string]*receiver
 
 	storageMutex sync.Mutex
 	storage      map[string]io.ReadWriteCloser
 
 	playbackDeviceMutex sync.Mutex
-	playbackDevice      map[string]func()
+	playbackDevice      map[string]*playing
+	// played last play request on device, it is played again by Replay
+	played map[string]*StartPlayRequest
+	// ended last ended playing on device, it is found by Wait called after end of playing
+	ended map[string]*playing
+
+	events *event.Bus
 
 	tcp            tcp
 	device         device
 	storageCreator storageCreator
+	decoder        decoder
+}
+
+// playing on device
+type playing struct {
+	stop        func()
+	storageUUID string
+	// reader of storage, cursor if storage keeps content
+	reader io.Reader
+	// ended is closed when playing is ended, finished is true if storage was played to end
+	ended    chan struct{}
+	finished bool
+}
+
+// receiver of signal on port saved in storage
+type receiver struct {
+	stop        func()
+	storageUUID string
+}
+
+// receiving writer of received signal, storage is finished at end of receiving
+type receiving struct {
+	io.Writer
+	storage io.Writer
+	// failed is called on first error of writing
+	failed func(err error)
+	err    error
+	// finished is called at end of receiving
+	finished func()
+}
+
+// Write received signal
+func (r *receiving) Write(data []byte) (n int, err error) {
+	if n, err = r.Writer.Write(data); err != nil && r.err == nil {
+		r.err = err
+		r.failed(err)
+	}
+	return
+}
+
+// Finish storage if it knows end of receiving
+func (r *receiving) Finish() {
+	if f, isFinisher := r.storage.(finisher); isFinisher {
+		f.Finish()
+	}
+	r.finished()
+}
+
+// monitor reader of playing, underrun is called when played storage has no data
+type monitor struct {
+	io.Reader
+	underrun func()
+	played   bool
+	empty    bool
+}
+
+// Read from storage, underrun is reported once until data is read again
+func (m *monitor) Read(data []byte) (n int, err error) {
+	n, err = m.Reader.Read(data)
+	empty := n == 0 && err == nil
+	if empty && m.played && !m.empty {
+		m.underrun()
+	}
+	m.empty, m.played = empty, m.played || n != 0
+	return
 }
 
-// State return all busy ports, devices on player and existing storage
+// State return all busy ports, devices on player, existing storage, fill level of bounded storages
+// and xruns of devices played from start of player
 func (p *player) State(ctx context.Context, in *StateRequest) (out *StateResponse, err error) {
 	out = &StateResponse{}
 
-	p.receivingMutex.Lock()
-	out.Ports = make([]string, 0, len(p.receivingPort))
-	for port := range p.receivingPort {
+	// stats are got without receivingMutex, transport finishes receiving under lock of stats
+	receivers := p.receivers()
+	out.Ports = make([]string, 0, len(receivers))
+	for port, receiver := range receivers {
 		out.Ports = append(out.Ports, port)
+		if stats, err := p.stats(port); err == nil {
+			out.ReceiveStates = append(out.ReceiveStates, &ReceiveState{
+				Port:          port,
+				StorageUUID:   receiver.storageUUID,
+				Packets:       stats.Packets,
+				Lost:          stats.Lost,
+				Late:          stats.Late,
+				Latency:       stats.Latency.Milliseconds(),
+				Channels:      uint32(stats.Format.Channels),
+				Rate:          uint32(stats.Format.Rate),
+				BitsPerSample: uint32(stats.Format.BitsPerSample),
+				AudioFormat:   uint32(stats.Format.AudioFormat),
+			})
+		}
 	}
-	p.receivingMutex.Unlock()
 
 	p.storageMutex.Lock()
 	out.Storages = make([]string, 0, len(p.storage))
-	for uuid := range p.receivingPort {
+	for uuid, storage := range p.storage {
 		out.Storages = append(out.Storages, uuid)
+		if f, isFiller := storage.(filler); isFiller {
+			size, capacity, duration, dropped := f.Fill()
+			out.StorageStates = append(out.StorageStates, &StorageState{
+				StorageUUID: uuid,
+				Size:        uint64(size),
+				Capacity:    uint64(capacity),
+				Duration:    duration.Milliseconds(),
+				Dropped:     dropped,
+			})
+		}
 	}
 	p.storageMutex.Unlock()
 
@@ -59,49 +226,119 @@ func (p *player) State(ctx context.Context, in *StateRequest) (out *StateRespons
 	for device := range p.playbackDevice {
 		out.Devices = append(out.Devices, device)
 	}
+	for device := range p.played {
+		underruns, errors := p.device.Xruns(device)
+		out.DeviceStates = append(out.DeviceStates, &DeviceState{
+			DeviceName: device,
+			Underruns:  underruns,
+			Errors:     errors,
+		})
+	}
 	p.playbackDeviceMutex.Unlock()
 	return
 }
 
-// ReceiveStart start receive data from server and save
+// ReceiveStart start receive data from server and save.
+// Signal is decoded by first supported codec from request before saving.
 func (p *player) ReceiveStart(c context.Context, in *StartReceiveRequest) (out *StartReceiveResponse, err error) {
 	p.receivingMutex.Lock()
 	defer p.receivingMutex.Unlock()
 
 	if _, isExist := p.receivingPort[in.Port]; !isExist {
-		storage := p.storageCreator.List()
 		uuid := uuid.NewV4().String()
-
 		if in.StorageUUID != nil {
 			uuid = in.StorageUUID.Value
-			if sTmp, isExist := p.storage[uuid]; isExist {
-				storage = sTmp
+		}
+
+		p.storageMutex.Lock()
+		storage, isExist := p.storage[uuid]
+		p.storageMutex.Unlock()
+		if !isExist {
+			if storage, err = p.create(uuid, in.Replay); err != nil {
+				return
+			}
+		}
+
+		codec := p.decoder.Choose(in.Codecs)
+		var w io.WriteCloser
+		if w, err = p.decoder.Decoder(codec, storage); err != nil {
+			if !isExist {
+				storage.Close()
 			}
+			return
 		}
 
 		ctx, stop := context.WithCancel(context.Background())
-		if err = p.tcp.Receive(ctx, in.Port, storage); err == nil {
+		rcv := &receiver{
+			stop:        stop,
+			storageUUID: uuid,
+		}
+		r := &receiving{
+			Writer:  w,
+			storage: storage,
+			failed: func(err error) {
+				p.events.Publish(event.Event{
+					Type:        event.Error,
+					StorageUUID: uuid,
+					Message:     err.Error(),
+				})
+			},
+			finished: func() {
+				p.receiveFinished(in.Port, rcv)
+			},
+		}
+		if err = p.tcp.Receive(ctx, in.Port, r); err == nil {
+			p.storageMutex.Lock()
 			p.storage[uuid] = storage
-			p.receivingPort[in.Port] = stop
+			p.storageMutex.Unlock()
+			p.receivingPort[in.Port] = rcv
 			out = &StartReceiveResponse{
 				StorageUUID: uuid,
+				Codec:       codec,
 			}
 			return
 		}
 		stop()
+		if !isExist {
+			storage.Close()
+		}
 		return
 	}
 	err = fmt.Errorf("%v is busy", in.Port)
 	return
 }
 
+// create storage with uuid, storage keeps content for replay if replay is set
+func (p *player) create(uuid string, replay bool) (io.ReadWriteCloser, error) {
+	if !replay {
+		return p.storageCreator.Create(uuid)
+	}
+	c, isReplayCreator := p.storageCreator.(replayCreator)
+	if !isReplayCreator {
+		return nil, ErrNoReplay
+	}
+	return c.CreateReplay(uuid)
+}
+
+// receiveFinished release port after end of receiving if port is not reused by next receiving.
+// Receiving is stopped as transports without connections keep listening after end of stream.
+func (p *player) receiveFinished(port string, rcv *receiver) {
+	p.receivingMutex.Lock()
+	defer p.receivingMutex.Unlock()
+
+	if p.receivingPort[port] == rcv {
+		rcv.stop()
+		delete(p.receivingPort, port)
+	}
+}
+
 // ReceiveStop stop receive data from server
 func (p *player) ReceiveStop(c context.Context, in *StopReceiveRequest) (out *StopReceiveResponse, err error) {
 	p.receivingMutex.Lock()
 	defer p.receivingMutex.Unlock()
 
-	if stop, isExist := p.receivingPort[in.Port]; isExist {
-		stop()
+	if receiver, isExist := p.receivingPort[in.Port]; isExist {
+		receiver.stop()
 		delete(p.receivingPort, in.Port)
 		out = &StopReceiveResponse{}
 		return
@@ -110,8 +347,14 @@ func (p *player) ReceiveStop(c context.Context, in *StopReceiveRequest) (out *St
 	return
 }
 
-// Play play audio on device
+// Play play audio on device.
+// Device is released when storage is played to end after receiving is finished.
 func (p *player) Play(c context.Context, in *StartPlayRequest) (out *StartPlayResponse, err error) {
+	// receiving is locked before storage as in ReceiveStart
+	if err = p.checkFormat(in); err != nil {
+		return
+	}
+
 	p.storageMutex.Lock()
 	defer p.storageMutex.Unlock()
 
@@ -121,27 +364,121 @@ func (p *player) Play(c context.Context, in *StartPlayRequest) (out *StartPlayRe
 		return
 	}
 
+	var startAt time.Time
+	if in.StartAt != 0 {
+		startAt = time.Unix(0, in.StartAt)
+	}
+	if s, isFormatSetter := storage.(formatSetter); isFormatSetter {
+		s.SetFormat(int(in.Channels), int(in.Rate), int(in.BitsPerSample), int(in.AudioFormat))
+	}
+	var r io.Reader = storage
+	if c, isCursorer := storage.(cursorer); isCursorer {
+		r = c.Cursor()
+	}
+
+	p.playbackDeviceMutex.Lock()
+	defer p.playbackDeviceMutex.Unlock()
+
 	if _, isExist := p.playbackDevice[in.DeviceName]; !isExist {
+		m := &monitor{
+			Reader: r,
+			underrun: func() {
+				p.events.Publish(event.Event{
+					Type:        event.Underrun,
+					DeviceName:  in.DeviceName,
+					StorageUUID: in.StorageUUID,
+				})
+			},
+		}
+		// device underrun is reported with message to distinguish it from empty storage
+		deviceUnderrun := func() {
+			p.events.Publish(event.Event{
+				Type:        event.Underrun,
+				DeviceName:  in.DeviceName,
+				StorageUUID: in.StorageUUID,
+				Message:     deviceUnderrunMessage,
+			})
+		}
 		ctx, stop := context.WithCancel(context.Background())
-		if err = p.device.Play(ctx, in.DeviceName, int(in.Channels), int(in.Rate), int(in.BitsPerSample), storage); err == nil {
-			p.playbackDevice[in.DeviceName] = stop
+		var done <-chan struct{}
+		if done, err = p.device.Play(ctx, in.DeviceName, int(in.Channels), int(in.Rate), int(in.BitsPerSample), int(in.AudioFormat), startAt, m, deviceUnderrun); err == nil {
+			playing := &playing{
+				stop:        stop,
+				storageUUID: in.StorageUUID,
+				reader:      r,
+				ended:       make(chan struct{}),
+			}
+			p.playbackDevice[in.DeviceName] = playing
+			p.played[in.DeviceName] = in
+			go p.release(in.DeviceName, in.StorageUUID, playing, done)
+			p.events.Publish(event.Event{
+				Type:        event.Started,
+				DeviceName:  in.DeviceName,
+				StorageUUID: in.StorageUUID,
+			})
 			out = &StartPlayResponse{}
 			return
 		}
 		stop()
+		p.events.Publish(event.Event{
+			Type:        event.Error,
+			DeviceName:  in.DeviceName,
+			StorageUUID: in.StorageUUID,
+			Message:     err.Error(),
+		})
 		return
 	}
 	err = fmt.Errorf("%s is busy", in.DeviceName)
 	return
 }
 
+// stats of receiving on port, error if transport has no statistics
+func (p *player) stats(port string) (stats stream.Stats, err error) {
+	s, isStatser := p.tcp.(statser)
+	if !isStatser {
+		err = ErrNoStats
+		return
+	}
+	return s.Stats(port)
+}
+
+// receivers copy of receiving ports
+func (p *player) receivers() map[string]*receiver {
+	p.receivingMutex.Lock()
+	defer p.receivingMutex.Unlock()
+
+	receivers := make(map[string]*receiver, len(p.receivingPort))
+	for port, receiver := range p.receivingPort {
+		receivers[port] = receiver
+	}
+	return receivers
+}
+
+// checkFormat of playing with format of signal received in storage, format is known only for transports with packets
+func (p *player) checkFormat(in *StartPlayRequest) (err error) {
+	for port, receiver := range p.receivers() {
+		if receiver.storageUUID != in.StorageUUID {
+			continue
+		}
+		stats, err := p.stats(port)
+		if err != nil || stats.Format.Channels == 0 {
+			continue
+		}
+		f := stats.Format
+		if f.Channels != int(in.Channels) || f.Rate != int(in.Rate) || f.BitsPerSample != int(in.BitsPerSample) || f.AudioFormat != int(in.AudioFormat) {
+			return ErrFormatMismatch
+		}
+	}
+	return
+}
+
 // Stop stop play on device
 func (p *player) Stop(c context.Context, in *StopPlayRequest) (out *StopPlayResponse, err error) {
 	p.playbackDeviceMutex.Lock()
 	defer p.playbackDeviceMutex.Unlock()
 
-	if stop, isExist := p.playbackDevice[in.DeviceName]; isExist {
-		stop()
+	if playing, isExist := p.playbackDevice[in.DeviceName]; isExist {
+		playing.stop()
 		delete(p.playbackDevice, in.DeviceName)
 		out = &StopPlayResponse{}
 		return
@@ -150,6 +487,94 @@ func (p *player) Stop(c context.Context, in *StopPlayRequest) (out *StopPlayResp
 	return
 }
 
+// release device when playing is done, playing which was not stopped is finished
+func (p *player) release(deviceName, storageUUID string, playing *playing, done <-chan struct{}) {
+	<-done
+	p.playbackDeviceMutex.Lock()
+	if p.playbackDevice[deviceName] == playing {
+		delete(p.playbackDevice, deviceName)
+		playing.finished = true
+	}
+	p.ended[deviceName] = playing
+	p.playbackDeviceMutex.Unlock()
+	playing.stop()
+	close(playing.ended)
+
+	e := event.Event{
+		Type:        event.Stopped,
+		DeviceName:  deviceName,
+		StorageUUID: storageUUID,
+	}
+	if playing.finished {
+		e.Type = event.EOF
+	}
+	p.events.Publish(e)
+}
+
+// Wait end of playing on device, response tells if storage was played to end or playing was stopped.
+// Playing of storage with uuid from request which is ended before waiting is found among ended playings.
+func (p *player) Wait(c context.Context, in *WaitRequest) (out *WaitResponse, err error) {
+	p.playbackDeviceMutex.Lock()
+	playing, isExist := p.playbackDevice[in.DeviceName]
+	if in.StorageUUID != "" && (!isExist || playing.storageUUID != in.StorageUUID) {
+		playing, isExist = p.ended[in.DeviceName]
+		isExist = isExist && playing.storageUUID == in.StorageUUID
+	}
+	p.playbackDeviceMutex.Unlock()
+	if !isExist {
+		err = fmt.Errorf("%s is not exist", in.DeviceName)
+		return
+	}
+
+	select {
+	case <-playing.ended:
+		out = &WaitResponse{
+			Finished: playing.finished,
+		}
+	case <-c.Done():
+		err = c.Err()
+	}
+	return
+}
+
+// Rewind playing on device to beginning of storage, device is not reopened
+func (p *player) Rewind(c context.Context, in *RewindRequest) (out *RewindResponse, err error) {
+	p.playbackDeviceMutex.Lock()
+	defer p.playbackDeviceMutex.Unlock()
+
+	playing, isExist := p.playbackDevice[in.DeviceName]
+	if !isExist {
+		err = fmt.Errorf("%s is not exist", in.DeviceName)
+		return
+	}
+	r, isRewinder := playing.reader.(rewinder)
+	if !isRewinder {
+		err = fmt.Errorf("storage on %s can not be rewound", in.DeviceName)
+		return
+	}
+	r.Rewind()
+	out = &RewindResponse{}
+	return
+}
+
+// Replay play again last played storage on device with the same format
+func (p *player) Replay(c context.Context, in *ReplayRequest) (out *ReplayResponse, err error) {
+	p.playbackDeviceMutex.Lock()
+	played, isExist := p.played[in.DeviceName]
+	p.playbackDeviceMutex.Unlock()
+	if !isExist {
+		err = fmt.Errorf("nothing was played on %s", in.DeviceName)
+		return
+	}
+
+	request := *played
+	request.StartAt = in.StartAt
+	if _, err = p.Play(c, &request); err == nil {
+		out = &ReplayResponse{}
+	}
+	return
+}
+
 // ClearStorage with StorageUUID
 func (p *player) ClearStorage(c context.Context, in *ClearStorageRequest) (out *ClearStorageResponse, err error) {
 	p.storageMutex.Lock()
@@ -165,19 +590,103 @@ func (p *player) ClearStorage(c context.Context, in *ClearStorageRequest) (out *
 	return
 }
 
-// NewPlayer ...
+// SetVolume set volume level on device, 1 - original loudness
+func (p *player) SetVolume(c context.Context, in *SetVolumeRequest) (out *SetVolumeResponse, err error) {
+	if err = p.device.SetVolume(in.DeviceName, float64(in.Volume)); err == nil {
+		out = &SetVolumeResponse{}
+	}
+	return
+}
+
+// Mute or unmute device
+func (p *player) Mute(c context.Context, in *MuteRequest) (out *MuteResponse, err error) {
+	if err = p.device.Mute(in.DeviceName, in.Mute); err == nil {
+		out = &MuteResponse{}
+	}
+	return
+}
+
+// Events send events on devices of player until client cancels streaming
+func (p *player) Events(in *EventsRequest, stream Player_EventsServer) (err error) {
+	events, cancel := p.events.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case e := <-events:
+			if err = stream.Send(&Event{
+				Type:        e.Type,
+				DeviceName:  e.DeviceName,
+				StorageUUID: e.StorageUUID,
+				Message:     e.Message,
+				Time:        e.Time.UnixNano(),
+			}); err != nil {
+				return
+			}
+		case <-stream.Context().Done():
+			return
+		}
+	}
+}
+
+// ListDevices return playback pcm devices of player with supported rates, channels and formats
+func (p *player) ListDevices(c context.Context, in *ListDevicesRequest) (out *ListDevicesResponse, err error) {
+	devices, err := p.device.Devices()
+	if err != nil {
+		return
+	}
+	out = &ListDevicesResponse{
+		Devices: make([]*Device, 0, len(devices)),
+	}
+	for _, d := range devices {
+		device := &Device{
+			Name:        d.Name,
+			Description: d.Description,
+		}
+		for _, rate := range d.Rates {
+			device.Rates = append(device.Rates, uint32(rate))
+		}
+		for _, channels := range d.Channels {
+			device.Channels = append(device.Channels, uint32(channels))
+		}
+		for _, f := range d.Formats {
+			device.Formats = append(device.Formats, &Format{
+				BitsPerSample: uint32(f.BitsPerSample),
+				AudioFormat:   uint32(f.AudioFormat),
+			})
+		}
+		out.Devices = append(out.Devices, device)
+	}
+	return
+}
+
+// NewPlayer persistent storages of storage creator are opened
 func NewPlayer(
 	tcp tcp,
 	device device,
 	storage storageCreator,
+	decoder decoder,
 ) PlayerServer {
-	return &player{
-		receivingPort:  make(map[string]func()),
+	p := &player{
+		receivingPort:  make(map[string]*receiver),
 		storage:        make(map[string]io.ReadWriteCloser),
-		playbackDevice: make(map[string]func()),
+		playbackDevice: make(map[string]*playing),
+		played:         make(map[string]*StartPlayRequest),
+		ended:          make(map[string]*playing),
+
+		events: event.NewBus(eventsBuffSize),
 
 		tcp:            tcp,
 		device:         device,
 		storageCreator: storage,
+		decoder:        decoder,
+	}
+	if l, isLoader := storage.(loader); isLoader {
+		for _, uuid := range l.Storages() {
+			if s, err := storage.Create(uuid); err == nil {
+				p.storage[uuid] = s
+			}
+		}
 	}
+	return p
 }
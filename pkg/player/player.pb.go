@@ -58,12 +58,18 @@ func (m *StateRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_StateRequest proto.InternalMessageInfo
 
 type StateResponse struct {
-	Ports                []string `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
-	Storages             []string `protobuf:"bytes,2,rep,name=storages,proto3" json:"storages,omitempty"`
-	Devices              []string `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Ports    []string `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+	Storages []string `protobuf:"bytes,2,rep,name=storages,proto3" json:"storages,omitempty"`
+	Devices  []string `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
+	// size and fill level of storages
+	StorageStates []*StorageState `protobuf:"bytes,4,rep,name=storageStates,proto3" json:"storageStates,omitempty"`
+	// xruns of devices played from start of player
+	DeviceStates []*DeviceState `protobuf:"bytes,5,rep,name=deviceStates,proto3" json:"deviceStates,omitempty"`
+	// receiving on ports by transports with packets
+	ReceiveStates        []*ReceiveState `protobuf:"bytes,6,rep,name=receiveStates,proto3" json:"receiveStates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *StateResponse) Reset()         { *m = StateResponse{} }
@@ -112,19 +118,291 @@ func (m *StateResponse) GetDevices() []string {
 	return nil
 }
 
+func (m *StateResponse) GetStorageStates() []*StorageState {
+	if m != nil {
+		return m.StorageStates
+	}
+	return nil
+}
+
+func (m *StateResponse) GetDeviceStates() []*DeviceState {
+	if m != nil {
+		return m.DeviceStates
+	}
+	return nil
+}
+
+func (m *StateResponse) GetReceiveStates() []*ReceiveState {
+	if m != nil {
+		return m.ReceiveStates
+	}
+	return nil
+}
+
+type ReceiveState struct {
+	Port        string `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	StorageUUID string `protobuf:"bytes,2,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	Packets     uint64 `protobuf:"varint,3,opt,name=packets,proto3" json:"packets,omitempty"`
+	// lost packets, gaps are filled with silence
+	Lost uint64 `protobuf:"varint,4,opt,name=lost,proto3" json:"lost,omitempty"`
+	// latency from sending to receiving of last packet in milliseconds, clocks of server and player must be synchronized
+	Latency int64 `protobuf:"varint,5,opt,name=latency,proto3" json:"latency,omitempty"`
+	// format of received samples, zero if sender does not send it
+	Channels      uint32 `protobuf:"varint,6,opt,name=channels,proto3" json:"channels,omitempty"`
+	Rate          uint32 `protobuf:"varint,7,opt,name=rate,proto3" json:"rate,omitempty"`
+	BitsPerSample uint32 `protobuf:"varint,8,opt,name=bitsPerSample,proto3" json:"bitsPerSample,omitempty"`
+	AudioFormat   uint32 `protobuf:"varint,9,opt,name=audioFormat,proto3" json:"audioFormat,omitempty"`
+	// late packets received after their place was played, they are dropped
+	Late                 uint64   `protobuf:"varint,10,opt,name=late,proto3" json:"late,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiveState) Reset()         { *m = ReceiveState{} }
+func (m *ReceiveState) String() string { return proto.CompactTextString(m) }
+func (*ReceiveState) ProtoMessage()    {}
+func (*ReceiveState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{2}
+}
+
+func (m *ReceiveState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiveState.Unmarshal(m, b)
+}
+func (m *ReceiveState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiveState.Marshal(b, m, deterministic)
+}
+func (m *ReceiveState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiveState.Merge(m, src)
+}
+func (m *ReceiveState) XXX_Size() int {
+	return xxx_messageInfo_ReceiveState.Size(m)
+}
+func (m *ReceiveState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiveState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiveState proto.InternalMessageInfo
+
+func (m *ReceiveState) GetPort() string {
+	if m != nil {
+		return m.Port
+	}
+	return ""
+}
+
+func (m *ReceiveState) GetStorageUUID() string {
+	if m != nil {
+		return m.StorageUUID
+	}
+	return ""
+}
+
+func (m *ReceiveState) GetPackets() uint64 {
+	if m != nil {
+		return m.Packets
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetLost() uint64 {
+	if m != nil {
+		return m.Lost
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetLatency() int64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetChannels() uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetRate() uint32 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetBitsPerSample() uint32 {
+	if m != nil {
+		return m.BitsPerSample
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetAudioFormat() uint32 {
+	if m != nil {
+		return m.AudioFormat
+	}
+	return 0
+}
+
+func (m *ReceiveState) GetLate() uint64 {
+	if m != nil {
+		return m.Late
+	}
+	return 0
+}
+
+type DeviceState struct {
+	DeviceName string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	// underruns of device, device is prepared again and playing is continued
+	Underruns uint64 `protobuf:"varint,2,opt,name=underruns,proto3" json:"underruns,omitempty"`
+	// errors of writing on device other than underrun
+	Errors               uint64   `protobuf:"varint,3,opt,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeviceState) Reset()         { *m = DeviceState{} }
+func (m *DeviceState) String() string { return proto.CompactTextString(m) }
+func (*DeviceState) ProtoMessage()    {}
+func (*DeviceState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{3}
+}
+
+func (m *DeviceState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceState.Unmarshal(m, b)
+}
+func (m *DeviceState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceState.Marshal(b, m, deterministic)
+}
+func (m *DeviceState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceState.Merge(m, src)
+}
+func (m *DeviceState) XXX_Size() int {
+	return xxx_messageInfo_DeviceState.Size(m)
+}
+func (m *DeviceState) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceState proto.InternalMessageInfo
+
+func (m *DeviceState) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *DeviceState) GetUnderruns() uint64 {
+	if m != nil {
+		return m.Underruns
+	}
+	return 0
+}
+
+func (m *DeviceState) GetErrors() uint64 {
+	if m != nil {
+		return m.Errors
+	}
+	return 0
+}
+
+type StorageState struct {
+	StorageUUID string `protobuf:"bytes,1,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	// size of buffered audio in bytes
+	Size uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// capacity of storage in bytes, 0 - unbounded
+	Capacity uint64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// duration of buffered audio in milliseconds, 0 if format of samples is unknown
+	Duration int64 `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// dropped bytes on overflow
+	Dropped              uint64   `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageState) Reset()         { *m = StorageState{} }
+func (m *StorageState) String() string { return proto.CompactTextString(m) }
+func (*StorageState) ProtoMessage()    {}
+func (*StorageState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{4}
+}
+
+func (m *StorageState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageState.Unmarshal(m, b)
+}
+func (m *StorageState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageState.Marshal(b, m, deterministic)
+}
+func (m *StorageState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageState.Merge(m, src)
+}
+func (m *StorageState) XXX_Size() int {
+	return xxx_messageInfo_StorageState.Size(m)
+}
+func (m *StorageState) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageState proto.InternalMessageInfo
+
+func (m *StorageState) GetStorageUUID() string {
+	if m != nil {
+		return m.StorageUUID
+	}
+	return ""
+}
+
+func (m *StorageState) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *StorageState) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *StorageState) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *StorageState) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
 type StartReceiveRequest struct {
-	Port                 string                `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
-	StorageUUID          *wrappers.StringValue `protobuf:"bytes,2,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Port        string                `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	StorageUUID *wrappers.StringValue `protobuf:"bytes,2,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	// codecs of signal in order of preference, player chooses first supported, pcm if none
+	Codecs []string `protobuf:"bytes,3,rep,name=codecs,proto3" json:"codecs,omitempty"`
+	// new storage keeps content after playing for rewind and replay, otherwise played content is dropped
+	Replay               bool     `protobuf:"varint,4,opt,name=replay,proto3" json:"replay,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StartReceiveRequest) Reset()         { *m = StartReceiveRequest{} }
 func (m *StartReceiveRequest) String() string { return proto.CompactTextString(m) }
 func (*StartReceiveRequest) ProtoMessage()    {}
 func (*StartReceiveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{2}
+	return fileDescriptor_41d803d1b635d5c6, []int{5}
 }
 
 func (m *StartReceiveRequest) XXX_Unmarshal(b []byte) error {
@@ -159,8 +437,24 @@ func (m *StartReceiveRequest) GetStorageUUID() *wrappers.StringValue {
 	return nil
 }
 
+func (m *StartReceiveRequest) GetCodecs() []string {
+	if m != nil {
+		return m.Codecs
+	}
+	return nil
+}
+
+func (m *StartReceiveRequest) GetReplay() bool {
+	if m != nil {
+		return m.Replay
+	}
+	return false
+}
+
 type StartReceiveResponse struct {
-	StorageUUID          string   `protobuf:"bytes,1,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	StorageUUID string `protobuf:"bytes,1,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	// codec chosen by player
+	Codec                string   `protobuf:"bytes,2,opt,name=codec,proto3" json:"codec,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -170,7 +464,7 @@ func (m *StartReceiveResponse) Reset()         { *m = StartReceiveResponse{} }
 func (m *StartReceiveResponse) String() string { return proto.CompactTextString(m) }
 func (*StartReceiveResponse) ProtoMessage()    {}
 func (*StartReceiveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{3}
+	return fileDescriptor_41d803d1b635d5c6, []int{6}
 }
 
 func (m *StartReceiveResponse) XXX_Unmarshal(b []byte) error {
@@ -198,6 +492,13 @@ func (m *StartReceiveResponse) GetStorageUUID() string {
 	return ""
 }
 
+func (m *StartReceiveResponse) GetCodec() string {
+	if m != nil {
+		return m.Codec
+	}
+	return ""
+}
+
 type StopReceiveRequest struct {
 	Port                 string   `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -209,7 +510,7 @@ func (m *StopReceiveRequest) Reset()         { *m = StopReceiveRequest{} }
 func (m *StopReceiveRequest) String() string { return proto.CompactTextString(m) }
 func (*StopReceiveRequest) ProtoMessage()    {}
 func (*StopReceiveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{4}
+	return fileDescriptor_41d803d1b635d5c6, []int{7}
 }
 
 func (m *StopReceiveRequest) XXX_Unmarshal(b []byte) error {
@@ -247,7 +548,7 @@ func (m *StopReceiveResponse) Reset()         { *m = StopReceiveResponse{} }
 func (m *StopReceiveResponse) String() string { return proto.CompactTextString(m) }
 func (*StopReceiveResponse) ProtoMessage()    {}
 func (*StopReceiveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{5}
+	return fileDescriptor_41d803d1b635d5c6, []int{8}
 }
 
 func (m *StopReceiveResponse) XXX_Unmarshal(b []byte) error {
@@ -269,11 +570,15 @@ func (m *StopReceiveResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_StopReceiveResponse proto.InternalMessageInfo
 
 type StartPlayRequest struct {
-	DeviceName           string   `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
-	Channels             uint32   `protobuf:"varint,2,opt,name=channels,proto3" json:"channels,omitempty"`
-	Rate                 uint32   `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
-	BitsPerSample        uint32   `protobuf:"varint,4,opt,name=bitsPerSample,proto3" json:"bitsPerSample,omitempty"`
-	StorageUUID          string   `protobuf:"bytes,5,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	DeviceName    string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	Channels      uint32 `protobuf:"varint,2,opt,name=channels,proto3" json:"channels,omitempty"`
+	Rate          uint32 `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	BitsPerSample uint32 `protobuf:"varint,4,opt,name=bitsPerSample,proto3" json:"bitsPerSample,omitempty"`
+	StorageUUID   string `protobuf:"bytes,5,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	// audioFormat as in wav header: 1 - PCM, 3 - IEEE float
+	AudioFormat uint32 `protobuf:"varint,6,opt,name=audioFormat,proto3" json:"audioFormat,omitempty"`
+	// startAt unix time in nanoseconds to start playing and keep playing in sync with wall clock, 0 - play immediately
+	StartAt              int64    `protobuf:"varint,7,opt,name=startAt,proto3" json:"startAt,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -283,7 +588,7 @@ func (m *StartPlayRequest) Reset()         { *m = StartPlayRequest{} }
 func (m *StartPlayRequest) String() string { return proto.CompactTextString(m) }
 func (*StartPlayRequest) ProtoMessage()    {}
 func (*StartPlayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{6}
+	return fileDescriptor_41d803d1b635d5c6, []int{9}
 }
 
 func (m *StartPlayRequest) XXX_Unmarshal(b []byte) error {
@@ -339,6 +644,20 @@ func (m *StartPlayRequest) GetStorageUUID() string {
 	return ""
 }
 
+func (m *StartPlayRequest) GetAudioFormat() uint32 {
+	if m != nil {
+		return m.AudioFormat
+	}
+	return 0
+}
+
+func (m *StartPlayRequest) GetStartAt() int64 {
+	if m != nil {
+		return m.StartAt
+	}
+	return 0
+}
+
 type StartPlayResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -349,7 +668,7 @@ func (m *StartPlayResponse) Reset()         { *m = StartPlayResponse{} }
 func (m *StartPlayResponse) String() string { return proto.CompactTextString(m) }
 func (*StartPlayResponse) ProtoMessage()    {}
 func (*StartPlayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{7}
+	return fileDescriptor_41d803d1b635d5c6, []int{10}
 }
 
 func (m *StartPlayResponse) XXX_Unmarshal(b []byte) error {
@@ -381,7 +700,7 @@ func (m *StopPlayRequest) Reset()         { *m = StopPlayRequest{} }
 func (m *StopPlayRequest) String() string { return proto.CompactTextString(m) }
 func (*StopPlayRequest) ProtoMessage()    {}
 func (*StopPlayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{8}
+	return fileDescriptor_41d803d1b635d5c6, []int{11}
 }
 
 func (m *StopPlayRequest) XXX_Unmarshal(b []byte) error {
@@ -419,7 +738,7 @@ func (m *StopPlayResponse) Reset()         { *m = StopPlayResponse{} }
 func (m *StopPlayResponse) String() string { return proto.CompactTextString(m) }
 func (*StopPlayResponse) ProtoMessage()    {}
 func (*StopPlayResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{9}
+	return fileDescriptor_41d803d1b635d5c6, []int{12}
 }
 
 func (m *StopPlayResponse) XXX_Unmarshal(b []byte) error {
@@ -440,125 +759,874 @@ func (m *StopPlayResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopPlayResponse proto.InternalMessageInfo
 
-type ClearStorageRequest struct {
-	StorageUUID          string   `protobuf:"bytes,1,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+type WaitRequest struct {
+	DeviceName string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	// storageUUID played on device, playing ended before waiting is found by it, empty - current playing
+	StorageUUID          string   `protobuf:"bytes,2,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClearStorageRequest) Reset()         { *m = ClearStorageRequest{} }
-func (m *ClearStorageRequest) String() string { return proto.CompactTextString(m) }
-func (*ClearStorageRequest) ProtoMessage()    {}
-func (*ClearStorageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{10}
+func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
+func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
+func (*WaitRequest) ProtoMessage()    {}
+func (*WaitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{13}
 }
 
-func (m *ClearStorageRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ClearStorageRequest.Unmarshal(m, b)
+func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitRequest.Unmarshal(m, b)
 }
-func (m *ClearStorageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ClearStorageRequest.Marshal(b, m, deterministic)
+func (m *WaitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitRequest.Marshal(b, m, deterministic)
 }
-func (m *ClearStorageRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClearStorageRequest.Merge(m, src)
+func (m *WaitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitRequest.Merge(m, src)
 }
-func (m *ClearStorageRequest) XXX_Size() int {
-	return xxx_messageInfo_ClearStorageRequest.Size(m)
+func (m *WaitRequest) XXX_Size() int {
+	return xxx_messageInfo_WaitRequest.Size(m)
 }
-func (m *ClearStorageRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClearStorageRequest.DiscardUnknown(m)
+func (m *WaitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClearStorageRequest proto.InternalMessageInfo
+var xxx_messageInfo_WaitRequest proto.InternalMessageInfo
 
-func (m *ClearStorageRequest) GetStorageUUID() string {
+func (m *WaitRequest) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *WaitRequest) GetStorageUUID() string {
 	if m != nil {
 		return m.StorageUUID
 	}
 	return ""
 }
 
-type ClearStorageResponse struct {
+type WaitResponse struct {
+	// finished storage was played to end after end of receiving, false - playing was stopped
+	Finished             bool     `protobuf:"varint,1,opt,name=finished,proto3" json:"finished,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClearStorageResponse) Reset()         { *m = ClearStorageResponse{} }
-func (m *ClearStorageResponse) String() string { return proto.CompactTextString(m) }
-func (*ClearStorageResponse) ProtoMessage()    {}
-func (*ClearStorageResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41d803d1b635d5c6, []int{11}
+func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
+func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
+func (*WaitResponse) ProtoMessage()    {}
+func (*WaitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{14}
 }
 
-func (m *ClearStorageResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ClearStorageResponse.Unmarshal(m, b)
+func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WaitResponse.Unmarshal(m, b)
 }
-func (m *ClearStorageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ClearStorageResponse.Marshal(b, m, deterministic)
+func (m *WaitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WaitResponse.Marshal(b, m, deterministic)
 }
-func (m *ClearStorageResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClearStorageResponse.Merge(m, src)
+func (m *WaitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitResponse.Merge(m, src)
 }
-func (m *ClearStorageResponse) XXX_Size() int {
-	return xxx_messageInfo_ClearStorageResponse.Size(m)
+func (m *WaitResponse) XXX_Size() int {
+	return xxx_messageInfo_WaitResponse.Size(m)
 }
-func (m *ClearStorageResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClearStorageResponse.DiscardUnknown(m)
+func (m *WaitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClearStorageResponse proto.InternalMessageInfo
+var xxx_messageInfo_WaitResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*StateRequest)(nil), "player.StateRequest")
-	proto.RegisterType((*StateResponse)(nil), "player.StateResponse")
-	proto.RegisterType((*StartReceiveRequest)(nil), "player.StartReceiveRequest")
-	proto.RegisterType((*StartReceiveResponse)(nil), "player.StartReceiveResponse")
-	proto.RegisterType((*StopReceiveRequest)(nil), "player.StopReceiveRequest")
-	proto.RegisterType((*StopReceiveResponse)(nil), "player.StopReceiveResponse")
-	proto.RegisterType((*StartPlayRequest)(nil), "player.StartPlayRequest")
-	proto.RegisterType((*StartPlayResponse)(nil), "player.StartPlayResponse")
-	proto.RegisterType((*StopPlayRequest)(nil), "player.StopPlayRequest")
+func (m *WaitResponse) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+type RewindRequest struct {
+	DeviceName           string   `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RewindRequest) Reset()         { *m = RewindRequest{} }
+func (m *RewindRequest) String() string { return proto.CompactTextString(m) }
+func (*RewindRequest) ProtoMessage()    {}
+func (*RewindRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{15}
+}
+
+func (m *RewindRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RewindRequest.Unmarshal(m, b)
+}
+func (m *RewindRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RewindRequest.Marshal(b, m, deterministic)
+}
+func (m *RewindRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RewindRequest.Merge(m, src)
+}
+func (m *RewindRequest) XXX_Size() int {
+	return xxx_messageInfo_RewindRequest.Size(m)
+}
+func (m *RewindRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RewindRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RewindRequest proto.InternalMessageInfo
+
+func (m *RewindRequest) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+type RewindResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RewindResponse) Reset()         { *m = RewindResponse{} }
+func (m *RewindResponse) String() string { return proto.CompactTextString(m) }
+func (*RewindResponse) ProtoMessage()    {}
+func (*RewindResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{16}
+}
+
+func (m *RewindResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RewindResponse.Unmarshal(m, b)
+}
+func (m *RewindResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RewindResponse.Marshal(b, m, deterministic)
+}
+func (m *RewindResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RewindResponse.Merge(m, src)
+}
+func (m *RewindResponse) XXX_Size() int {
+	return xxx_messageInfo_RewindResponse.Size(m)
+}
+func (m *RewindResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RewindResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RewindResponse proto.InternalMessageInfo
+
+type ReplayRequest struct {
+	DeviceName string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	// startAt unix time in nanoseconds to start playing, 0 - play immediately
+	StartAt              int64    `protobuf:"varint,2,opt,name=startAt,proto3" json:"startAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayRequest) Reset()         { *m = ReplayRequest{} }
+func (m *ReplayRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayRequest) ProtoMessage()    {}
+func (*ReplayRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{17}
+}
+
+func (m *ReplayRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayRequest.Unmarshal(m, b)
+}
+func (m *ReplayRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplayRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayRequest.Merge(m, src)
+}
+func (m *ReplayRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplayRequest.Size(m)
+}
+func (m *ReplayRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayRequest proto.InternalMessageInfo
+
+func (m *ReplayRequest) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *ReplayRequest) GetStartAt() int64 {
+	if m != nil {
+		return m.StartAt
+	}
+	return 0
+}
+
+type ReplayResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayResponse) Reset()         { *m = ReplayResponse{} }
+func (m *ReplayResponse) String() string { return proto.CompactTextString(m) }
+func (*ReplayResponse) ProtoMessage()    {}
+func (*ReplayResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{18}
+}
+
+func (m *ReplayResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayResponse.Unmarshal(m, b)
+}
+func (m *ReplayResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayResponse.Marshal(b, m, deterministic)
+}
+func (m *ReplayResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayResponse.Merge(m, src)
+}
+func (m *ReplayResponse) XXX_Size() int {
+	return xxx_messageInfo_ReplayResponse.Size(m)
+}
+func (m *ReplayResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayResponse proto.InternalMessageInfo
+
+type ClearStorageRequest struct {
+	StorageUUID          string   `protobuf:"bytes,1,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClearStorageRequest) Reset()         { *m = ClearStorageRequest{} }
+func (m *ClearStorageRequest) String() string { return proto.CompactTextString(m) }
+func (*ClearStorageRequest) ProtoMessage()    {}
+func (*ClearStorageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{19}
+}
+
+func (m *ClearStorageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClearStorageRequest.Unmarshal(m, b)
+}
+func (m *ClearStorageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClearStorageRequest.Marshal(b, m, deterministic)
+}
+func (m *ClearStorageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClearStorageRequest.Merge(m, src)
+}
+func (m *ClearStorageRequest) XXX_Size() int {
+	return xxx_messageInfo_ClearStorageRequest.Size(m)
+}
+func (m *ClearStorageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClearStorageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClearStorageRequest proto.InternalMessageInfo
+
+func (m *ClearStorageRequest) GetStorageUUID() string {
+	if m != nil {
+		return m.StorageUUID
+	}
+	return ""
+}
+
+type ClearStorageResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClearStorageResponse) Reset()         { *m = ClearStorageResponse{} }
+func (m *ClearStorageResponse) String() string { return proto.CompactTextString(m) }
+func (*ClearStorageResponse) ProtoMessage()    {}
+func (*ClearStorageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{20}
+}
+
+func (m *ClearStorageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClearStorageResponse.Unmarshal(m, b)
+}
+func (m *ClearStorageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClearStorageResponse.Marshal(b, m, deterministic)
+}
+func (m *ClearStorageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClearStorageResponse.Merge(m, src)
+}
+func (m *ClearStorageResponse) XXX_Size() int {
+	return xxx_messageInfo_ClearStorageResponse.Size(m)
+}
+func (m *ClearStorageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClearStorageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClearStorageResponse proto.InternalMessageInfo
+
+type SetVolumeRequest struct {
+	DeviceName           string   `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	Volume               float32  `protobuf:"fixed32,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetVolumeRequest) Reset()         { *m = SetVolumeRequest{} }
+func (m *SetVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*SetVolumeRequest) ProtoMessage()    {}
+func (*SetVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{21}
+}
+
+func (m *SetVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetVolumeRequest.Unmarshal(m, b)
+}
+func (m *SetVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *SetVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetVolumeRequest.Merge(m, src)
+}
+func (m *SetVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_SetVolumeRequest.Size(m)
+}
+func (m *SetVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetVolumeRequest proto.InternalMessageInfo
+
+func (m *SetVolumeRequest) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *SetVolumeRequest) GetVolume() float32 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+type SetVolumeResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetVolumeResponse) Reset()         { *m = SetVolumeResponse{} }
+func (m *SetVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*SetVolumeResponse) ProtoMessage()    {}
+func (*SetVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{22}
+}
+
+func (m *SetVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetVolumeResponse.Unmarshal(m, b)
+}
+func (m *SetVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetVolumeResponse.Marshal(b, m, deterministic)
+}
+func (m *SetVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetVolumeResponse.Merge(m, src)
+}
+func (m *SetVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_SetVolumeResponse.Size(m)
+}
+func (m *SetVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetVolumeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetVolumeResponse proto.InternalMessageInfo
+
+type MuteRequest struct {
+	DeviceName           string   `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	Mute                 bool     `protobuf:"varint,2,opt,name=mute,proto3" json:"mute,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuteRequest) Reset()         { *m = MuteRequest{} }
+func (m *MuteRequest) String() string { return proto.CompactTextString(m) }
+func (*MuteRequest) ProtoMessage()    {}
+func (*MuteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{23}
+}
+
+func (m *MuteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuteRequest.Unmarshal(m, b)
+}
+func (m *MuteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuteRequest.Marshal(b, m, deterministic)
+}
+func (m *MuteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuteRequest.Merge(m, src)
+}
+func (m *MuteRequest) XXX_Size() int {
+	return xxx_messageInfo_MuteRequest.Size(m)
+}
+func (m *MuteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuteRequest proto.InternalMessageInfo
+
+func (m *MuteRequest) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *MuteRequest) GetMute() bool {
+	if m != nil {
+		return m.Mute
+	}
+	return false
+}
+
+type MuteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MuteResponse) Reset()         { *m = MuteResponse{} }
+func (m *MuteResponse) String() string { return proto.CompactTextString(m) }
+func (*MuteResponse) ProtoMessage()    {}
+func (*MuteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{24}
+}
+
+func (m *MuteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MuteResponse.Unmarshal(m, b)
+}
+func (m *MuteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MuteResponse.Marshal(b, m, deterministic)
+}
+func (m *MuteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MuteResponse.Merge(m, src)
+}
+func (m *MuteResponse) XXX_Size() int {
+	return xxx_messageInfo_MuteResponse.Size(m)
+}
+func (m *MuteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MuteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MuteResponse proto.InternalMessageInfo
+
+type EventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
+func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
+func (*EventsRequest) ProtoMessage()    {}
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{25}
+}
+
+func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventsRequest.Unmarshal(m, b)
+}
+func (m *EventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventsRequest.Marshal(b, m, deterministic)
+}
+func (m *EventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsRequest.Merge(m, src)
+}
+func (m *EventsRequest) XXX_Size() int {
+	return xxx_messageInfo_EventsRequest.Size(m)
+}
+func (m *EventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventsRequest proto.InternalMessageInfo
+
+type Event struct {
+	// type of event: started, stopped, underrun, eof, error
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	DeviceName  string `protobuf:"bytes,2,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	StorageUUID string `protobuf:"bytes,3,opt,name=storageUUID,proto3" json:"storageUUID,omitempty"`
+	// message of error
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// time unix time in nanoseconds
+	Time                 int64    `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{26}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Event) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *Event) GetStorageUUID() string {
+	if m != nil {
+		return m.StorageUUID
+	}
+	return ""
+}
+
+func (m *Event) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Event) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type ListDevicesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDevicesRequest) Reset()         { *m = ListDevicesRequest{} }
+func (m *ListDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDevicesRequest) ProtoMessage()    {}
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{27}
+}
+
+func (m *ListDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDevicesRequest.Unmarshal(m, b)
+}
+func (m *ListDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDevicesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDevicesRequest.Merge(m, src)
+}
+func (m *ListDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDevicesRequest.Size(m)
+}
+func (m *ListDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDevicesRequest proto.InternalMessageInfo
+
+type ListDevicesResponse struct {
+	Devices              []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListDevicesResponse) Reset()         { *m = ListDevicesResponse{} }
+func (m *ListDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDevicesResponse) ProtoMessage()    {}
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{28}
+}
+
+func (m *ListDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDevicesResponse.Unmarshal(m, b)
+}
+func (m *ListDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDevicesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDevicesResponse.Merge(m, src)
+}
+func (m *ListDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDevicesResponse.Size(m)
+}
+func (m *ListDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDevicesResponse proto.InternalMessageInfo
+
+func (m *ListDevicesResponse) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type Device struct {
+	// name of device for playing: hw:card,device
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// rates, channels and formats supported by device, empty if device is busy
+	Rates                []uint32  `protobuf:"varint,3,rep,packed,name=rates,proto3" json:"rates,omitempty"`
+	Channels             []uint32  `protobuf:"varint,4,rep,packed,name=channels,proto3" json:"channels,omitempty"`
+	Formats              []*Format `protobuf:"bytes,5,rep,name=formats,proto3" json:"formats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+func (*Device) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{29}
+}
+
+func (m *Device) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Device.Unmarshal(m, b)
+}
+func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Device.Marshal(b, m, deterministic)
+}
+func (m *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(m, src)
+}
+func (m *Device) XXX_Size() int {
+	return xxx_messageInfo_Device.Size(m)
+}
+func (m *Device) XXX_DiscardUnknown() {
+	xxx_messageInfo_Device.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Device proto.InternalMessageInfo
+
+func (m *Device) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Device) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Device) GetRates() []uint32 {
+	if m != nil {
+		return m.Rates
+	}
+	return nil
+}
+
+func (m *Device) GetChannels() []uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Device) GetFormats() []*Format {
+	if m != nil {
+		return m.Formats
+	}
+	return nil
+}
+
+type Format struct {
+	BitsPerSample uint32 `protobuf:"varint,1,opt,name=bitsPerSample,proto3" json:"bitsPerSample,omitempty"`
+	// audioFormat as in wav header: 1 - PCM, 3 - IEEE float
+	AudioFormat          uint32   `protobuf:"varint,2,opt,name=audioFormat,proto3" json:"audioFormat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Format) Reset()         { *m = Format{} }
+func (m *Format) String() string { return proto.CompactTextString(m) }
+func (*Format) ProtoMessage()    {}
+func (*Format) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41d803d1b635d5c6, []int{30}
+}
+
+func (m *Format) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Format.Unmarshal(m, b)
+}
+func (m *Format) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Format.Marshal(b, m, deterministic)
+}
+func (m *Format) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Format.Merge(m, src)
+}
+func (m *Format) XXX_Size() int {
+	return xxx_messageInfo_Format.Size(m)
+}
+func (m *Format) XXX_DiscardUnknown() {
+	xxx_messageInfo_Format.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Format proto.InternalMessageInfo
+
+func (m *Format) GetBitsPerSample() uint32 {
+	if m != nil {
+		return m.BitsPerSample
+	}
+	return 0
+}
+
+func (m *Format) GetAudioFormat() uint32 {
+	if m != nil {
+		return m.AudioFormat
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*StateRequest)(nil), "player.StateRequest")
+	proto.RegisterType((*StateResponse)(nil), "player.StateResponse")
+	proto.RegisterType((*ReceiveState)(nil), "player.ReceiveState")
+	proto.RegisterType((*DeviceState)(nil), "player.DeviceState")
+	proto.RegisterType((*StorageState)(nil), "player.StorageState")
+	proto.RegisterType((*StartReceiveRequest)(nil), "player.StartReceiveRequest")
+	proto.RegisterType((*StartReceiveResponse)(nil), "player.StartReceiveResponse")
+	proto.RegisterType((*StopReceiveRequest)(nil), "player.StopReceiveRequest")
+	proto.RegisterType((*StopReceiveResponse)(nil), "player.StopReceiveResponse")
+	proto.RegisterType((*StartPlayRequest)(nil), "player.StartPlayRequest")
+	proto.RegisterType((*StartPlayResponse)(nil), "player.StartPlayResponse")
+	proto.RegisterType((*StopPlayRequest)(nil), "player.StopPlayRequest")
 	proto.RegisterType((*StopPlayResponse)(nil), "player.StopPlayResponse")
+	proto.RegisterType((*WaitRequest)(nil), "player.WaitRequest")
+	proto.RegisterType((*WaitResponse)(nil), "player.WaitResponse")
+	proto.RegisterType((*RewindRequest)(nil), "player.RewindRequest")
+	proto.RegisterType((*RewindResponse)(nil), "player.RewindResponse")
+	proto.RegisterType((*ReplayRequest)(nil), "player.ReplayRequest")
+	proto.RegisterType((*ReplayResponse)(nil), "player.ReplayResponse")
 	proto.RegisterType((*ClearStorageRequest)(nil), "player.ClearStorageRequest")
 	proto.RegisterType((*ClearStorageResponse)(nil), "player.ClearStorageResponse")
+	proto.RegisterType((*SetVolumeRequest)(nil), "player.SetVolumeRequest")
+	proto.RegisterType((*SetVolumeResponse)(nil), "player.SetVolumeResponse")
+	proto.RegisterType((*MuteRequest)(nil), "player.MuteRequest")
+	proto.RegisterType((*MuteResponse)(nil), "player.MuteResponse")
+	proto.RegisterType((*EventsRequest)(nil), "player.EventsRequest")
+	proto.RegisterType((*Event)(nil), "player.Event")
+	proto.RegisterType((*ListDevicesRequest)(nil), "player.ListDevicesRequest")
+	proto.RegisterType((*ListDevicesResponse)(nil), "player.ListDevicesResponse")
+	proto.RegisterType((*Device)(nil), "player.Device")
+	proto.RegisterType((*Format)(nil), "player.Format")
 }
 
 func init() { proto.RegisterFile("player.proto", fileDescriptor_41d803d1b635d5c6) }
 
 var fileDescriptor_41d803d1b635d5c6 = []byte{
-	// 476 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xad, 0x9b, 0x0f, 0xe8, 0x24, 0xe1, 0x63, 0x93, 0xc2, 0xe2, 0x56, 0x55, 0xb4, 0xe2, 0x90,
-	0x93, 0x2b, 0x8a, 0x04, 0x48, 0x08, 0x2e, 0x70, 0x00, 0x21, 0xa1, 0xc8, 0x56, 0xb9, 0x70, 0xda,
-	0x84, 0x21, 0x58, 0x72, 0xb3, 0xcb, 0xee, 0xa6, 0xa8, 0xff, 0x86, 0x33, 0xbf, 0x12, 0x79, 0x77,
-	0xed, 0xac, 0x53, 0x4b, 0x51, 0x6f, 0x9e, 0x99, 0x7d, 0xef, 0xcd, 0x9b, 0x19, 0xc3, 0x50, 0x16,
-	0xfc, 0x06, 0x55, 0x22, 0x95, 0x30, 0x82, 0xf4, 0x5d, 0x14, 0x9f, 0xad, 0x84, 0x58, 0x15, 0x78,
-	0x6e, 0xb3, 0x8b, 0xcd, 0xcf, 0xf3, 0x3f, 0x8a, 0x4b, 0x89, 0x4a, 0xbb, 0x77, 0xec, 0x01, 0x0c,
-	0x33, 0xc3, 0x0d, 0xa6, 0xf8, 0x7b, 0x83, 0xda, 0xb0, 0xef, 0x30, 0xf2, 0xb1, 0x96, 0x62, 0xad,
-	0x91, 0x4c, 0xa0, 0x27, 0x85, 0x32, 0x9a, 0x46, 0xd3, 0xce, 0xec, 0x28, 0x75, 0x01, 0x89, 0xe1,
-	0xbe, 0x36, 0x42, 0xf1, 0x15, 0x6a, 0x7a, 0x68, 0x0b, 0x75, 0x4c, 0x28, 0xdc, 0xfb, 0x81, 0xd7,
-	0xf9, 0x12, 0x35, 0xed, 0xd8, 0x52, 0x15, 0xb2, 0x1c, 0xc6, 0x99, 0xe1, 0xca, 0xa4, 0xb8, 0xc4,
-	0xfc, 0xba, 0xd2, 0x24, 0x04, 0xba, 0x25, 0x2b, 0x8d, 0xa6, 0xd1, 0xec, 0x28, 0xb5, 0xdf, 0xe4,
-	0x3d, 0x0c, 0x3c, 0xe1, 0xe5, 0xe5, 0xe7, 0x8f, 0xf4, 0x70, 0x1a, 0xcd, 0x06, 0x17, 0xa7, 0x89,
-	0x73, 0x93, 0x54, 0x6e, 0x92, 0xcc, 0xa8, 0x7c, 0xbd, 0xfa, 0xc6, 0x8b, 0x0d, 0xa6, 0x21, 0x80,
-	0xbd, 0x81, 0x49, 0x53, 0xca, 0xdb, 0x99, 0x36, 0x79, 0x9d, 0x64, 0x03, 0x39, 0x03, 0x92, 0x19,
-	0x21, 0xf7, 0xf7, 0xc8, 0x8e, 0x4b, 0x3b, 0xc1, 0x4b, 0x27, 0xc1, 0xfe, 0x45, 0xf0, 0xc8, 0x6a,
-	0xcf, 0x0b, 0x7e, 0x53, 0xe1, 0xcf, 0x00, 0xdc, 0x14, 0xbe, 0xf2, 0x2b, 0xf4, 0x2c, 0x41, 0xa6,
-	0x1c, 0xe8, 0xf2, 0x17, 0x5f, 0xaf, 0xb1, 0xd0, 0xd6, 0xec, 0x28, 0xad, 0xe3, 0x52, 0x5b, 0x71,
-	0x83, 0xb4, 0x63, 0xf3, 0xf6, 0x9b, 0x3c, 0x87, 0xd1, 0x22, 0x37, 0x7a, 0x8e, 0x2a, 0xe3, 0x57,
-	0xb2, 0x40, 0xda, 0xb5, 0xc5, 0x66, 0x72, 0xd7, 0x6d, 0xef, 0xb6, 0xdb, 0x31, 0x3c, 0x0e, 0x7a,
-	0xf5, 0x0e, 0x5e, 0xc0, 0xc3, 0xd2, 0xd8, 0x1d, 0xfa, 0x67, 0xa4, 0xf4, 0x5c, 0x41, 0x3c, 0xcd,
-	0x6b, 0x18, 0x7f, 0x28, 0x90, 0xab, 0xcc, 0xe9, 0x55, 0x54, 0xfb, 0x57, 0xf0, 0x04, 0x26, 0x4d,
-	0xa0, 0x23, 0xbc, 0xf8, 0xdb, 0x81, 0xfe, 0xdc, 0xde, 0x35, 0x79, 0x05, 0x3d, 0x7b, 0xa7, 0x64,
-	0x92, 0xf8, 0xbb, 0x0f, 0xcf, 0x38, 0x3e, 0xde, 0xc9, 0xfa, 0x8e, 0x0e, 0xc8, 0x17, 0x18, 0xfa,
-	0x7d, 0x59, 0xdb, 0xe4, 0x24, 0x78, 0xb8, 0x7b, 0x98, 0xf1, 0x69, 0x7b, 0xb1, 0x26, 0xfb, 0x04,
-	0x83, 0x9a, 0x4c, 0x48, 0x12, 0x6f, 0x9f, 0xef, 0xde, 0x4f, 0x7c, 0xd2, 0x5a, 0xab, 0x99, 0xde,
-	0x41, 0xb7, 0x34, 0x46, 0x68, 0x43, 0x31, 0x58, 0x40, 0xfc, 0xac, 0xa5, 0x52, 0xc3, 0xdf, 0x42,
-	0xd7, 0x76, 0xf0, 0x34, 0x54, 0x09, 0xd1, 0xf4, 0x76, 0x21, 0x1c, 0x49, 0x38, 0xed, 0xed, 0x48,
-	0x5a, 0x96, 0xb7, 0x1d, 0x49, 0xdb, 0x82, 0xd8, 0xc1, 0xa2, 0x6f, 0x7f, 0xcd, 0x97, 0xff, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x34, 0xfd, 0x36, 0xc0, 0x8e, 0x04, 0x00, 0x00,
+	// 1125 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdf, 0x6e, 0xe3, 0xc4,
+	0x17, 0x5e, 0x27, 0x8e, 0x9b, 0x9c, 0x34, 0xdd, 0xfe, 0x26, 0x69, 0x7e, 0x5e, 0xb7, 0x5a, 0x55,
+	0x16, 0x17, 0x11, 0x17, 0x2d, 0x74, 0x25, 0x56, 0x80, 0x00, 0x2d, 0x2c, 0x88, 0xe5, 0xcf, 0x52,
+	0x4d, 0xb4, 0xcb, 0xf5, 0x34, 0x99, 0x76, 0x2d, 0x12, 0xdb, 0xcc, 0x8c, 0xbb, 0x2a, 0xaf, 0x00,
+	0xd7, 0x08, 0x78, 0x1c, 0x1e, 0x86, 0xe7, 0x40, 0x73, 0x66, 0x6c, 0x8f, 0x93, 0x2c, 0xc9, 0xde,
+	0xf9, 0x9c, 0x33, 0xe7, 0xcf, 0x7c, 0xfe, 0xe6, 0x9b, 0x81, 0xfd, 0x7c, 0xc1, 0xee, 0xb8, 0x38,
+	0xcb, 0x45, 0xa6, 0x32, 0x12, 0x18, 0x2b, 0x7a, 0x78, 0x93, 0x65, 0x37, 0x0b, 0x7e, 0x8e, 0xde,
+	0xab, 0xe2, 0xfa, 0xfc, 0xb5, 0x60, 0x79, 0xce, 0x85, 0x34, 0xeb, 0xe2, 0x03, 0xd8, 0x9f, 0x2a,
+	0xa6, 0x38, 0xe5, 0x3f, 0x17, 0x5c, 0xaa, 0xf8, 0xb7, 0x16, 0x0c, 0xac, 0x43, 0xe6, 0x59, 0x2a,
+	0x39, 0x19, 0x41, 0x27, 0xcf, 0x84, 0x92, 0xa1, 0x77, 0xda, 0x9e, 0xf4, 0xa8, 0x31, 0x48, 0x04,
+	0x5d, 0xa9, 0x32, 0xc1, 0x6e, 0xb8, 0x0c, 0x5b, 0x18, 0xa8, 0x6c, 0x12, 0xc2, 0xde, 0x9c, 0xdf,
+	0x26, 0x33, 0x2e, 0xc3, 0x36, 0x86, 0x4a, 0x93, 0x7c, 0x04, 0x03, 0xbb, 0x0a, 0x7b, 0xc8, 0xd0,
+	0x3f, 0x6d, 0x4f, 0xfa, 0x17, 0xa3, 0x33, 0x3b, 0xfb, 0xd4, 0x09, 0xd2, 0xe6, 0x52, 0xf2, 0x18,
+	0xf6, 0x4d, 0x19, 0x9b, 0xda, 0xc1, 0xd4, 0x61, 0x99, 0xfa, 0xb4, 0x8e, 0xd1, 0xc6, 0x42, 0xdd,
+	0x54, 0xf0, 0x19, 0x4f, 0x6e, 0xcb, 0xcc, 0xa0, 0xd9, 0x94, 0x3a, 0x41, 0xda, 0x5c, 0x1a, 0xff,
+	0xd1, 0x82, 0x7d, 0x37, 0x4e, 0x08, 0xf8, 0x1a, 0x80, 0xd0, 0x3b, 0xf5, 0x26, 0x3d, 0x8a, 0xdf,
+	0xe4, 0x14, 0xfa, 0x76, 0xd4, 0x17, 0x2f, 0x9e, 0x3d, 0x0d, 0x5b, 0x18, 0x72, 0x5d, 0x1a, 0x91,
+	0x9c, 0xcd, 0x7e, 0xe2, 0x4a, 0x23, 0xe2, 0x4d, 0x7c, 0x5a, 0x9a, 0xba, 0xde, 0x22, 0x93, 0x2a,
+	0xf4, 0xd1, 0x8d, 0xdf, 0x7a, 0xf5, 0x82, 0x29, 0x9e, 0xce, 0xee, 0xc2, 0xce, 0xa9, 0x37, 0x69,
+	0xd3, 0xd2, 0xd4, 0xa8, 0xcf, 0x5e, 0xb1, 0x34, 0xe5, 0x0b, 0xbd, 0x0b, 0x6f, 0x32, 0xa0, 0x95,
+	0xad, 0x2b, 0x09, 0xa6, 0x78, 0xb8, 0x87, 0x7e, 0xfc, 0x26, 0xef, 0xc0, 0xe0, 0x2a, 0x51, 0xf2,
+	0x92, 0x8b, 0x29, 0x5b, 0xe6, 0x0b, 0x1e, 0x76, 0x31, 0xd8, 0x74, 0xea, 0xf9, 0x59, 0x31, 0x4f,
+	0xb2, 0xaf, 0x32, 0xb1, 0x64, 0x2a, 0xec, 0xe1, 0x1a, 0xd7, 0x85, 0x53, 0xea, 0xda, 0x60, 0xa7,
+	0x64, 0x8a, 0xc7, 0x33, 0xe8, 0x3b, 0x98, 0x93, 0x87, 0x00, 0x06, 0xf5, 0xe7, 0x6c, 0xc9, 0x2d,
+	0x3c, 0x8e, 0x87, 0x9c, 0x40, 0xaf, 0x48, 0xe7, 0x5c, 0x88, 0x22, 0x95, 0x08, 0x91, 0x4f, 0x6b,
+	0x07, 0x19, 0x43, 0xc0, 0x85, 0xc8, 0x44, 0x89, 0x8f, 0xb5, 0xe2, 0xdf, 0x3d, 0xcd, 0xcf, 0x9a,
+	0x06, 0xab, 0x58, 0x7b, 0xeb, 0x58, 0x13, 0xf0, 0x65, 0xf2, 0x0b, 0xb7, 0x3d, 0xf0, 0x1b, 0x71,
+	0x63, 0x39, 0x9b, 0x25, 0xea, 0xce, 0x36, 0xa8, 0x6c, 0x1d, 0x9b, 0x17, 0x82, 0xa9, 0x24, 0x4b,
+	0xf1, 0x2f, 0xb4, 0x69, 0x65, 0x23, 0x93, 0x45, 0x96, 0xe7, 0x7c, 0x8e, 0x7f, 0xc2, 0xa7, 0xa5,
+	0x19, 0xff, 0xe9, 0xc1, 0x70, 0xaa, 0x98, 0x50, 0x96, 0x1d, 0xf6, 0xfc, 0x6c, 0xe4, 0xc7, 0xa7,
+	0xeb, 0xfc, 0xe8, 0x5f, 0x9c, 0x9c, 0x99, 0x93, 0x79, 0x56, 0x9e, 0xcc, 0xb3, 0xa9, 0x12, 0x49,
+	0x7a, 0xf3, 0x92, 0x2d, 0x0a, 0xde, 0xdc, 0xd1, 0x18, 0x82, 0x59, 0x36, 0xe7, 0xb3, 0xf2, 0x38,
+	0x59, 0x4b, 0xfb, 0x05, 0xd7, 0x24, 0xc6, 0xb9, 0xbb, 0xd4, 0x5a, 0xf1, 0x73, 0x18, 0x35, 0x47,
+	0xb3, 0x27, 0x79, 0x3b, 0x76, 0x23, 0xe8, 0x60, 0x6d, 0xcb, 0x61, 0x63, 0xc4, 0x13, 0x20, 0x53,
+	0x95, 0xe5, 0xdb, 0x77, 0x1a, 0x1f, 0xc1, 0xb0, 0xb1, 0xd2, 0x34, 0x8e, 0xff, 0xf1, 0xe0, 0x10,
+	0x27, 0xba, 0x5c, 0xb0, 0xbb, 0x32, 0x7f, 0x1b, 0x61, 0x5c, 0xae, 0xb7, 0xde, 0xc0, 0xf5, 0xf6,
+	0x7f, 0x71, 0xdd, 0x7f, 0x03, 0xd7, 0x5d, 0x0c, 0x3a, 0xeb, 0x18, 0xac, 0x9c, 0x86, 0x60, 0xfd,
+	0x34, 0x84, 0xb0, 0x27, 0xf5, 0x6e, 0x9e, 0x28, 0x3c, 0x6c, 0x6d, 0x5a, 0x9a, 0xf1, 0x10, 0xfe,
+	0xe7, 0xec, 0xd3, 0xee, 0xfe, 0x7d, 0xb8, 0xaf, 0x41, 0x79, 0x8b, 0xbd, 0xc7, 0x04, 0x0e, 0xeb,
+	0x14, 0x5b, 0xe6, 0x07, 0xe8, 0xff, 0xc8, 0x12, 0xb5, 0x2b, 0x7c, 0x5b, 0x45, 0x29, 0x7e, 0x17,
+	0xf6, 0x4d, 0x41, 0x4b, 0x8f, 0x08, 0xba, 0xd7, 0x49, 0x9a, 0xc8, 0x57, 0x7c, 0x8e, 0xf5, 0xba,
+	0xb4, 0xb2, 0xe3, 0x73, 0x18, 0x50, 0xfe, 0x3a, 0x49, 0xe7, 0xbb, 0xee, 0xe0, 0x10, 0x0e, 0xca,
+	0x04, 0x3b, 0xff, 0x33, 0x5d, 0x22, 0x7f, 0x0b, 0x02, 0x38, 0x30, 0xb7, 0x9a, 0x30, 0x63, 0xf1,
+	0xdc, 0x05, 0xe7, 0x31, 0x0c, 0xbf, 0x58, 0x70, 0x26, 0xac, 0x56, 0x94, 0x2d, 0xb6, 0x32, 0x3e,
+	0x1e, 0xc3, 0xa8, 0x99, 0x68, 0x0b, 0x7e, 0x03, 0x87, 0x53, 0xae, 0x5e, 0x66, 0x8b, 0x62, 0xc9,
+	0x77, 0x1d, 0x78, 0x0c, 0xc1, 0x2d, 0x26, 0xe0, 0xbc, 0x2d, 0x6a, 0x2d, 0x64, 0x45, 0x5d, 0xcb,
+	0x36, 0x78, 0x02, 0xfd, 0xef, 0x0b, 0xb5, 0x73, 0x6d, 0x02, 0xfe, 0xb2, 0x50, 0xa6, 0x72, 0x97,
+	0xe2, 0xb7, 0xbe, 0xbb, 0x4d, 0x09, 0x5b, 0xf2, 0x3e, 0x0c, 0xbe, 0xbc, 0xe5, 0xa9, 0x92, 0xe5,
+	0x65, 0xfe, 0xab, 0x07, 0x1d, 0xf4, 0xe8, 0x74, 0x75, 0x97, 0x97, 0x85, 0xf1, 0x7b, 0xa5, 0x65,
+	0x6b, 0x1b, 0x83, 0xda, 0x1b, 0xaf, 0xb5, 0x25, 0x97, 0x92, 0xdd, 0x98, 0xc3, 0xd6, 0xa3, 0xa5,
+	0x89, 0xfd, 0x92, 0x25, 0xb7, 0xf7, 0x17, 0x7e, 0xc7, 0x23, 0x20, 0xdf, 0x25, 0x52, 0x99, 0x4b,
+	0xa3, 0x9a, 0xf1, 0x33, 0x18, 0x36, 0xbc, 0x96, 0x8c, 0x93, 0xfa, 0x0d, 0xe1, 0xe1, 0x75, 0x7d,
+	0xd0, 0xbc, 0xe8, 0xab, 0x37, 0x45, 0xfc, 0x97, 0x07, 0x81, 0xf1, 0xe9, 0xae, 0x69, 0x0d, 0x9f,
+	0x9f, 0xda, 0x5d, 0xcc, 0xb9, 0x9c, 0x89, 0x24, 0x47, 0x85, 0xb7, 0xe7, 0xc0, 0x71, 0x69, 0xd1,
+	0x13, 0xf8, 0x2e, 0xd0, 0xea, 0x3a, 0xa0, 0xc6, 0x68, 0xc8, 0x8f, 0x8f, 0x81, 0xca, 0xd6, 0xc3,
+	0x5d, 0xa3, 0x14, 0x94, 0xaf, 0x90, 0x6a, 0x38, 0xa3, 0x10, 0xb4, 0x0c, 0xc7, 0x97, 0x10, 0x18,
+	0xd7, 0xba, 0x3c, 0x79, 0x3b, 0x5c, 0xc5, 0xad, 0x35, 0xf1, 0xb9, 0xf8, 0x3b, 0x80, 0xe0, 0x12,
+	0x9b, 0x91, 0x0f, 0xa0, 0x63, 0x2e, 0x45, 0xe7, 0xfd, 0x54, 0x3f, 0xe5, 0xa2, 0xa3, 0x15, 0xaf,
+	0x65, 0xc9, 0x3d, 0xf2, 0xad, 0xfb, 0xa6, 0x11, 0x8a, 0x1c, 0x3b, 0x0b, 0x57, 0x2f, 0xb4, 0xe8,
+	0x64, 0x73, 0xb0, 0x2a, 0xf6, 0x35, 0xf4, 0xab, 0x62, 0x59, 0x4e, 0xa2, 0x7a, 0xf9, 0xea, 0x8d,
+	0x11, 0x1d, 0x6f, 0x8c, 0x55, 0x95, 0x3e, 0x01, 0x5f, 0x6f, 0x8c, 0x84, 0x8d, 0x8e, 0x8e, 0x6c,
+	0x46, 0x0f, 0x36, 0x44, 0xaa, 0xf4, 0x8f, 0xc1, 0xc7, 0x09, 0xfe, 0xef, 0x76, 0x71, 0xb3, 0xc3,
+	0xf5, 0x40, 0x95, 0xfc, 0x08, 0x7c, 0xad, 0x85, 0xa4, 0x7a, 0x4e, 0x3a, 0x52, 0x1b, 0x8d, 0x9a,
+	0xce, 0x2a, 0xe9, 0x43, 0x08, 0x8c, 0xc6, 0x91, 0xa3, 0xfa, 0x2d, 0xe9, 0x88, 0x64, 0x34, 0x5e,
+	0x75, 0x37, 0x53, 0x75, 0xd0, 0x4d, 0x75, 0xc4, 0x31, 0x1a, 0xaf, 0xba, 0xdd, 0xbf, 0xe7, 0x2a,
+	0x56, 0xfd, 0xf7, 0x36, 0x08, 0x60, 0x74, 0xb2, 0x39, 0x58, 0x15, 0xfb, 0x1c, 0x7a, 0x95, 0x34,
+	0x39, 0xc0, 0xaf, 0x28, 0x5f, 0xf4, 0x60, 0x43, 0xc4, 0xc5, 0x4e, 0xcb, 0x50, 0x8d, 0x9d, 0xa3,
+	0x6b, 0xd1, 0xa8, 0xe9, 0xac, 0x92, 0x2e, 0x20, 0x30, 0x5a, 0x55, 0x03, 0xd0, 0xd0, 0xae, 0x68,
+	0xd0, 0x70, 0xc7, 0xf7, 0xde, 0xf3, 0x34, 0xd5, 0x1c, 0xa9, 0xa8, 0xa9, 0xb6, 0xae, 0x2a, 0xd1,
+	0xf1, 0xc6, 0x58, 0xd9, 0xfd, 0x2a, 0xc0, 0x47, 0xd7, 0xa3, 0x7f, 0x07, 0x00, 0x2f, 0x23, 0x17,
+	0x11, 0x34, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -582,7 +1650,21 @@ type PlayerClient interface {
 	Play(ctx context.Context, in *StartPlayRequest, opts ...grpc.CallOption) (*StartPlayResponse, error)
 	// Stop audio on deviceName
 	Stop(ctx context.Context, in *StopPlayRequest, opts ...grpc.CallOption) (*StopPlayResponse, error)
+	// Wait end of playing on deviceName, playing ends when storage is played to end or on Stop
+	Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error)
+	// Rewind playing on deviceName to beginning of storage
+	Rewind(ctx context.Context, in *RewindRequest, opts ...grpc.CallOption) (*RewindResponse, error)
+	// Replay play again last played storage on deviceName
+	Replay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
 	ClearStorage(ctx context.Context, in *ClearStorageRequest, opts ...grpc.CallOption) (*ClearStorageResponse, error)
+	// SetVolume set volume level on deviceName, 1 - original loudness
+	SetVolume(ctx context.Context, in *SetVolumeRequest, opts ...grpc.CallOption) (*SetVolumeResponse, error)
+	// Mute or unmute deviceName
+	Mute(ctx context.Context, in *MuteRequest, opts ...grpc.CallOption) (*MuteResponse, error)
+	// Events on devices of player from start of streaming
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Player_EventsClient, error)
+	// ListDevices return playback pcm devices of player with supported rates, channels and formats
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
 }
 
 type playerClient struct {
@@ -638,6 +1720,33 @@ func (c *playerClient) Stop(ctx context.Context, in *StopPlayRequest, opts ...gr
 	return out, nil
 }
 
+func (c *playerClient) Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error) {
+	out := new(WaitResponse)
+	err := c.cc.Invoke(ctx, "/player.Player/Wait", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Rewind(ctx context.Context, in *RewindRequest, opts ...grpc.CallOption) (*RewindResponse, error) {
+	out := new(RewindResponse)
+	err := c.cc.Invoke(ctx, "/player.Player/Rewind", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Replay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
+	out := new(ReplayResponse)
+	err := c.cc.Invoke(ctx, "/player.Player/Replay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playerClient) ClearStorage(ctx context.Context, in *ClearStorageRequest, opts ...grpc.CallOption) (*ClearStorageResponse, error) {
 	out := new(ClearStorageResponse)
 	err := c.cc.Invoke(ctx, "/player.Player/ClearStorage", in, out, opts...)
@@ -647,6 +1756,65 @@ func (c *playerClient) ClearStorage(ctx context.Context, in *ClearStorageRequest
 	return out, nil
 }
 
+func (c *playerClient) SetVolume(ctx context.Context, in *SetVolumeRequest, opts ...grpc.CallOption) (*SetVolumeResponse, error) {
+	out := new(SetVolumeResponse)
+	err := c.cc.Invoke(ctx, "/player.Player/SetVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Mute(ctx context.Context, in *MuteRequest, opts ...grpc.CallOption) (*MuteResponse, error) {
+	out := new(MuteResponse)
+	err := c.cc.Invoke(ctx, "/player.Player/Mute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Player_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Player_serviceDesc.Streams[0], "/player.Player/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playerEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Player_EventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type playerEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *playerEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playerClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, "/player.Player/ListDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlayerServer is the server API for Player service.
 type PlayerServer interface {
 	// State return receiving ports, storages and busy device
@@ -658,7 +1826,21 @@ type PlayerServer interface {
 	Play(context.Context, *StartPlayRequest) (*StartPlayResponse, error)
 	// Stop audio on deviceName
 	Stop(context.Context, *StopPlayRequest) (*StopPlayResponse, error)
+	// Wait end of playing on deviceName, playing ends when storage is played to end or on Stop
+	Wait(context.Context, *WaitRequest) (*WaitResponse, error)
+	// Rewind playing on deviceName to beginning of storage
+	Rewind(context.Context, *RewindRequest) (*RewindResponse, error)
+	// Replay play again last played storage on deviceName
+	Replay(context.Context, *ReplayRequest) (*ReplayResponse, error)
 	ClearStorage(context.Context, *ClearStorageRequest) (*ClearStorageResponse, error)
+	// SetVolume set volume level on deviceName, 1 - original loudness
+	SetVolume(context.Context, *SetVolumeRequest) (*SetVolumeResponse, error)
+	// Mute or unmute deviceName
+	Mute(context.Context, *MuteRequest) (*MuteResponse, error)
+	// Events on devices of player from start of streaming
+	Events(*EventsRequest, Player_EventsServer) error
+	// ListDevices return playback pcm devices of player with supported rates, channels and formats
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
 }
 
 // UnimplementedPlayerServer can be embedded to have forward compatible implementations.
@@ -680,9 +1862,30 @@ func (*UnimplementedPlayerServer) Play(ctx context.Context, req *StartPlayReques
 func (*UnimplementedPlayerServer) Stop(ctx context.Context, req *StopPlayRequest) (*StopPlayResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (*UnimplementedPlayerServer) Wait(ctx context.Context, req *WaitRequest) (*WaitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Wait not implemented")
+}
+func (*UnimplementedPlayerServer) Rewind(ctx context.Context, req *RewindRequest) (*RewindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rewind not implemented")
+}
+func (*UnimplementedPlayerServer) Replay(ctx context.Context, req *ReplayRequest) (*ReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replay not implemented")
+}
 func (*UnimplementedPlayerServer) ClearStorage(ctx context.Context, req *ClearStorageRequest) (*ClearStorageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearStorage not implemented")
 }
+func (*UnimplementedPlayerServer) SetVolume(ctx context.Context, req *SetVolumeRequest) (*SetVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVolume not implemented")
+}
+func (*UnimplementedPlayerServer) Mute(ctx context.Context, req *MuteRequest) (*MuteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mute not implemented")
+}
+func (*UnimplementedPlayerServer) Events(req *EventsRequest, srv Player_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (*UnimplementedPlayerServer) ListDevices(ctx context.Context, req *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
 
 func RegisterPlayerServer(s *grpc.Server, srv PlayerServer) {
 	s.RegisterService(&_Player_serviceDesc, srv)
@@ -778,6 +1981,60 @@ func _Player_Stop_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_Wait_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Wait(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/player.Player/Wait",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Wait(ctx, req.(*WaitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Rewind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Rewind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/player.Player/Rewind",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Rewind(ctx, req.(*RewindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Replay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Replay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/player.Player/Replay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Replay(ctx, req.(*ReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Player_ClearStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ClearStorageRequest)
 	if err := dec(in); err != nil {
@@ -796,6 +2053,81 @@ func _Player_ClearStorage_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_SetVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).SetVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/player.Player/SetVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).SetVolume(ctx, req.(*SetVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Mute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).Mute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/player.Player/Mute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).Mute(ctx, req.(*MuteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Player_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlayerServer).Events(m, &playerEventsServer{stream})
+}
+
+type Player_EventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type playerEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *playerEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Player_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/player.Player/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Player_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "player.Player",
 	HandlerType: (*PlayerServer)(nil),
@@ -820,11 +2152,41 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _Player_Stop_Handler,
 		},
+		{
+			MethodName: "Wait",
+			Handler:    _Player_Wait_Handler,
+		},
+		{
+			MethodName: "Rewind",
+			Handler:    _Player_Rewind_Handler,
+		},
+		{
+			MethodName: "Replay",
+			Handler:    _Player_Replay_Handler,
+		},
 		{
 			MethodName: "ClearStorage",
 			Handler:    _Player_ClearStorage_Handler,
 		},
+		{
+			MethodName: "SetVolume",
+			Handler:    _Player_SetVolume_Handler,
+		},
+		{
+			MethodName: "Mute",
+			Handler:    _Player_Mute_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _Player_ListDevices_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _Player_Events_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "player.proto",
 }
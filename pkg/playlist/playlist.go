@@ -0,0 +1,190 @@
+package playlist
+
+import (
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lead time of signal sent ahead of playing, small lead lets skip and clear take effect quickly
+const lead = 500 * time.Millisecond
+
+// Opener return reader of file audio signal in format of playlist
+type Opener func(file string) (io.Reader, error)
+
+// Playlist queue of files played one by one as one continuous signal.
+// Signal is read in real time, reading is blocked while there is nothing to play.
+type Playlist struct {
+	mutex sync.Mutex
+	cond  *sync.Cond
+
+	open     Opener
+	byteRate int
+
+	files   []string
+	current int
+	played  map[int]bool
+	r       io.Reader
+	loop    bool
+	shuffle bool
+	closed  bool
+
+	start time.Time
+	sent  int
+}
+
+// Read next part of signal.
+func (p *Playlist) Read(b []byte) (n int, err error) {
+	p.mutex.Lock()
+	for n == 0 {
+		if p.closed {
+			p.mutex.Unlock()
+			return 0, io.EOF
+		}
+		if p.r == nil && !p.next() {
+			p.cond.Wait()
+			continue
+		}
+		if n, err = p.r.Read(b); err != nil {
+			p.r, err = nil, nil
+		}
+	}
+	p.mutex.Unlock()
+
+	p.pace(n)
+	return
+}
+
+// pace block reading to keep signal no more than lead ahead of real time
+func (p *Playlist) pace(n int) {
+	now := time.Now()
+	// player has played all sent signal, real time is counted again
+	if p.sent == 0 || now.Sub(p.start) > p.duration(p.sent) {
+		p.start, p.sent = now, 0
+	}
+	p.sent += n
+	if wait := p.duration(p.sent) - lead - now.Sub(p.start); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+func (p *Playlist) duration(size int) time.Duration {
+	return time.Duration(float64(size) / float64(p.byteRate) * float64(time.Second))
+}
+
+// next open next file for playing, return false if there is nothing to play
+// or no file of one full round can be opened
+func (p *Playlist) next() bool {
+	for failed := 0; ; failed++ {
+		index, isExist := p.nextIndex()
+		if !isExist || failed >= len(p.files) {
+			p.current = -1
+			return false
+		}
+		p.current = index
+		p.played[index] = true
+		r, err := p.open(p.files[index])
+		if err == nil {
+			p.r = r
+			return true
+		}
+		// file can not be played, it is skipped
+	}
+}
+
+func (p *Playlist) nextIndex() (index int, isExist bool) {
+	if len(p.played) == len(p.files) {
+		if !p.loop || len(p.files) == 0 {
+			return
+		}
+		p.played = make(map[int]bool)
+	}
+
+	if !p.shuffle {
+		for index = 0; p.played[index]; index++ {
+		}
+		return index, true
+	}
+
+	free := make([]int, 0, len(p.files)-len(p.played))
+	for i := range p.files {
+		if !p.played[i] && (i != p.current || len(p.files) == 1) {
+			free = append(free, i)
+		}
+	}
+	if len(free) == 0 {
+		// only current file is not played in new round
+		return p.current, true
+	}
+	return free[rand.Intn(len(free))], true
+}
+
+// Enqueue add files to the end of playlist
+func (p *Playlist) Enqueue(files ...string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.files = append(p.files, files...)
+	p.cond.Broadcast()
+}
+
+// Skip current file and play next one
+func (p *Playlist) Skip() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.r = nil
+}
+
+// Clear remove all files from playlist and stop current file
+func (p *Playlist) Clear() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.files = nil
+	p.played = make(map[int]bool)
+	p.current = -1
+	p.r = nil
+}
+
+// Mode set playing in loop and in random order
+func (p *Playlist) Mode(loop, shuffle bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.loop, p.shuffle = loop, shuffle
+	p.cond.Broadcast()
+}
+
+// State return files of playlist, index of playing file (-1 if nothing is playing) and mode
+func (p *Playlist) State() (files []string, current int, loop, shuffle bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	files = append(files, p.files...)
+	return files, p.current, p.loop, p.shuffle
+}
+
+// Close playlist, reading return io.EOF
+func (p *Playlist) Close() (err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.closed = true
+	p.cond.Broadcast()
+	return
+}
+
+// NewPlaylist return empty playlist with signal of byteRate, files are opened with open
+func NewPlaylist(open Opener, byteRate int) *Playlist {
+	p := &Playlist{
+		open:     open,
+		byteRate: byteRate,
+
+		current: -1,
+		played:  make(map[int]bool),
+	}
+	p.cond = sync.NewCond(&p.mutex)
+	return p
+}
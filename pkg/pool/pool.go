@@ -0,0 +1,165 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	// client side health checking
+	_ "google.golang.org/grpc/health"
+)
+
+const (
+	// serviceConfig turns on health checking of connections, grpc checks health only with round_robin balancer.
+	// Calls on unhealthy connection fail at once, server without health service is healthy.
+	serviceConfig = `{"loadBalancingConfig":[{"round_robin":{}}],"healthCheckConfig":{"serviceName":""}}`
+	// minConnectTimeout of one attempt to connect
+	minConnectTimeout = 5 * time.Second
+)
+
+// Pool of grpc connections by address of player or recorder.
+// Connection is dialed on first call, broken connection is reconnected by grpc with backoff,
+// connection without calls for idle timeout is closed.
+type Pool struct {
+	timeout     time.Duration
+	idleTimeout time.Duration
+	options     []grpc.DialOption
+
+	mutex sync.Mutex
+	conns map[string]*conn
+
+	stop chan struct{}
+	once sync.Once
+}
+
+type conn struct {
+	*grpc.ClientConn
+	// calls in progress, connection with calls is not closed
+	calls int
+	used  time.Time
+}
+
+// Call return connection to addr for unary call, ctx of call is limited by timeout of pool.
+// done must be called when call is finished.
+func (p *Pool) Call(ctx context.Context, addr string) (callCtx context.Context, cc *grpc.ClientConn, done func(), err error) {
+	cc, release, err := p.get(addr)
+	if err != nil {
+		return
+	}
+	if p.timeout == 0 {
+		return ctx, cc, release, nil
+	}
+	callCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	done = func() {
+		cancel()
+		release()
+	}
+	return
+}
+
+// Stream return connection to addr for streaming or long call without deadline of pool.
+// done must be called when stream is finished.
+func (p *Pool) Stream(addr string) (cc *grpc.ClientConn, done func(), err error) {
+	return p.get(addr)
+}
+
+func (p *Pool) get(addr string) (cc *grpc.ClientConn, release func(), err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	c, isExist := p.conns[addr]
+	if !isExist || c.GetState() == connectivity.Shutdown {
+		// dial does not wait for connection, it is connected in background
+		var clientConn *grpc.ClientConn
+		if clientConn, err = grpc.Dial(addr, p.options...); err != nil {
+			return
+		}
+		c = &conn{
+			ClientConn: clientConn,
+		}
+		p.conns[addr] = c
+	}
+	c.calls++
+
+	release = func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		c.calls--
+		c.used = time.Now()
+	}
+	return c.ClientConn, release, nil
+}
+
+// evict close connections without calls for idle timeout
+func (p *Pool) evict(now time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for addr, c := range p.conns {
+		if c.calls == 0 && now.Sub(c.used) >= p.idleTimeout {
+			c.Close()
+			delete(p.conns, addr)
+		}
+	}
+}
+
+func (p *Pool) evicting() {
+	ticker := time.NewTicker(p.idleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case now := <-ticker.C:
+			p.evict(now)
+		}
+	}
+}
+
+// Close all connections of pool
+func (p *Pool) Close() {
+	p.once.Do(func() {
+		close(p.stop)
+	})
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for addr, c := range p.conns {
+		c.Close()
+		delete(p.conns, addr)
+	}
+}
+
+// NewPool timeout is deadline of unary call, idleTimeout of unused connection, 0 - no limit.
+// maxBackoff is max delay between attempts to reconnect.
+// options of dialing, for example credentials of transport.
+func NewPool(timeout, idleTimeout, maxBackoff time.Duration, options ...grpc.DialOption) *Pool {
+	b := backoff.DefaultConfig
+	b.MaxDelay = maxBackoff
+	p := &Pool{
+		timeout:     timeout,
+		idleTimeout: idleTimeout,
+		options: append(
+			[]grpc.DialOption{
+				grpc.WithDefaultServiceConfig(serviceConfig),
+				grpc.WithConnectParams(grpc.ConnectParams{
+					Backoff:           b,
+					MinConnectTimeout: minConnectTimeout,
+				}),
+			},
+			options...,
+		),
+		conns: make(map[string]*conn),
+		stop:  make(chan struct{}),
+	}
+	if idleTimeout != 0 {
+		go p.evicting()
+	}
+	return p
+}
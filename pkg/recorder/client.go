@@ -3,27 +3,38 @@ package recorder
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/grpc"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
 )
 
+// pool of grpc connections shared by clients
+type pool interface {
+	Call(ctx context.Context, addr string) (callCtx context.Context, cc *grpc.ClientConn, done func(), err error)
+	Stream(addr string) (cc *grpc.ClientConn, done func(), err error)
+}
+
 // Client rpc recorder
 type Client struct {
 	hostLayout  string
 	controlPort string
+	conns       pool
+}
+
+func (c *Client) addr(ip string) string {
+	return fmt.Sprintf(c.hostLayout, ip, c.controlPort)
 }
 
 // State return busy recorder device
 func (c *Client) State(ctx context.Context, ip string) (devices []string, err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, ip, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(ip))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
 	if res, err := NewRecorderClient(conn).
 		State(
@@ -36,43 +47,41 @@ func (c *Client) State(ctx context.Context, ip string) (devices []string, err er
 }
 
 // Start rpc request for start record and send audio signal on server
-func (c *Client) Start(ctx context.Context, destAddr, recorderIP, deviceName string, channels, rate uint32) (err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, recorderIP, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+// channels, rate, bitsPerSample, audioFormat - recording options
+// codecs of signal in order of preference, recorder returns chosen codec, pcm if codecs are not supported
+func (c *Client) Start(ctx context.Context, destAddr, recorderIP, deviceName string, channels, rate, bitsPerSample, audioFormat uint32, codecs []string) (codec string, err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(recorderIP))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
-	_, err = NewRecorderClient(conn).
+	res, err := NewRecorderClient(conn).
 		Start(
 			ctx,
 			&StartSendRequest{
-				DeviceName: deviceName,
-				Channels:   channels,
-				Rate:       rate,
-				DestAddr:   destAddr,
+				DeviceName:    deviceName,
+				Channels:      channels,
+				Rate:          rate,
+				DestAddr:      destAddr,
+				BitsPerSample: bitsPerSample,
+				AudioFormat:   audioFormat,
+				Codecs:        codecs,
 			})
 	if err != nil {
 		return
 	}
+	codec = res.Codec
 	return
 }
 
 // Stop rpc request for stop record and send audio signal
 func (c *Client) Stop(ctx context.Context, recorderIP, deviceName string) (err error) {
-	conn, err := grpc.Dial(
-		fmt.Sprintf(c.hostLayout, recorderIP, c.controlPort),
-		// todo
-		grpc.WithInsecure(),
-	)
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(recorderIP))
 	if err != nil {
 		return
 	}
-	defer conn.Close()
+	defer done()
 
 	_, err = NewRecorderClient(conn).
 		Stop(
@@ -87,10 +96,96 @@ func (c *Client) Stop(ctx context.Context, recorderIP, deviceName string) (err e
 	return
 }
 
-// NewClient ...
-func NewClient(hostLayout, controlPort string) *Client {
+// Events rpc streaming of events on devices of recorder with recorderIP.
+// events are closed when streaming is ended by ctx or by error of connection.
+func (c *Client) Events(ctx context.Context, recorderIP string) (events <-chan event.Event, err error) {
+	conn, done, err := c.conns.Stream(c.addr(recorderIP))
+	if err != nil {
+		return
+	}
+
+	stream, err := NewRecorderClient(conn).
+		Events(
+			ctx,
+			&EventsRequest{},
+		)
+	if err != nil {
+		done()
+		return
+	}
+
+	e := make(chan event.Event)
+	go func() {
+		defer func() {
+			close(e)
+			done()
+		}()
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case e <- event.Event{
+				Source:     event.Recorder,
+				IP:         recorderIP,
+				Type:       res.Type,
+				DeviceName: res.DeviceName,
+				Message:    res.Message,
+				Time:       time.Unix(0, res.Time),
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return e, nil
+}
+
+// ListDevices rpc request to recorder with recorderIP for capture pcm devices with supported rates, channels and formats
+func (c *Client) ListDevices(ctx context.Context, recorderIP string) (devices []pcm.Device, err error) {
+	ctx, conn, done, err := c.conns.Call(ctx, c.addr(recorderIP))
+	if err != nil {
+		return
+	}
+	defer done()
+
+	res, err := NewRecorderClient(conn).
+		ListDevices(
+			ctx,
+			&ListDevicesRequest{},
+		)
+	if err != nil {
+		return
+	}
+	devices = make([]pcm.Device, 0, len(res.Devices))
+	for _, d := range res.Devices {
+		device := pcm.Device{
+			Name:        d.Name,
+			Description: d.Description,
+		}
+		for _, rate := range d.Rates {
+			device.Rates = append(device.Rates, int(rate))
+		}
+		for _, channels := range d.Channels {
+			device.Channels = append(device.Channels, int(channels))
+		}
+		for _, f := range d.Formats {
+			device.Formats = append(device.Formats, pcm.Format{
+				BitsPerSample: int(f.BitsPerSample),
+				AudioFormat:   int(f.AudioFormat),
+			})
+		}
+		devices = append(devices, device)
+	}
+	return
+}
+
+// NewClient conns is pool of connections, it can be shared by player and recorder clients
+func NewClient(hostLayout, controlPort string, conns pool) *Client {
 	return &Client{
 		hostLayout:  hostLayout,
 		controlPort: controlPort,
+		conns:       conns,
 	}
 }
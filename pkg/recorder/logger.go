@@ -38,6 +38,24 @@ func (l *loggerMiddleware) Stop(ctx context.Context, in *StopSendRequest) (out *
 	return
 }
 
+// Events log
+func (l *loggerMiddleware) Events(in *EventsRequest, stream Recorder_EventsServer) (err error) {
+	l.logger.Log("Events", "start", "in", in.String())
+	if err = l.server.Events(in, stream); err != nil {
+		l.logger.Log("Events", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
+// ListDevices log
+func (l *loggerMiddleware) ListDevices(ctx context.Context, in *ListDevicesRequest) (out *ListDevicesResponse, err error) {
+	l.logger.Log("ListDevices", "start", "in", in.String())
+	if out, err = l.server.ListDevices(ctx, in); err != nil {
+		l.logger.Log("ListDevices", "err", "in", in.String(), "err", err.Error())
+	}
+	return
+}
+
 // NewLoggerMiddleware recoder
 func NewLoggerMiddleware(
 	logger log.Logger,
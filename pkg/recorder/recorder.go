@@ -5,22 +5,64 @@ import (
 	"fmt"
 	"io"
 	"sync"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
 )
 
 type tcp interface {
 	TurnOnSender(string) (io.WriteCloser, error)
 }
 
+// formatSetter destination sending format of samples
+type formatSetter interface {
+	SetFormat(channels, rate, bitsPerSample, audioFormat int)
+}
+
+const (
+	// defaultBitsPerSample if bitsPerSample is not set in request
+	defaultBitsPerSample = 16
+	// pcmCodec of not encoded samples
+	pcmCodec = "pcm"
+	// eventsBuffSize events buffered for each subscriber
+	eventsBuffSize = 64
+)
+
+type encoder interface {
+	Choose(names []string) string
+	Encoder(name string, w io.WriteCloser, channels, bitsPerSample, audioFormat int) (io.WriteCloser, error)
+}
+
 type device interface {
-	Record(context.Context, string, int, int, io.WriteCloser) error
+	Record(context.Context, string, int, int, int, int, io.WriteCloser) error
+	Devices() ([]pcm.Device, error)
 }
 
 type recorder struct {
 	mutex         sync.Mutex
 	captureDevice map[string]func()
 
-	tcp    tcp
-	device device
+	events *event.Bus
+
+	tcp     tcp
+	device  device
+	encoder encoder
+}
+
+// sending writer of recorded signal, failed is called on first error of sending
+type sending struct {
+	io.WriteCloser
+	failed func(err error)
+	err    error
+}
+
+// Write recorded signal
+func (s *sending) Write(data []byte) (n int, err error) {
+	if n, err = s.WriteCloser.Write(data); err != nil && s.err == nil {
+		s.err = err
+		s.failed(err)
+	}
+	return
 }
 
 // State return busy recorder device
@@ -37,20 +79,51 @@ func (r *recorder) State(ctx context.Context, in *StateRequest) (out *StateRespo
 	return
 }
 
-// Start recording audio on recorder from recorderDeviceName
+// Start recording audio on recorder from recorderDeviceName.
+// Signal is encoded by first supported codec from request, format of samples is sent only with pcm.
 func (r *recorder) Start(c context.Context, in *StartSendRequest) (out *StartSendResponse, err error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	bitsPerSample := int(in.BitsPerSample)
+	if bitsPerSample == 0 {
+		bitsPerSample = defaultBitsPerSample
+	}
+
 	if _, isExist := r.captureDevice[in.DeviceName]; !isExist {
+		defer func() {
+			if err != nil {
+				r.publish(event.Error, in.DeviceName, err.Error())
+			}
+		}()
+
+		codec := r.encoder.Choose(in.Codecs)
 		var destination io.WriteCloser
 		if destination, err = r.tcp.TurnOnSender(in.DestAddr); err == nil {
+			if f, isFormatSetter := destination.(formatSetter); isFormatSetter && codec == pcmCodec {
+				f.SetFormat(int(in.Channels), int(in.Rate), bitsPerSample, int(in.AudioFormat))
+			}
+			var encoded io.WriteCloser
+			if encoded, err = r.encoder.Encoder(codec, destination, int(in.Channels), bitsPerSample, int(in.AudioFormat)); err != nil {
+				destination.Close()
+				return
+			}
+			destination = &sending{
+				WriteCloser: encoded,
+				failed: func(err error) {
+					r.publish(event.Error, in.DeviceName, err.Error())
+				},
+			}
 			ctx, stop := context.WithCancel(context.Background())
-			if err = r.device.Record(ctx, in.DeviceName, int(in.Channels), int(in.Rate), destination); err == nil {
+			if err = r.device.Record(ctx, in.DeviceName, int(in.Channels), int(in.Rate), bitsPerSample, int(in.AudioFormat), destination); err == nil {
 				r.captureDevice[in.DeviceName] = stop
-				out = &StartSendResponse{}
+				r.publish(event.Started, in.DeviceName, "")
+				out = &StartSendResponse{
+					Codec: codec,
+				}
 				return
 			}
+			destination.Close()
 			stop()
 		}
 		return
@@ -67,6 +140,7 @@ func (r *recorder) Stop(ctx context.Context, in *StopSendRequest) (out *StopSend
 	if stop, isExist := r.captureDevice[in.DeviceName]; isExist {
 		stop()
 		delete(r.captureDevice, in.DeviceName)
+		r.publish(event.Stopped, in.DeviceName, "")
 		out = &StopSendResponse{}
 		return
 	}
@@ -74,15 +148,80 @@ func (r *recorder) Stop(ctx context.Context, in *StopSendRequest) (out *StopSend
 	return
 }
 
+// Events send events on devices of recorder until client cancels streaming
+func (r *recorder) Events(in *EventsRequest, stream Recorder_EventsServer) (err error) {
+	events, cancel := r.events.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case e := <-events:
+			if err = stream.Send(&Event{
+				Type:       e.Type,
+				DeviceName: e.DeviceName,
+				Message:    e.Message,
+				Time:       e.Time.UnixNano(),
+			}); err != nil {
+				return
+			}
+		case <-stream.Context().Done():
+			return
+		}
+	}
+}
+
+// ListDevices return capture pcm devices of recorder with supported rates, channels and formats
+func (r *recorder) ListDevices(ctx context.Context, in *ListDevicesRequest) (out *ListDevicesResponse, err error) {
+	devices, err := r.device.Devices()
+	if err != nil {
+		return
+	}
+	out = &ListDevicesResponse{
+		Devices: make([]*Device, 0, len(devices)),
+	}
+	for _, d := range devices {
+		device := &Device{
+			Name:        d.Name,
+			Description: d.Description,
+		}
+		for _, rate := range d.Rates {
+			device.Rates = append(device.Rates, uint32(rate))
+		}
+		for _, channels := range d.Channels {
+			device.Channels = append(device.Channels, uint32(channels))
+		}
+		for _, f := range d.Formats {
+			device.Formats = append(device.Formats, &Format{
+				BitsPerSample: uint32(f.BitsPerSample),
+				AudioFormat:   uint32(f.AudioFormat),
+			})
+		}
+		out.Devices = append(out.Devices, device)
+	}
+	return
+}
+
+func (r *recorder) publish(eventType, deviceName, message string) {
+	r.events.Publish(event.Event{
+		Type:       eventType,
+		DeviceName: deviceName,
+		Message:    message,
+	})
+}
+
 // NewRecorder ...
 func NewRecorder(
 	tcp tcp,
 	device device,
+	encoder encoder,
 ) RecorderServer {
 	return &recorder{
 		captureDevice: make(map[string]func()),
 
-		tcp:    tcp,
-		device: device,
+		events: event.NewBus(eventsBuffSize),
+
+		tcp:     tcp,
+		device:  device,
+		encoder: encoder,
 	}
 }
@@ -96,10 +96,16 @@ func (m *StateResponse) GetDevices() []string {
 }
 
 type StartSendRequest struct {
-	DeviceName           string   `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
-	Channels             uint32   `protobuf:"varint,2,opt,name=channels,proto3" json:"channels,omitempty"`
-	Rate                 uint32   `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
-	DestAddr             string   `protobuf:"bytes,4,opt,name=destAddr,proto3" json:"destAddr,omitempty"`
+	DeviceName string `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	Channels   uint32 `protobuf:"varint,2,opt,name=channels,proto3" json:"channels,omitempty"`
+	Rate       uint32 `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	DestAddr   string `protobuf:"bytes,4,opt,name=destAddr,proto3" json:"destAddr,omitempty"`
+	// bitsPerSample default 16
+	BitsPerSample uint32 `protobuf:"varint,5,opt,name=bitsPerSample,proto3" json:"bitsPerSample,omitempty"`
+	// audioFormat as in wav header: 1 - PCM, 3 - IEEE float
+	AudioFormat uint32 `protobuf:"varint,6,opt,name=audioFormat,proto3" json:"audioFormat,omitempty"`
+	// codecs of signal in order of preference, recorder chooses first supported, pcm if none
+	Codecs               []string `protobuf:"bytes,7,rep,name=codecs,proto3" json:"codecs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -158,7 +164,30 @@ func (m *StartSendRequest) GetDestAddr() string {
 	return ""
 }
 
+func (m *StartSendRequest) GetBitsPerSample() uint32 {
+	if m != nil {
+		return m.BitsPerSample
+	}
+	return 0
+}
+
+func (m *StartSendRequest) GetAudioFormat() uint32 {
+	if m != nil {
+		return m.AudioFormat
+	}
+	return 0
+}
+
+func (m *StartSendRequest) GetCodecs() []string {
+	if m != nil {
+		return m.Codecs
+	}
+	return nil
+}
+
 type StartSendResponse struct {
+	// codec chosen by recorder
+	Codec                string   `protobuf:"bytes,1,opt,name=codec,proto3" json:"codec,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -189,6 +218,13 @@ func (m *StartSendResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StartSendResponse proto.InternalMessageInfo
 
+func (m *StartSendResponse) GetCodec() string {
+	if m != nil {
+		return m.Codec
+	}
+	return ""
+}
+
 type StopSendRequest struct {
 	DeviceName           string   `protobuf:"bytes,1,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -259,6 +295,294 @@ func (m *StopSendResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StopSendResponse proto.InternalMessageInfo
 
+type EventsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
+func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
+func (*EventsRequest) ProtoMessage()    {}
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b063ffe85a4e6395, []int{6}
+}
+
+func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventsRequest.Unmarshal(m, b)
+}
+func (m *EventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventsRequest.Marshal(b, m, deterministic)
+}
+func (m *EventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsRequest.Merge(m, src)
+}
+func (m *EventsRequest) XXX_Size() int {
+	return xxx_messageInfo_EventsRequest.Size(m)
+}
+func (m *EventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventsRequest proto.InternalMessageInfo
+
+type Event struct {
+	// type of event: started, stopped, error
+	Type       string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	DeviceName string `protobuf:"bytes,2,opt,name=deviceName,proto3" json:"deviceName,omitempty"`
+	// message of error
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// time unix time in nanoseconds
+	Time                 int64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b063ffe85a4e6395, []int{7}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Event) GetDeviceName() string {
+	if m != nil {
+		return m.DeviceName
+	}
+	return ""
+}
+
+func (m *Event) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Event) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type ListDevicesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDevicesRequest) Reset()         { *m = ListDevicesRequest{} }
+func (m *ListDevicesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDevicesRequest) ProtoMessage()    {}
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b063ffe85a4e6395, []int{8}
+}
+
+func (m *ListDevicesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDevicesRequest.Unmarshal(m, b)
+}
+func (m *ListDevicesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDevicesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDevicesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDevicesRequest.Merge(m, src)
+}
+func (m *ListDevicesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDevicesRequest.Size(m)
+}
+func (m *ListDevicesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDevicesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDevicesRequest proto.InternalMessageInfo
+
+type ListDevicesResponse struct {
+	Devices              []*Device `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListDevicesResponse) Reset()         { *m = ListDevicesResponse{} }
+func (m *ListDevicesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDevicesResponse) ProtoMessage()    {}
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b063ffe85a4e6395, []int{9}
+}
+
+func (m *ListDevicesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDevicesResponse.Unmarshal(m, b)
+}
+func (m *ListDevicesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDevicesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDevicesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDevicesResponse.Merge(m, src)
+}
+func (m *ListDevicesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDevicesResponse.Size(m)
+}
+func (m *ListDevicesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDevicesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDevicesResponse proto.InternalMessageInfo
+
+func (m *ListDevicesResponse) GetDevices() []*Device {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+type Device struct {
+	// name of device for recording: hw:card,device
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// rates, channels and formats supported by device, empty if device is busy
+	Rates                []uint32  `protobuf:"varint,3,rep,packed,name=rates,proto3" json:"rates,omitempty"`
+	Channels             []uint32  `protobuf:"varint,4,rep,packed,name=channels,proto3" json:"channels,omitempty"`
+	Formats              []*Format `protobuf:"bytes,5,rep,name=formats,proto3" json:"formats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Device) Reset()         { *m = Device{} }
+func (m *Device) String() string { return proto.CompactTextString(m) }
+func (*Device) ProtoMessage()    {}
+func (*Device) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b063ffe85a4e6395, []int{10}
+}
+
+func (m *Device) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Device.Unmarshal(m, b)
+}
+func (m *Device) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Device.Marshal(b, m, deterministic)
+}
+func (m *Device) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Device.Merge(m, src)
+}
+func (m *Device) XXX_Size() int {
+	return xxx_messageInfo_Device.Size(m)
+}
+func (m *Device) XXX_DiscardUnknown() {
+	xxx_messageInfo_Device.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Device proto.InternalMessageInfo
+
+func (m *Device) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Device) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Device) GetRates() []uint32 {
+	if m != nil {
+		return m.Rates
+	}
+	return nil
+}
+
+func (m *Device) GetChannels() []uint32 {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Device) GetFormats() []*Format {
+	if m != nil {
+		return m.Formats
+	}
+	return nil
+}
+
+type Format struct {
+	BitsPerSample uint32 `protobuf:"varint,1,opt,name=bitsPerSample,proto3" json:"bitsPerSample,omitempty"`
+	// audioFormat as in wav header: 1 - PCM, 3 - IEEE float
+	AudioFormat          uint32   `protobuf:"varint,2,opt,name=audioFormat,proto3" json:"audioFormat,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Format) Reset()         { *m = Format{} }
+func (m *Format) String() string { return proto.CompactTextString(m) }
+func (*Format) ProtoMessage()    {}
+func (*Format) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b063ffe85a4e6395, []int{11}
+}
+
+func (m *Format) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Format.Unmarshal(m, b)
+}
+func (m *Format) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Format.Marshal(b, m, deterministic)
+}
+func (m *Format) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Format.Merge(m, src)
+}
+func (m *Format) XXX_Size() int {
+	return xxx_messageInfo_Format.Size(m)
+}
+func (m *Format) XXX_DiscardUnknown() {
+	xxx_messageInfo_Format.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Format proto.InternalMessageInfo
+
+func (m *Format) GetBitsPerSample() uint32 {
+	if m != nil {
+		return m.BitsPerSample
+	}
+	return 0
+}
+
+func (m *Format) GetAudioFormat() uint32 {
+	if m != nil {
+		return m.AudioFormat
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StateRequest)(nil), "recorder.StateRequest")
 	proto.RegisterType((*StateResponse)(nil), "recorder.StateResponse")
@@ -266,29 +590,50 @@ func init() {
 	proto.RegisterType((*StartSendResponse)(nil), "recorder.StartSendResponse")
 	proto.RegisterType((*StopSendRequest)(nil), "recorder.StopSendRequest")
 	proto.RegisterType((*StopSendResponse)(nil), "recorder.StopSendResponse")
+	proto.RegisterType((*EventsRequest)(nil), "recorder.EventsRequest")
+	proto.RegisterType((*Event)(nil), "recorder.Event")
+	proto.RegisterType((*ListDevicesRequest)(nil), "recorder.ListDevicesRequest")
+	proto.RegisterType((*ListDevicesResponse)(nil), "recorder.ListDevicesResponse")
+	proto.RegisterType((*Device)(nil), "recorder.Device")
+	proto.RegisterType((*Format)(nil), "recorder.Format")
 }
 
 func init() { proto.RegisterFile("recorder.proto", fileDescriptor_b063ffe85a4e6395) }
 
 var fileDescriptor_b063ffe85a4e6395 = []byte{
-	// 267 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x51, 0xbd, 0x4e, 0xf4, 0x30,
-	0x10, 0xfc, 0xf2, 0x5d, 0x80, 0xdc, 0x8a, 0x3b, 0x60, 0x91, 0xc0, 0x18, 0x09, 0x45, 0xae, 0x42,
-	0x73, 0x12, 0xd0, 0xd1, 0x20, 0x78, 0x00, 0x0a, 0xe7, 0x09, 0x4c, 0xbc, 0x12, 0x48, 0x10, 0x07,
-	0x7b, 0xa1, 0xe4, 0x05, 0x79, 0x29, 0x14, 0xe7, 0x47, 0x89, 0xb8, 0x86, 0x2e, 0xb3, 0x3b, 0x33,
-	0x99, 0x1d, 0xc3, 0xda, 0x53, 0xe5, 0xbc, 0x25, 0xbf, 0x69, 0xbc, 0x63, 0x87, 0xd9, 0x80, 0xd5,
-	0x1a, 0xf6, 0x4b, 0x36, 0x4c, 0x9a, 0xde, 0x3f, 0x28, 0xb0, 0xba, 0x84, 0x55, 0x8f, 0x43, 0xe3,
-	0xea, 0x40, 0x28, 0x60, 0xcf, 0xd2, 0xe7, 0x4b, 0x45, 0x41, 0x24, 0xf9, 0xa2, 0x58, 0xea, 0x01,
-	0xaa, 0x2f, 0x38, 0x2c, 0xd9, 0x78, 0x2e, 0xa9, 0xb6, 0xbd, 0x1c, 0x2f, 0x00, 0xba, 0xf5, 0xa3,
-	0x79, 0x23, 0x91, 0xe4, 0x49, 0xb1, 0xd4, 0x93, 0x09, 0x4a, 0xc8, 0xaa, 0x67, 0x53, 0xd7, 0xf4,
-	0x1a, 0xc4, 0xff, 0x3c, 0x29, 0x56, 0x7a, 0xc4, 0x88, 0x90, 0x7a, 0xc3, 0x24, 0x16, 0x71, 0x1e,
-	0xbf, 0x5b, 0xbe, 0xa5, 0xc0, 0xf7, 0xd6, 0x7a, 0x91, 0x46, 0xb7, 0x11, 0xab, 0x63, 0x38, 0x9a,
-	0xfc, 0xbf, 0x8b, 0xab, 0xae, 0xe0, 0xa0, 0x64, 0xd7, 0xfc, 0x21, 0x93, 0xc2, 0xf6, 0x8e, 0x41,
-	0xd2, 0xd9, 0x5c, 0x7f, 0x27, 0x90, 0xe9, 0xbe, 0x23, 0xbc, 0x85, 0x9d, 0xd8, 0x09, 0x9e, 0x6c,
-	0xc6, 0x1e, 0xa7, 0xa5, 0xc9, 0xd3, 0x5f, 0xf3, 0x3e, 0xcd, 0x3f, 0x7c, 0x88, 0x5a, 0xcf, 0x28,
-	0x67, 0x9c, 0x59, 0x6b, 0xf2, 0x7c, 0xeb, 0x6e, 0xf4, 0xb8, 0x83, 0xb4, 0x0d, 0x88, 0x67, 0x53,
-	0xda, 0xec, 0x46, 0x29, 0xb7, 0xad, 0x06, 0x83, 0xa7, 0xdd, 0xf8, 0xea, 0x37, 0x3f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x72, 0xe9, 0xdb, 0xbe, 0x07, 0x02, 0x00, 0x00,
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4b, 0x6e, 0xd4, 0x40,
+	0x10, 0x8d, 0xc7, 0x63, 0xcf, 0x4c, 0x0d, 0xce, 0x0c, 0x45, 0x14, 0x1a, 0xf3, 0x91, 0xd5, 0x62,
+	0x31, 0x61, 0x11, 0x41, 0x90, 0x58, 0xb0, 0x41, 0x41, 0xc0, 0x2a, 0x42, 0x91, 0x7d, 0x02, 0xc7,
+	0x2e, 0xc0, 0x52, 0xfc, 0xa1, 0xbb, 0x13, 0x89, 0xbb, 0x70, 0x29, 0x2e, 0xc0, 0x59, 0x90, 0xbb,
+	0xfd, 0x1d, 0x07, 0x09, 0x76, 0xfd, 0x5e, 0x55, 0x97, 0xab, 0x5e, 0xbd, 0x36, 0x1c, 0x0a, 0x4a,
+	0x4a, 0x91, 0x92, 0x38, 0xad, 0x44, 0xa9, 0x4a, 0x5c, 0xb6, 0x98, 0x1f, 0xc2, 0xbd, 0x48, 0xc5,
+	0x8a, 0x42, 0xfa, 0x7e, 0x43, 0x52, 0xf1, 0x13, 0xf0, 0x1a, 0x2c, 0xab, 0xb2, 0x90, 0x84, 0x0c,
+	0x16, 0x29, 0xdd, 0x66, 0x09, 0x49, 0x66, 0x05, 0xf6, 0x6e, 0x15, 0xb6, 0x90, 0xff, 0xb6, 0x60,
+	0x1b, 0xa9, 0x58, 0xa8, 0x88, 0x8a, 0xb4, 0xb9, 0x8f, 0xcf, 0x00, 0x4c, 0xfc, 0x73, 0x9c, 0x13,
+	0xb3, 0x02, 0x6b, 0xb7, 0x0a, 0x07, 0x0c, 0xfa, 0xb0, 0x4c, 0xbe, 0xc5, 0x45, 0x41, 0xd7, 0x92,
+	0xcd, 0x02, 0x6b, 0xe7, 0x85, 0x1d, 0x46, 0x84, 0xb9, 0x88, 0x15, 0x31, 0x5b, 0xf3, 0xfa, 0x5c,
+	0xe7, 0xa7, 0x24, 0xd5, 0x79, 0x9a, 0x0a, 0x36, 0xd7, 0xd5, 0x3a, 0x8c, 0xcf, 0xc1, 0xbb, 0xca,
+	0x94, 0xbc, 0x24, 0x11, 0xc5, 0x79, 0x75, 0x4d, 0xcc, 0xd1, 0x17, 0xc7, 0x24, 0x06, 0xb0, 0x8e,
+	0x6f, 0xd2, 0xac, 0xfc, 0x54, 0x8a, 0x3c, 0x56, 0xcc, 0xd5, 0x39, 0x43, 0x0a, 0x8f, 0xc1, 0x4d,
+	0xca, 0x94, 0x12, 0xc9, 0x16, 0x7a, 0xc2, 0x06, 0xf1, 0x13, 0xb8, 0x3f, 0x98, 0xaf, 0xd1, 0xe3,
+	0x08, 0x1c, 0x1d, 0x6e, 0x66, 0x33, 0x80, 0xbf, 0x82, 0x4d, 0xa4, 0xca, 0xea, 0x3f, 0x94, 0xe0,
+	0x08, 0xdb, 0xfe, 0x8a, 0x29, 0xce, 0x37, 0xe0, 0x7d, 0xbc, 0xa5, 0x42, 0xc9, 0x76, 0x1d, 0x19,
+	0x38, 0x9a, 0xa8, 0xb5, 0x51, 0x3f, 0xaa, 0xb6, 0x8e, 0x3e, 0xef, 0x7d, 0x61, 0x36, 0xd1, 0x9a,
+	0xc1, 0x22, 0x27, 0x29, 0xe3, 0xaf, 0x46, 0xd2, 0x55, 0xd8, 0x42, 0x5d, 0x2d, 0xcb, 0x49, 0x2b,
+	0x6a, 0x87, 0xfa, 0xcc, 0x8f, 0x00, 0x2f, 0x32, 0xa9, 0x3e, 0x98, 0xed, 0xb6, 0x0d, 0x9c, 0xc3,
+	0x83, 0x11, 0xdb, 0xa8, 0xf0, 0x62, 0xec, 0x8a, 0xf5, 0xd9, 0xf6, 0xb4, 0xb3, 0x98, 0xc9, 0xed,
+	0x7d, 0xf2, 0xd3, 0x02, 0xd7, 0x70, 0xf5, 0x77, 0x8b, 0x5e, 0x0d, 0x7d, 0xae, 0xf7, 0x93, 0x92,
+	0x4c, 0x44, 0x56, 0xa9, 0xac, 0x2c, 0x9a, 0x31, 0x86, 0x54, 0x2d, 0x79, 0xed, 0x05, 0xc9, 0xec,
+	0xc0, 0xde, 0x79, 0xa1, 0x01, 0x23, 0x27, 0xcd, 0x75, 0xa0, 0xc3, 0x75, 0x7b, 0x5f, 0xf4, 0x6e,
+	0x25, 0x73, 0xf6, 0xdb, 0x33, 0x4b, 0x0f, 0xdb, 0x04, 0x7e, 0x09, 0xae, 0xa1, 0xa6, 0x7e, 0xb2,
+	0xfe, 0xc1, 0x4f, 0xb3, 0x89, 0x9f, 0xce, 0x7e, 0xcd, 0x60, 0x19, 0x36, 0x9f, 0xc3, 0xb7, 0xe0,
+	0xe8, 0x07, 0x85, 0xc7, 0x7d, 0x0b, 0xc3, 0x17, 0xe7, 0x3f, 0x9c, 0xf0, 0x8d, 0x19, 0x0e, 0xf0,
+	0xbd, 0xbe, 0x2b, 0x14, 0xfa, 0xa3, 0x9c, 0xd1, 0x8b, 0xf3, 0x1f, 0xdf, 0x19, 0xeb, 0x6a, 0xbc,
+	0x83, 0x79, 0x6d, 0x33, 0x7c, 0x34, 0x4c, 0x1b, 0x39, 0xd5, 0xf7, 0xef, 0x0a, 0x75, 0x05, 0xde,
+	0x80, 0x6b, 0x3c, 0x89, 0x83, 0x4e, 0x47, 0x2e, 0xf5, 0x37, 0x7b, 0x01, 0x7e, 0xf0, 0xd2, 0xc2,
+	0x0b, 0x58, 0x0f, 0x9c, 0x83, 0x4f, 0xfa, 0x9c, 0xa9, 0xcd, 0xfc, 0xa7, 0x7f, 0x89, 0xb6, 0x5d,
+	0x5c, 0xb9, 0xfa, 0xc7, 0xf5, 0xfa, 0xcf, 0x00, 0x25, 0xc5, 0x2b, 0xda, 0xca, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -309,6 +654,10 @@ type RecorderClient interface {
 	Start(ctx context.Context, in *StartSendRequest, opts ...grpc.CallOption) (*StartSendResponse, error)
 	// Stop record from deviceName
 	Stop(ctx context.Context, in *StopSendRequest, opts ...grpc.CallOption) (*StopSendResponse, error)
+	// Events on devices of recorder from start of streaming
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Recorder_EventsClient, error)
+	// ListDevices return capture pcm devices of recorder with supported rates, channels and formats
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
 }
 
 type recorderClient struct {
@@ -346,6 +695,47 @@ func (c *recorderClient) Stop(ctx context.Context, in *StopSendRequest, opts ...
 	return out, nil
 }
 
+func (c *recorderClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (Recorder_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Recorder_serviceDesc.Streams[0], "/recorder.Recorder/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recorderEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Recorder_EventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type recorderEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *recorderEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recorderClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, "/recorder.Recorder/ListDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RecorderServer is the server API for Recorder service.
 type RecorderServer interface {
 	// State return receiving ports, storages and busy device
@@ -354,6 +744,10 @@ type RecorderServer interface {
 	Start(context.Context, *StartSendRequest) (*StartSendResponse, error)
 	// Stop record from deviceName
 	Stop(context.Context, *StopSendRequest) (*StopSendResponse, error)
+	// Events on devices of recorder from start of streaming
+	Events(*EventsRequest, Recorder_EventsServer) error
+	// ListDevices return capture pcm devices of recorder with supported rates, channels and formats
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
 }
 
 // UnimplementedRecorderServer can be embedded to have forward compatible implementations.
@@ -369,6 +763,12 @@ func (*UnimplementedRecorderServer) Start(ctx context.Context, req *StartSendReq
 func (*UnimplementedRecorderServer) Stop(ctx context.Context, req *StopSendRequest) (*StopSendResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (*UnimplementedRecorderServer) Events(req *EventsRequest, srv Recorder_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (*UnimplementedRecorderServer) ListDevices(ctx context.Context, req *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
 
 func RegisterRecorderServer(s *grpc.Server, srv RecorderServer) {
 	s.RegisterService(&_Recorder_serviceDesc, srv)
@@ -428,6 +828,45 @@ func _Recorder_Stop_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Recorder_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecorderServer).Events(m, &recorderEventsServer{stream})
+}
+
+type Recorder_EventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type recorderEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *recorderEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Recorder_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecorderServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.Recorder/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecorderServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Recorder_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "recorder.Recorder",
 	HandlerType: (*RecorderServer)(nil),
@@ -444,7 +883,17 @@ var _Recorder_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _Recorder_Stop_Handler,
 		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _Recorder_ListDevices_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _Recorder_Events_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "recorder.proto",
 }
@@ -0,0 +1,239 @@
+package resampler
+
+import (
+	"io"
+	"math"
+)
+
+const (
+	// framesPerRead count of frames read from source at once
+	framesPerRead = 4096
+	// halfTaps half length of interpolation kernel on output rate
+	halfTaps = 8
+)
+
+type converter interface {
+	ToFloat64(src []byte, bitsPerSample, audioFormat int) []float64
+	FromFloat64(src []float64, bitsPerSample, audioFormat int) []byte
+}
+
+type reader struct {
+	converter converter
+	r         io.Reader
+
+	channels, dstChannels            int
+	bitsPerSample, audioFormat       int
+	dstBitsPerSample, dstAudioFormat int
+	frameSize                        int
+
+	// step position in input frames per one output frame
+	step float64
+	// cutoff frequency of lowpass filter relative to input Nyquist frequency
+	cutoff float64
+	// width half length of interpolation kernel in input frames
+	width int
+
+	raw    []byte
+	rest   int
+	frames [][]float64
+	pos    float64
+	eof    bool
+
+	out []byte
+}
+
+// Read converted signal
+func (r *reader) Read(p []byte) (n int, err error) {
+	for len(r.out) == 0 {
+		if r.eof && r.pos >= float64(len(r.frames)) {
+			return 0, io.EOF
+		}
+		if err = r.fill(); err != nil {
+			return
+		}
+		r.out = r.converter.FromFloat64(r.resample(), r.dstBitsPerSample, r.dstAudioFormat)
+	}
+	n = copy(p, r.out)
+	r.out = r.out[n:]
+	return
+}
+
+// fill read next frames from source and mix channels
+func (r *reader) fill() error {
+	if r.eof {
+		return nil
+	}
+	l, err := io.ReadAtLeast(r.r, r.raw[r.rest:], r.frameSize-r.rest)
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		r.eof = true
+	default:
+		return err
+	}
+	l += r.rest
+	size := l - l%r.frameSize
+	samples := r.converter.ToFloat64(r.raw[:size], r.bitsPerSample, r.audioFormat)
+	r.rest = copy(r.raw, r.raw[size:l])
+
+	for i := 0; i+r.channels <= len(samples); i += r.channels {
+		r.frames = append(r.frames, mix(samples[i:i+r.channels], r.dstChannels))
+	}
+	return nil
+}
+
+// resample return interleaved output frames which can be calculated from read frames
+func (r *reader) resample() (samples []float64) {
+	if r.step == 1 {
+		for _, frame := range r.frames {
+			samples = append(samples, frame...)
+		}
+		r.frames = r.frames[:0]
+		r.pos = 0
+		return
+	}
+
+	for {
+		center := int(math.Floor(r.pos))
+		if !r.eof && center+r.width >= len(r.frames) {
+			break
+		}
+		if r.eof && r.pos >= float64(len(r.frames)) {
+			break
+		}
+		samples = append(samples, r.interpolate(center)...)
+		r.pos += r.step
+	}
+
+	// frames before kernel of next output frame are not needed anymore
+	if drop := int(math.Floor(r.pos)) - r.width; drop > 0 {
+		if drop > len(r.frames) {
+			drop = len(r.frames)
+		}
+		r.frames = append(r.frames[:0], r.frames[drop:]...)
+		r.pos -= float64(drop)
+	}
+	return
+}
+
+// interpolate output frame on r.pos with windowed sinc kernel, frames out of signal are silence
+func (r *reader) interpolate(center int) []float64 {
+	frame := make([]float64, r.dstChannels)
+	var sum float64
+	for i := center - r.width + 1; i <= center+r.width; i++ {
+		x := r.pos - float64(i)
+		weight := r.kernel(x)
+		sum += weight
+		if i < 0 || i >= len(r.frames) {
+			continue
+		}
+		for c := range frame {
+			frame[c] += weight * r.frames[i][c]
+		}
+	}
+	if sum != 0 {
+		for c := range frame {
+			frame[c] /= sum
+		}
+	}
+	return frame
+}
+
+// kernel lowpass sinc with Hann window
+func (r *reader) kernel(x float64) float64 {
+	w := float64(r.width)
+	if math.Abs(x) >= w {
+		return 0
+	}
+	window := 0.5 + 0.5*math.Cos(math.Pi*x/w)
+	return r.cutoff * sinc(r.cutoff*x) * window
+}
+
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// mix input frame to dstChannels.
+// Every output channel is average of input channels with the same number modulo dstChannels,
+// if input has less channels they are repeated.
+func mix(frame []float64, dstChannels int) []float64 {
+	out := make([]float64, dstChannels)
+	if len(frame) <= dstChannels {
+		for c := range out {
+			out[c] = frame[c%len(frame)]
+		}
+		return out
+	}
+
+	counts := make([]int, dstChannels)
+	for c, sample := range frame {
+		out[c%dstChannels] += sample
+		counts[c%dstChannels]++
+	}
+	for c := range out {
+		out[c] /= float64(counts[c])
+	}
+	return out
+}
+
+// Resampler convert sample rate, channels and format of samples of audio signal
+type Resampler struct {
+	converter converter
+}
+
+// Reader return reader of signal from r with channels and rate converted to dstChannels and dstRate.
+// Format of samples is not changed, 0 dstChannels or dstRate keep value of source.
+func (rs *Resampler) Reader(r io.Reader, channels, rate, bitsPerSample, audioFormat, dstChannels, dstRate int) io.Reader {
+	return rs.Convert(r, channels, rate, bitsPerSample, audioFormat, dstChannels, dstRate, bitsPerSample, audioFormat)
+}
+
+// Convert return reader of signal from r with channels, rate and format of samples converted to dst values.
+// 0 dst value keeps value of source.
+func (rs *Resampler) Convert(r io.Reader, channels, rate, bitsPerSample, audioFormat, dstChannels, dstRate, dstBitsPerSample, dstAudioFormat int) io.Reader {
+	if dstChannels == 0 {
+		dstChannels = channels
+	}
+	if dstRate == 0 {
+		dstRate = rate
+	}
+	if dstBitsPerSample == 0 {
+		dstBitsPerSample = bitsPerSample
+	}
+	if dstAudioFormat == 0 {
+		dstAudioFormat = audioFormat
+	}
+	if channels == dstChannels && rate == dstRate && bitsPerSample == dstBitsPerSample && audioFormat == dstAudioFormat {
+		return r
+	}
+
+	frameSize := channels * bitsPerSample / 8
+	cutoff := math.Min(1, float64(dstRate)/float64(rate))
+	return &reader{
+		converter: rs.converter,
+		r:         r,
+
+		channels:         channels,
+		dstChannels:      dstChannels,
+		bitsPerSample:    bitsPerSample,
+		audioFormat:      audioFormat,
+		dstBitsPerSample: dstBitsPerSample,
+		dstAudioFormat:   dstAudioFormat,
+		frameSize:        frameSize,
+
+		step:   float64(rate) / float64(dstRate),
+		cutoff: cutoff,
+		width:  int(math.Ceil(halfTaps / cutoff)),
+
+		raw: make([]byte, framesPerRead*frameSize),
+	}
+}
+
+// NewResampler ...
+func NewResampler(converter converter) *Resampler {
+	return &Resampler{
+		converter: converter,
+	}
+}
@@ -0,0 +1,120 @@
+package segment
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// placeholders in template of file name
+	numberPlaceholder = "{n}"
+	timePlaceholder   = "{time}"
+	timeLayout        = "20060102-150405"
+)
+
+type open func(fileName string) (io.WriteCloser, error)
+
+// Writer of audio signal in segments, next file is opened when segment reaches duration or size.
+// Segments are split on sample frames, each closed segment is a whole file.
+type Writer struct {
+	open     open
+	template string
+	// maxSize of samples in segment, 0 - one file without rotation
+	maxSize int64
+
+	number int
+	size   int64
+	wc     io.WriteCloser
+}
+
+// Write samples to current segment, segment is closed and next one is opened when it is full
+func (w *Writer) Write(data []byte) (n int, err error) {
+	for len(data) != 0 {
+		if w.wc == nil {
+			if err = w.next(); err != nil {
+				return
+			}
+		}
+		l := int64(len(data))
+		if w.maxSize != 0 && w.size+l > w.maxSize {
+			l = w.maxSize - w.size
+		}
+		var written int
+		written, err = w.wc.Write(data[:l])
+		n += written
+		w.size += int64(written)
+		if err != nil {
+			return
+		}
+		data = data[l:]
+
+		if w.maxSize != 0 && w.size >= w.maxSize {
+			err = w.wc.Close()
+			w.wc = nil
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// Close current segment
+func (w *Writer) Close() (err error) {
+	if w.wc != nil {
+		err = w.wc.Close()
+		w.wc = nil
+	}
+	return
+}
+
+func (w *Writer) next() (err error) {
+	w.number++
+	if w.wc, err = w.open(w.fileName(time.Now())); err != nil {
+		return
+	}
+	w.size = 0
+	return
+}
+
+// fileName of segment from template
+func (w *Writer) fileName(now time.Time) string {
+	return strings.NewReplacer(
+		numberPlaceholder, strconv.Itoa(w.number),
+		timePlaceholder, now.Format(timeLayout),
+	).Replace(w.template)
+}
+
+// NewWriter segments are named by template with placeholders {n} - number of segment from 1 and {time} - start of segment.
+// Number is added before extension if segments are rotated and template has no placeholders.
+// byteRate and frameSize are bytes of samples per second and per sample frame,
+// duration and size limit segment, zero disables the limit.
+// The first segment is opened at once to check format of file.
+func NewWriter(open open, template string, frameSize, byteRate int, duration time.Duration, size int64) (w *Writer, err error) {
+	maxSize := size
+	if d := int64(duration) * int64(byteRate) / int64(time.Second); d != 0 && (maxSize == 0 || d < maxSize) {
+		maxSize = d
+	}
+	if frameSize > 0 && maxSize != 0 {
+		maxSize -= maxSize % int64(frameSize)
+		if maxSize == 0 {
+			maxSize = int64(frameSize)
+		}
+	}
+
+	if maxSize != 0 && !strings.Contains(template, numberPlaceholder) && !strings.Contains(template, timePlaceholder) {
+		ext := filepath.Ext(template)
+		template = strings.TrimSuffix(template, ext) + "-" + numberPlaceholder + ext
+	}
+
+	w = &Writer{
+		open:     open,
+		template: template,
+		maxSize:  maxSize,
+	}
+	err = w.next()
+	return
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"audio-service/pkg/event"
+)
+
+const (
+	// eventsBuffSize events buffered for each subscriber of server
+	eventsBuffSize = 256
+	// eventsReconnectDelay of streaming of events from player or recorder after error
+	eventsReconnectDelay = 5 * time.Second
+	// eventsMaxFailures in a row after which streaming is stopped until next request to player or recorder
+	eventsMaxFailures = 12
+)
+
+// eventSource player or recorder streaming events
+type eventSource func(ctx context.Context, ip string) (<-chan event.Event, error)
+
+// Events subscribe on events of players and recorders controlled by server, events are closed by cancel.
+// Server streams events from player or recorder after the first request to it.
+func (s *server) Events(ctx context.Context) (events <-chan event.Event, cancel func()) {
+	return s.events.Subscribe()
+}
+
+// watchPlayer stream events of player with playerIP to subscribers of server
+func (s *server) watchPlayer(playerIP string) {
+	s.watch(event.Player, playerIP, s.player.Events)
+}
+
+// watchRecorder stream events of recorder with recorderIP to subscribers of server
+func (s *server) watchRecorder(recorderIP string) {
+	s.watch(event.Recorder, recorderIP, s.recorder.Events)
+}
+
+// watch start streaming of events from source with ip once, streaming is reconnected after error.
+// Streaming is stopped with context of server or after eventsMaxFailures connections without events.
+func (s *server) watch(source, ip string, events eventSource) {
+	s.mutexWatched.Lock()
+	defer s.mutexWatched.Unlock()
+
+	key := source + "/" + ip
+	if _, isExist := s.watched[key]; isExist || s.ctx.Err() != nil {
+		return
+	}
+	s.watched[key] = struct{}{}
+
+	go func() {
+		defer s.unwatch(key)
+
+		for failures := 0; failures < eventsMaxFailures; {
+			failures++
+			if c, err := events(s.ctx, ip); err == nil {
+				for e := range c {
+					failures = 0
+					s.events.Publish(e)
+				}
+			}
+
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(eventsReconnectDelay):
+			}
+		}
+	}()
+}
+
+// unwatch source with key, it is watched again on next request to it
+func (s *server) unwatch(key string) {
+	s.mutexWatched.Lock()
+	defer s.mutexWatched.Unlock()
+
+	delete(s.watched, key)
+}
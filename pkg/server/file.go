@@ -0,0 +1,66 @@
+package server
+
+import (
+	"time"
+)
+
+// fileSession file playing on player, keeps data of file to pause, resume and seek
+type fileSession struct {
+	data []byte
+
+	channels, rate             uint32
+	bitsPerSample, audioFormat uint32
+	dstChannels, dstRate       uint32
+
+	playerDeviceName string
+	uuid             string
+
+	// offset in data from which sending was started or where file was paused
+	offset    int
+	startTime time.Time
+	paused    bool
+	// startAt time of synchronized start of playing in group, zero - start immediately
+	startAt time.Time
+}
+
+func (f *fileSession) frameSize() int {
+	return int(f.channels * f.bitsPerSample / 8)
+}
+
+func (f *fileSession) byteRate() int {
+	return f.frameSize() * int(f.rate)
+}
+
+// played return offset in data played on player at now.
+// Player plays data in real time after start, so offset is calculated from elapsed time,
+// nothing is played before start in group in future.
+func (f *fileSession) played(now time.Time) int {
+	elapsed := now.Sub(f.startTime)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	offset := f.offset + int(elapsed.Seconds()*float64(f.byteRate()))
+	offset -= offset % f.frameSize()
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(f.data) {
+		offset = len(f.data)
+	}
+	return offset
+}
+
+// position return time from start of file for offset in data
+func (f *fileSession) position(offset int) time.Duration {
+	return time.Duration(float64(offset) / float64(f.byteRate()) * float64(time.Second))
+}
+
+// byteOffset return offset of sample frame in data for position from start of file
+func (f *fileSession) byteOffset(position time.Duration) (offset int, err error) {
+	offset = int(position.Seconds() * float64(f.byteRate()))
+	offset -= offset % f.frameSize()
+	if position < 0 || offset > len(f.data) {
+		err = ErrWrongPosition
+	}
+	return
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPlayed(t *testing.T) {
+	now := time.Now()
+	// one second of 16 bits stereo at 1000 Hz
+	f := &fileSession{
+		data:          make([]byte, 4000),
+		channels:      2,
+		rate:          1000,
+		bitsPerSample: 16,
+	}
+
+	tests := []struct {
+		name      string
+		offset    int
+		startTime time.Time
+		want      int
+	}{
+		{name: "playing", offset: 400, startTime: now.Add(-100 * time.Millisecond), want: 800},
+		{name: "ended", offset: 400, startTime: now.Add(-2 * time.Second), want: 4000},
+		// pause before start in group
+		{name: "before start", offset: 400, startTime: now.Add(time.Second), want: 400},
+		{name: "before start of file", startTime: now.Add(time.Second), want: 0},
+	}
+	for _, tt := range tests {
+		f.offset, f.startTime = tt.offset, tt.startTime
+		if played := f.played(now); played != tt.want {
+			t.Errorf("%s: played %d, want %d", tt.name, played, tt.want)
+		}
+	}
+}
@@ -8,12 +8,54 @@ import (
 )
 
 const (
-	protocol = "http"
+	protocol     = "http"
+	headerAPIKey = "X-API-Key"
 
-	methodFilePlay = http.MethodPost
-	uriFilePlay    = "/player/file/play"
-	methodFileStop = http.MethodPost
-	uriFileStop    = "/player/file/stop"
+	methodFilePlay   = http.MethodPost
+	uriFilePlay      = "/player/file/play"
+	methodFileStop   = http.MethodPost
+	uriFileStop      = "/player/file/stop"
+	methodFilePause  = http.MethodPost
+	uriFilePause     = "/player/file/pause"
+	methodFileResume = http.MethodPost
+	uriFileResume    = "/player/file/resume"
+	methodFileSeek   = http.MethodPost
+	uriFileSeek      = "/player/file/seek"
+
+	methodGroupPlay = http.MethodPost
+	uriGroupPlay    = "/player/group/play"
+	methodGroupStop = http.MethodPost
+	uriGroupStop    = "/player/group/stop"
+
+	methodMulticastPlay = http.MethodPost
+	uriMulticastPlay    = "/player/multicast/play"
+	methodMulticastStop = http.MethodPost
+	uriMulticastStop    = "/player/multicast/stop"
+
+	methodPlaylistEnqueue = http.MethodPost
+	uriPlaylistEnqueue    = "/player/playlist/enqueue"
+	methodPlaylistSkip    = http.MethodPost
+	uriPlaylistSkip       = "/player/playlist/skip"
+	methodPlaylistClear   = http.MethodPost
+	uriPlaylistClear      = "/player/playlist/clear"
+	methodPlaylistMode    = http.MethodPost
+	uriPlaylistMode       = "/player/playlist/mode"
+	methodPlaylistState   = http.MethodGet
+	uriPlaylistState      = "/player/playlist/state"
+	methodPlaylistStop    = http.MethodPost
+	uriPlaylistStop       = "/player/playlist/stop"
+
+	methodScheduleAdd    = http.MethodPost
+	uriScheduleAdd       = "/player/schedule/add"
+	methodScheduleRemove = http.MethodPost
+	uriScheduleRemove    = "/player/schedule/remove"
+	methodScheduleList   = http.MethodGet
+	uriScheduleList      = "/player/schedule/list"
+
+	methodMixPlay = http.MethodPost
+	uriMixPlay    = "/player/mix/play"
+	methodMixStop = http.MethodPost
+	uriMixStop    = "/player/mix/stop"
 
 	methodPlayerState        = http.MethodGet
 	uriPlayerState           = "/player/state"
@@ -25,9 +67,20 @@ const (
 	uriPlayerPlay            = "/player/play"
 	methodPlayerStop         = http.MethodPost
 	uriPlayerStop            = "/player/stop"
+	methodPlayerRewind       = http.MethodPost
+	uriPlayerRewind          = "/player/rewind"
+	methodPlayerReplay       = http.MethodPost
+	uriPlayerReplay          = "/player/replay"
 	methodPlayerClearStorage = http.MethodPost
 	uriPlayerClearStorage    = "/player/clearstorage"
 
+	methodPlayerSetVolume   = http.MethodPost
+	uriPlayerSetVolume      = "/player/volume"
+	methodPlayerMute        = http.MethodPost
+	uriPlayerMute           = "/player/mute"
+	methodPlayerListDevices = http.MethodGet
+	uriPlayerListDevices    = "/player/devices"
+
 	methodStartFileRecording = http.MethodPost
 	uriStartFileRecording    = "/recoder/file/start"
 	methodStopFileRecording  = http.MethodPost
@@ -37,35 +90,66 @@ const (
 	methodStopFromRecorder   = http.MethodPost
 	uriStopFromRecorder      = "/recoder/player/stop"
 
-	methodRecorderState = http.MethodGet
-	uriRecorderState    = "/recorder/state"
-	methodRecorderStart = http.MethodPost
-	uriRecorderStart    = "/recoder/start"
-	methodRecorderStop  = http.MethodPost
-	uriRecorderStop     = "/recoder/stop"
+	methodRecorderState       = http.MethodGet
+	uriRecorderState          = "/recorder/state"
+	methodRecorderStart       = http.MethodPost
+	uriRecorderStart          = "/recoder/start"
+	methodRecorderStop        = http.MethodPost
+	uriRecorderStop           = "/recoder/stop"
+	methodRecorderListDevices = http.MethodGet
+	uriRecorderListDevices    = "/recorder/devices"
+
+	methodEvents = http.MethodGet
+	uriEvents    = "/events"
 )
 
-// NewClient return http client
-func NewClient(serverAddr string) Client {
+// NewClient return http client, apiKey is sent in requests if it is not empty
+func NewClient(serverAddr, apiKey string) Client {
 	if !strings.HasPrefix(serverAddr, "http") {
 		serverAddr = protocol + "://" + serverAddr
 	}
 	return &client{
-		cli:                         &fasthttp.Client{},
-		filePlayTransport:           NewFilePlayTransport(methodFilePlay, serverAddr+uriFilePlay),
-		fileStopTransport:           NewFileStopTransport(methodFileStop, serverAddr+uriFileStop),
-		playerStateTransport:        NewPlayerStateTransport(methodPlayerState, serverAddr+uriPlayerState),
-		playerReceiveStartTransport: NewPlayerReceiveStartTransport(methodPlayerReceiveStart, serverAddr+uriPlayerState),
-		playerReceiveStopTransport:  NewPlayerReceiveStopTransport(methodPlayerReceiveStop, serverAddr+uriPlayerReceiveStop),
-		playerPlayTransport:         NewPlayerPlayTransport(methodPlayerPlay, serverAddr+uriPlayerPlay),
-		playerStopTransport:         NewPlayerStopTransport(methodPlayerStop, serverAddr+uriPlayerStop),
-		playerClearStorageTransport: NewPlayerClearStorageTransport(methodPlayerClearStorage, serverAddr+uriPlayerClearStorage),
-		startFileRecordingTransport: NewStartFileRecordingTransport(methodStartFileRecording, serverAddr+uriStartFileRecording),
-		stopFileRecordingTransport:  NewStopFileRecordingTransport(methodStopFileRecording, serverAddr+uriStopFileRecording),
-		playFromRecorderTransport:   NewPlayFromRecorderTransport(methodPlayFromRecorder, serverAddr+uriPlayFromRecorder),
-		stopFromRecorderTransport:   NewStopFromRecorderTransport(methodStopFromRecorder, serverAddr+uriStopFromRecorder),
-		recorderStateTransport:      NewRecorderStateTransport(methodRecorderState, serverAddr+uriRecorderState),
-		recorderStartTransport:      NewRecorderStartTransport(methodRecorderStart, serverAddr+uriRecorderStart),
-		recorderStopTransport:       NewRecorderStopTransport(methodRecorderStop, serverAddr+uriRecorderStop),
+		cli:                          &fasthttp.Client{},
+		apiKey:                       apiKey,
+		filePlayTransport:            NewFilePlayTransport(methodFilePlay, serverAddr+uriFilePlay),
+		fileStopTransport:            NewFileStopTransport(methodFileStop, serverAddr+uriFileStop),
+		filePauseTransport:           NewFilePauseTransport(methodFilePause, serverAddr+uriFilePause),
+		fileResumeTransport:          NewFileResumeTransport(methodFileResume, serverAddr+uriFileResume),
+		fileSeekTransport:            NewFileSeekTransport(methodFileSeek, serverAddr+uriFileSeek),
+		groupPlayTransport:           NewGroupPlayTransport(methodGroupPlay, serverAddr+uriGroupPlay),
+		groupStopTransport:           NewGroupStopTransport(methodGroupStop, serverAddr+uriGroupStop),
+		multicastPlayTransport:       NewMulticastPlayTransport(methodMulticastPlay, serverAddr+uriMulticastPlay),
+		multicastStopTransport:       NewMulticastStopTransport(methodMulticastStop, serverAddr+uriMulticastStop),
+		playlistEnqueueTransport:     NewPlaylistEnqueueTransport(methodPlaylistEnqueue, serverAddr+uriPlaylistEnqueue),
+		playlistSkipTransport:        NewPlaylistSkipTransport(methodPlaylistSkip, serverAddr+uriPlaylistSkip),
+		playlistClearTransport:       NewPlaylistClearTransport(methodPlaylistClear, serverAddr+uriPlaylistClear),
+		playlistModeTransport:        NewPlaylistModeTransport(methodPlaylistMode, serverAddr+uriPlaylistMode),
+		playlistStateTransport:       NewPlaylistStateTransport(methodPlaylistState, serverAddr+uriPlaylistState),
+		playlistStopTransport:        NewPlaylistStopTransport(methodPlaylistStop, serverAddr+uriPlaylistStop),
+		scheduleAddTransport:         NewScheduleAddTransport(methodScheduleAdd, serverAddr+uriScheduleAdd),
+		scheduleRemoveTransport:      NewScheduleRemoveTransport(methodScheduleRemove, serverAddr+uriScheduleRemove),
+		scheduleListTransport:        NewScheduleListTransport(methodScheduleList, serverAddr+uriScheduleList),
+		mixPlayTransport:             NewMixPlayTransport(methodMixPlay, serverAddr+uriMixPlay),
+		mixStopTransport:             NewMixStopTransport(methodMixStop, serverAddr+uriMixStop),
+		playerStateTransport:         NewPlayerStateTransport(methodPlayerState, serverAddr+uriPlayerState),
+		playerReceiveStartTransport:  NewPlayerReceiveStartTransport(methodPlayerReceiveStart, serverAddr+uriPlayerState),
+		playerReceiveStopTransport:   NewPlayerReceiveStopTransport(methodPlayerReceiveStop, serverAddr+uriPlayerReceiveStop),
+		playerPlayTransport:          NewPlayerPlayTransport(methodPlayerPlay, serverAddr+uriPlayerPlay),
+		playerStopTransport:          NewPlayerStopTransport(methodPlayerStop, serverAddr+uriPlayerStop),
+		playerRewindTransport:        NewPlayerRewindTransport(methodPlayerRewind, serverAddr+uriPlayerRewind),
+		playerReplayTransport:        NewPlayerReplayTransport(methodPlayerReplay, serverAddr+uriPlayerReplay),
+		playerClearStorageTransport:  NewPlayerClearStorageTransport(methodPlayerClearStorage, serverAddr+uriPlayerClearStorage),
+		playerSetVolumeTransport:     NewPlayerSetVolumeTransport(methodPlayerSetVolume, serverAddr+uriPlayerSetVolume),
+		playerMuteTransport:          NewPlayerMuteTransport(methodPlayerMute, serverAddr+uriPlayerMute),
+		playerListDevicesTransport:   NewPlayerListDevicesTransport(methodPlayerListDevices, serverAddr+uriPlayerListDevices),
+		startFileRecordingTransport:  NewStartFileRecordingTransport(methodStartFileRecording, serverAddr+uriStartFileRecording),
+		stopFileRecordingTransport:   NewStopFileRecordingTransport(methodStopFileRecording, serverAddr+uriStopFileRecording),
+		playFromRecorderTransport:    NewPlayFromRecorderTransport(methodPlayFromRecorder, serverAddr+uriPlayFromRecorder),
+		stopFromRecorderTransport:    NewStopFromRecorderTransport(methodStopFromRecorder, serverAddr+uriStopFromRecorder),
+		recorderStateTransport:       NewRecorderStateTransport(methodRecorderState, serverAddr+uriRecorderState),
+		recorderStartTransport:       NewRecorderStartTransport(methodRecorderStart, serverAddr+uriRecorderStart),
+		recorderStopTransport:        NewRecorderStopTransport(methodRecorderStop, serverAddr+uriRecorderStop),
+		recorderListDevicesTransport: NewRecorderListDevicesTransport(methodRecorderListDevices, serverAddr+uriRecorderListDevices),
+		eventsTransport:              NewEventsTransport(methodEvents, serverAddr+uriEvents),
 	}
 }
@@ -1,10 +1,15 @@
 package httpclient
 
 import (
+	"bufio"
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/valyala/fasthttp"
 
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
 	"audio-service/pkg/server"
 )
 
@@ -15,39 +20,75 @@ type Client interface {
 
 type client struct {
 	cli *fasthttp.Client
+	// apiKey of requests, empty - requests without key
+	apiKey string
+
+	filePlayTransport            FilePlayTransport
+	fileStopTransport            FileStopTransport
+	filePauseTransport           FilePauseTransport
+	fileResumeTransport          FileResumeTransport
+	fileSeekTransport            FileSeekTransport
+	groupPlayTransport           GroupPlayTransport
+	groupStopTransport           GroupStopTransport
+	multicastPlayTransport       MulticastPlayTransport
+	multicastStopTransport       MulticastStopTransport
+	playlistEnqueueTransport     PlaylistEnqueueTransport
+	playlistSkipTransport        PlaylistSkipTransport
+	playlistClearTransport       PlaylistClearTransport
+	playlistModeTransport        PlaylistModeTransport
+	playlistStateTransport       PlaylistStateTransport
+	playlistStopTransport        PlaylistStopTransport
+	scheduleAddTransport         ScheduleAddTransport
+	scheduleRemoveTransport      ScheduleRemoveTransport
+	scheduleListTransport        ScheduleListTransport
+	mixPlayTransport             MixPlayTransport
+	mixStopTransport             MixStopTransport
+	playerStateTransport         PlayerStateTransport
+	playerReceiveStartTransport  PlayerReceiveStartTransport
+	playerReceiveStopTransport   PlayerReceiveStopTransport
+	playerPlayTransport          PlayerPlayTransport
+	playerStopTransport          PlayerStopTransport
+	playerRewindTransport        PlayerRewindTransport
+	playerReplayTransport        PlayerReplayTransport
+	playerClearStorageTransport  PlayerClearStorageTransport
+	playerSetVolumeTransport     PlayerSetVolumeTransport
+	playerMuteTransport          PlayerMuteTransport
+	playerListDevicesTransport   PlayerListDevicesTransport
+	startFileRecordingTransport  StartFileRecordingTransport
+	stopFileRecordingTransport   StopFileRecordingTransport
+	playFromRecorderTransport    PlayFromRecorderTransport
+	stopFromRecorderTransport    StopFromRecorderTransport
+	recorderStateTransport       RecorderStateTransport
+	recorderStartTransport       RecorderStartTransport
+	recorderStopTransport        RecorderStopTransport
+	recorderListDevicesTransport RecorderListDevicesTransport
+	eventsTransport              EventsTransport
+}
 
-	filePlayTransport           FilePlayTransport
-	fileStopTransport           FileStopTransport
-	playerStateTransport        PlayerStateTransport
-	playerReceiveStartTransport PlayerReceiveStartTransport
-	playerReceiveStopTransport  PlayerReceiveStopTransport
-	playerPlayTransport         PlayerPlayTransport
-	playerStopTransport         PlayerStopTransport
-	playerClearStorageTransport PlayerClearStorageTransport
-	startFileRecordingTransport StartFileRecordingTransport
-	stopFileRecordingTransport  StopFileRecordingTransport
-	playFromRecorderTransport   PlayFromRecorderTransport
-	stopFromRecorderTransport   StopFromRecorderTransport
-	recorderStateTransport      RecorderStateTransport
-	recorderStartTransport      RecorderStartTransport
-	recorderStopTransport       RecorderStopTransport
+// do request with api key of client
+func (c *client) do(req *fasthttp.Request, res *fasthttp.Response) error {
+	if c.apiKey != "" {
+		req.Header.Set(headerAPIKey, c.apiKey)
+	}
+	return c.cli.Do(req, res)
 }
 
 // FilePlay send file to player with playerIP on port and play on playerDeviceName
-// channel and rate audio info from file.
+// format of samples audio info from file.
+// Audio is converted to dstChannels and dstRate before sending, 0 - channels or rate from file.
 // Player save audio from server in storage with uuid.
-func (c *client) FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error) {
+func (c *client) FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
 	defer func() {
 		fasthttp.ReleaseRequest(req)
 		fasthttp.ReleaseResponse(res)
 	}()
 
-	if err = c.filePlayTransport.EncodeRequest(ctx, req, file, playerIP, playerPort, playerDeviceName); err != nil {
+	if err = c.filePlayTransport.EncodeRequest(ctx, req, file, playerIP, playerPort, playerDeviceName, dstChannels, dstRate); err != nil {
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -68,13 +109,372 @@ func (c *client) FileStop(ctx context.Context, playerIP, playerPort, playerDevic
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
 	return c.fileStopTransport.DecodeResponse(ctx, res)
 }
 
+// FilePause pause file playing on player with playerIP on port.
+// Playback, sending and storage on player are stopped, file session is kept to resume from position.
+func (c *client) FilePause(ctx context.Context, playerIP, playerPort string) (position time.Duration, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.filePauseTransport.EncodeRequest(ctx, req, playerIP, playerPort); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.filePauseTransport.DecodeResponse(ctx, res)
+}
+
+// FileResume resume paused file playing on player with playerIP on port from position of pause.
+func (c *client) FileResume(ctx context.Context, playerIP, playerPort string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.fileResumeTransport.EncodeRequest(ctx, req, playerIP, playerPort); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.fileResumeTransport.DecodeResponse(ctx, res)
+}
+
+// FileSeek move file playing on player with playerIP on port to position from start of file.
+// Paused file stays paused and is resumed from position.
+func (c *client) FileSeek(ctx context.Context, playerIP, playerPort string, position time.Duration) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.fileSeekTransport.EncodeRequest(ctx, req, playerIP, playerPort, position); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.fileSeekTransport.DecodeResponse(ctx, res)
+}
+
+// GroupPlay send file to players and play on their devices synchronously from startAt.
+// Players keep playing in sync with their wall clocks, so clocks of players must be synchronized.
+// Audio is converted to dstChannels and dstRate before sending, 0 - channels or rate from file.
+// Players save audio from server in storages with uuids in order of players,
+// playing on each player is controlled as playing of file.
+func (c *client) GroupPlay(ctx context.Context, file string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.groupPlayTransport.EncodeRequest(ctx, req, file, players, dstChannels, dstRate); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.groupPlayTransport.DecodeResponse(ctx, res)
+}
+
+// GroupStop stop playing file on players
+func (c *client) GroupStop(ctx context.Context, players []server.PlayerDevice) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.groupStopTransport.EncodeRequest(ctx, req, players); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.groupStopTransport.DecodeResponse(ctx, res)
+}
+
+// MulticastPlay send file once to multicast group with groupAddr and play it on players joined to the group
+// synchronously from startAt, PlayerPort of players is not used.
+func (c *client) MulticastPlay(ctx context.Context, file, groupAddr string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.multicastPlayTransport.EncodeRequest(ctx, req, file, groupAddr, players, dstChannels, dstRate); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.multicastPlayTransport.DecodeResponse(ctx, res)
+}
+
+// MulticastStop stop sending to multicast group with groupAddr and playing on players joined to the group
+func (c *client) MulticastStop(ctx context.Context, groupAddr string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.multicastStopTransport.EncodeRequest(ctx, req, groupAddr); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.multicastStopTransport.DecodeResponse(ctx, res)
+}
+
+// PlaylistEnqueue add files to playlist on playerDeviceName on player with playerIP.
+// Not existing playlist is created and sent to player on playerPort.
+// Files are played with channels and rate (0 - from first file) and format of samples from first file.
+// Player save audio from server in storage with uuid.
+func (c *client) PlaylistEnqueue(ctx context.Context, playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32) (uuid string, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playlistEnqueueTransport.EncodeRequest(ctx, req, playerIP, playerPort, playerDeviceName, files, channels, rate); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playlistEnqueueTransport.DecodeResponse(ctx, res)
+}
+
+// PlaylistSkip skip playing file of playlist on playerDeviceName on player with playerIP and play next one
+func (c *client) PlaylistSkip(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playlistSkipTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playlistSkipTransport.DecodeResponse(ctx, res)
+}
+
+// PlaylistClear remove all files from playlist on playerDeviceName on player with playerIP.
+// Player device stays opened and plays files enqueued later.
+func (c *client) PlaylistClear(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playlistClearTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playlistClearTransport.DecodeResponse(ctx, res)
+}
+
+// PlaylistMode set playing playlist on playerDeviceName on player with playerIP in loop and in random order
+func (c *client) PlaylistMode(ctx context.Context, playerIP, playerDeviceName string, loop, shuffle bool) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playlistModeTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName, loop, shuffle); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playlistModeTransport.DecodeResponse(ctx, res)
+}
+
+// PlaylistState return files of playlist on playerDeviceName on player with playerIP,
+// index of playing file (-1 if nothing is playing) and mode of playlist
+func (c *client) PlaylistState(ctx context.Context, playerIP, playerDeviceName string) (files []string, current int, loop, shuffle bool, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playlistStateTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playlistStateTransport.DecodeResponse(ctx, res)
+}
+
+// PlaylistStop stop sending playlist to player with playerIP and playing on playerDeviceName, clear storage on player
+func (c *client) PlaylistStop(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playlistStopTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playlistStopTransport.DecodeResponse(ctx, res)
+}
+
+// ScheduleAdd plan playing file on players by cron expression spec or once at time at if spec is empty.
+// Playback of previous run is stopped on next run, playback is stopped after duration if it is not 0.
+func (c *client) ScheduleAdd(ctx context.Context, spec string, at time.Time, file string, players []server.PlayerDevice, duration time.Duration) (id string, nextRun time.Time, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.scheduleAddTransport.EncodeRequest(ctx, req, spec, at, file, players, duration); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.scheduleAddTransport.DecodeResponse(ctx, res)
+}
+
+// ScheduleRemove remove scheduled playback with id, playback started by it is stopped
+func (c *client) ScheduleRemove(ctx context.Context, id string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.scheduleRemoveTransport.EncodeRequest(ctx, req, id); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.scheduleRemoveTransport.DecodeResponse(ctx, res)
+}
+
+// ScheduleList return all scheduled playbacks with time of next and last run and result of last run
+func (c *client) ScheduleList(ctx context.Context) (jobs []server.ScheduleJob, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.scheduleListTransport.EncodeRequest(ctx, req); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.scheduleListTransport.DecodeResponse(ctx, res)
+}
+
+// MixPlay mix sources sample by sample with gain of each source and send mixed signal to player with playerIP on port and play on playerDeviceName.
+// Files must be 16 bits per sample with channels and rate, recorders start recording with channels and rate.
+// Player save audio from server in storage with uuid.
+func (c *client) MixPlay(ctx context.Context, sources []server.MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32) (uuid string, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.mixPlayTransport.EncodeRequest(ctx, req, sources, playerIP, playerPort, playerDeviceName, channels, rate); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.mixPlayTransport.DecodeResponse(ctx, res)
+}
+
+// MixStop stop send mixed signal to player with playerIP on port and stop recorders of sources of mix.
+// Stop play audio on device of mix on player with playerIP
+// Clear storage with uuid on player with playerIP
+func (c *client) MixStop(ctx context.Context, playerIP, playerPort, uuid string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.mixStopTransport.EncodeRequest(ctx, req, playerIP, playerPort, uuid); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.mixStopTransport.DecodeResponse(ctx, res)
+}
+
 // PlayerState return all busy ports, devices on player and existing storage
 func (c *client) PlayerState(ctx context.Context, playerIP string) (ports, storages, devices []string, err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
@@ -87,7 +487,7 @@ func (c *client) PlayerState(ctx context.Context, playerIP string) (ports, stora
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -97,19 +497,19 @@ func (c *client) PlayerState(ctx context.Context, playerIP string) (ports, stora
 // PlayerReceiveStart player with playerIP start receive signal from server on playerPort.
 // uuid of the storage existing on the player
 // if the storage with uuid does not exist or the uuid is nil, a new storage will be created on the player
-// The signal will be stored in the storage sUUID
-func (c *client) PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string) (sUUID string, err error) {
+// The signal will be stored in the storage sUUID, new storage keeps content for rewind and replay if replay is set
+func (c *client) PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string, replay bool) (sUUID string, err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
 	defer func() {
 		fasthttp.ReleaseRequest(req)
 		fasthttp.ReleaseResponse(res)
 	}()
 
-	if err = c.playerReceiveStartTransport.EncodeRequest(ctx, req, playerIP, playerPort, uuid); err != nil {
+	if err = c.playerReceiveStartTransport.EncodeRequest(ctx, req, playerIP, playerPort, uuid, replay); err != nil {
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -128,7 +528,7 @@ func (c *client) PlayerReceiveStop(ctx context.Context, playerIP, playerPort str
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -136,19 +536,19 @@ func (c *client) PlayerReceiveStop(ctx context.Context, playerIP, playerPort str
 }
 
 // PlayerPlay play audio from storage with uuid on player with playerIP on playerDeviceName
-// channels, rate, bitsPerSample - params audio
-func (c *client) PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32) (err error) {
+// channels, rate, bitsPerSample, audioFormat - params audio
+func (c *client) PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32) (err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
 	defer func() {
 		fasthttp.ReleaseRequest(req)
 		fasthttp.ReleaseResponse(res)
 	}()
 
-	if err = c.playerPlayTransport.EncodeRequest(ctx, req, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample); err != nil {
+	if err = c.playerPlayTransport.EncodeRequest(ctx, req, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, audioFormat); err != nil {
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -167,13 +567,51 @@ func (c *client) PlayerStop(ctx context.Context, playerIP, playerDeviceName stri
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
 	return c.playerStopTransport.DecodeResponse(ctx, res)
 }
 
+// PlayerRewind play audio on player with playerIP on playerDeviceName from beginning of storage
+func (c *client) PlayerRewind(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playerRewindTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playerRewindTransport.DecodeResponse(ctx, res)
+}
+
+// PlayerReplay play again last played storage on player with playerIP on playerDeviceName without sending audio
+func (c *client) PlayerReplay(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playerReplayTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playerReplayTransport.DecodeResponse(ctx, res)
+}
+
 // PlayerClearStorage clear storage with uuid on player with playerIP
 func (c *client) PlayerClearStorage(ctx context.Context, playerIP, uuid string) (err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
@@ -186,27 +624,86 @@ func (c *client) PlayerClearStorage(ctx context.Context, playerIP, uuid string)
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
 	return c.playerClearStorageTransport.DecodeResponse(ctx, res)
 }
 
+// PlayerSetVolume set volume level on playerDeviceName on player with playerIP
+// 1 - original loudness
+func (c *client) PlayerSetVolume(ctx context.Context, playerIP, playerDeviceName string, volume float32) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playerSetVolumeTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName, volume); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playerSetVolumeTransport.DecodeResponse(ctx, res)
+}
+
+// PlayerMute mute or unmute playerDeviceName on player with playerIP
+func (c *client) PlayerMute(ctx context.Context, playerIP, playerDeviceName string, mute bool) (err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playerMuteTransport.EncodeRequest(ctx, req, playerIP, playerDeviceName, mute); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playerMuteTransport.DecodeResponse(ctx, res)
+}
+
+// PlayerListDevices return playback devices on player with playerIP with supported rates, channels and formats
+func (c *client) PlayerListDevices(ctx context.Context, playerIP string) (devices []pcm.Device, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.playerListDevicesTransport.EncodeRequest(ctx, req, playerIP); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.playerListDevicesTransport.DecodeResponse(ctx, res)
+}
+
 // StartFileRecording start receive on receivePort audio signal from recorder with recorderIP from recordeDeviceName and write in file
-// channels, rate - params audio
-func (c *client) StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string) (err error) {
+// channels, rate, bitsPerSample, audioFormat - params audio
+// fileFormat - extension of file without dot (wav, flac), segmentDuration, segmentSize - limits of file, zero disables the limit
+func (c *client) StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64) (err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
 	defer func() {
 		fasthttp.ReleaseRequest(req)
 		fasthttp.ReleaseResponse(res)
 	}()
 
-	if err = c.startFileRecordingTransport.EncodeRequest(ctx, req, recorderIP, recorderDeviceName, channels, rate, receivePort, file); err != nil {
+	if err = c.startFileRecordingTransport.EncodeRequest(ctx, req, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, receivePort, file, fileFormat, segmentDuration, segmentSize); err != nil {
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -225,7 +722,7 @@ func (c *client) StopFileRecording(ctx context.Context, recorderIP, recorderDevi
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -233,18 +730,19 @@ func (c *client) StopFileRecording(ctx context.Context, recorderIP, recorderDevi
 }
 
 // PlayFromRecorder play audio on player with playerIP from recorder with recorderIP
-func (c *client) PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string) (uuid string, err error) {
+// channels, rate, bitsPerSample, audioFormat - params audio
+func (c *client) PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string) (uuid string, err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
 	defer func() {
 		fasthttp.ReleaseRequest(req)
 		fasthttp.ReleaseResponse(res)
 	}()
 
-	if err = c.playFromRecorderTransport.EncodeRequest(ctx, req, playerIP, playerPort, playerDeviceName, channels, rate, recorderIP, recorderDeviceName); err != nil {
+	if err = c.playFromRecorderTransport.EncodeRequest(ctx, req, playerIP, playerPort, playerDeviceName, channels, rate, bitsPerSample, audioFormat, recorderIP, recorderDeviceName); err != nil {
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -263,7 +761,7 @@ func (c *client) StopFromRecorder(ctx context.Context, playerIP, playerPort, pla
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -282,7 +780,7 @@ func (c *client) RecorderState(ctx context.Context, recorderIP string) (devices
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -290,19 +788,19 @@ func (c *client) RecorderState(ctx context.Context, recorderIP string) (devices
 }
 
 // RecorderStart start recording audio on recorder with recorderIP from recorderDeviceName and receive on dstAddr
-// channels, rate - recording param
-func (c *client) RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string) (err error) {
+// channels, rate, bitsPerSample, audioFormat - recording param
+func (c *client) RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string) (err error) {
 	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
 	defer func() {
 		fasthttp.ReleaseRequest(req)
 		fasthttp.ReleaseResponse(res)
 	}()
 
-	if err = c.recorderStartTransport.EncodeRequest(ctx, req, recorderIP, recorderDeviceName, channels, rate, dstAddr); err != nil {
+	if err = c.recorderStartTransport.EncodeRequest(ctx, req, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, dstAddr); err != nil {
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
@@ -321,9 +819,72 @@ func (c *client) RecorderStop(ctx context.Context, recorderIP, recorderDeviceNam
 		return
 	}
 
-	if err = c.cli.Do(req, res); err != nil {
+	if err = c.do(req, res); err != nil {
 		return
 	}
 
 	return c.recorderStopTransport.DecodeResponse(ctx, res)
 }
+
+// RecorderListDevices return capture devices on recorder with recorderIP with supported rates, channels and formats
+func (c *client) RecorderListDevices(ctx context.Context, recorderIP string) (devices []pcm.Device, err error) {
+	req, res := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer func() {
+		fasthttp.ReleaseRequest(req)
+		fasthttp.ReleaseResponse(res)
+	}()
+
+	if err = c.recorderListDevicesTransport.EncodeRequest(ctx, req, recorderIP); err != nil {
+		return
+	}
+
+	if err = c.do(req, res); err != nil {
+		return
+	}
+
+	return c.recorderListDevicesTransport.DecodeResponse(ctx, res)
+}
+
+// Events subscribe on events of players and recorders controlled by server.
+// events are closed by cancel or when connection to server is lost.
+// Response of fasthttp client is not streamed, so events are read with net/http.
+func (c *client) Events(ctx context.Context) (events <-chan event.Event, cancel func()) {
+	ctx, cancel = context.WithCancel(ctx)
+	e := make(chan event.Event)
+	go func() {
+		defer close(e)
+
+		req, err := c.eventsTransport.EncodeRequest(ctx)
+		if err != nil {
+			return
+		}
+		if c.apiKey != "" {
+			req.Header.Set(headerAPIKey, c.apiKey)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return
+		}
+
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			ev, isEvent, err := c.eventsTransport.DecodeEvent(ctx, scanner.Bytes())
+			if err != nil {
+				return
+			}
+			if !isEvent {
+				continue
+			}
+			select {
+			case e <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return e, cancel
+}
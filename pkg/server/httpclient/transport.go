@@ -1,17 +1,23 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/valyala/fasthttp"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
+	"audio-service/pkg/server"
 )
 
 // FilePlayTransport ...
 type FilePlayTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, file, playerIP, playerPort, playerDeviceName string) (err error)
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error)
 }
 
@@ -25,9 +31,11 @@ type filePlayRequest struct {
 	PlayerIP         string `json:"playerIP"`
 	PlayerPort       string `json:"playerPort"`
 	PlayerDeviceName string `json:"playerDeviceName"`
+	Channels         uint16 `json:"channels,omitempty"`
+	Rate             uint32 `json:"rate,omitempty"`
 }
 
-func (t *filePlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, file, playerIP, playerPort, playerDeviceName string) (err error) {
+func (t *filePlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
@@ -36,6 +44,8 @@ func (t *filePlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Req
 		PlayerIP:         playerIP,
 		PlayerPort:       playerPort,
 		PlayerDeviceName: playerDeviceName,
+		Channels:         dstChannels,
+		Rate:             dstRate,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -83,27 +93,1387 @@ type FileStopTransport interface {
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
-type fileStopTransport struct {
-	pathTemplate string
+type fileStopTransport struct {
+	pathTemplate string
+	method       string
+}
+
+type fileStopRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerPort       string `json:"playerPort"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	UUID             string `json:"uuid"`
+}
+
+func (t *fileStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName, uuid string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := fileStopRequest{
+		PlayerIP:         playerIP,
+		PlayerPort:       playerPort,
+		PlayerDeviceName: playerDeviceName,
+		UUID:             uuid,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *fileStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewFileStopTransport ...
+func NewFileStopTransport(method, pathTemplate string) FileStopTransport {
+	return &fileStopTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+type mixSource struct {
+	File               string  `json:"file,omitempty"`
+	RecorderIP         string  `json:"recorderIP,omitempty"`
+	RecorderDeviceName string  `json:"recorderDeviceName,omitempty"`
+	ReceivePort        string  `json:"receivePort,omitempty"`
+	Gain               float64 `json:"gain"`
+}
+
+func fromMixSources(sources []server.MixSource) []mixSource {
+	mixSources := make([]mixSource, 0, len(sources))
+	for _, source := range sources {
+		mixSources = append(mixSources, mixSource{
+			File:               source.File,
+			RecorderIP:         source.RecorderIP,
+			RecorderDeviceName: source.RecorderDeviceName,
+			ReceivePort:        source.ReceivePort,
+			Gain:               source.Gain,
+		})
+	}
+	return mixSources
+}
+
+// FilePauseTransport ...
+type FilePauseTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (position time.Duration, err error)
+}
+
+type filePauseTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type filePauseRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+}
+
+func (t *filePauseTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := filePauseRequest{
+		PlayerIP:   playerIP,
+		PlayerPort: playerPort,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type filePauseResponse struct {
+	// Position in milliseconds
+	Position int64 `json:"position"`
+}
+
+func (t *filePauseTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (position time.Duration, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response filePauseResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	position = time.Duration(response.Position) * time.Millisecond
+	return
+}
+
+// NewFilePauseTransport ...
+func NewFilePauseTransport(method, pathTemplate string) FilePauseTransport {
+	return &filePauseTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// FileResumeTransport ...
+type FileResumeTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type fileResumeTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type fileResumeRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+}
+
+func (t *fileResumeTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := fileResumeRequest{
+		PlayerIP:   playerIP,
+		PlayerPort: playerPort,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *fileResumeTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewFileResumeTransport ...
+func NewFileResumeTransport(method, pathTemplate string) FileResumeTransport {
+	return &fileResumeTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// FileSeekTransport ...
+type FileSeekTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string, position time.Duration) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type fileSeekTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type fileSeekRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+	// Position in milliseconds
+	Position int64 `json:"position"`
+}
+
+func (t *fileSeekTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string, position time.Duration) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := fileSeekRequest{
+		PlayerIP:   playerIP,
+		PlayerPort: playerPort,
+		Position:   position.Milliseconds(),
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *fileSeekTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewFileSeekTransport ...
+func NewFileSeekTransport(method, pathTemplate string) FileSeekTransport {
+	return &fileSeekTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+type playerDevice struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerPort       string `json:"playerPort"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func fromPlayerDevices(players []server.PlayerDevice) []playerDevice {
+	playerDevices := make([]playerDevice, 0, len(players))
+	for _, player := range players {
+		playerDevices = append(playerDevices, playerDevice{
+			PlayerIP:         player.PlayerIP,
+			PlayerPort:       player.PlayerPort,
+			PlayerDeviceName: player.PlayerDeviceName,
+		})
+	}
+	return playerDevices
+}
+
+func toPlayerDevices(players []playerDevice) []server.PlayerDevice {
+	playerDevices := make([]server.PlayerDevice, 0, len(players))
+	for _, player := range players {
+		playerDevices = append(playerDevices, server.PlayerDevice{
+			PlayerIP:         player.PlayerIP,
+			PlayerPort:       player.PlayerPort,
+			PlayerDeviceName: player.PlayerDeviceName,
+		})
+	}
+	return playerDevices
+}
+
+// GroupPlayTransport ...
+type GroupPlayTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, file string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuids []string, startAt time.Time, err error)
+}
+
+type groupPlayTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type groupPlayRequest struct {
+	File     string         `json:"file"`
+	Players  []playerDevice `json:"players"`
+	Channels uint16         `json:"channels,omitempty"`
+	Rate     uint32         `json:"rate,omitempty"`
+}
+
+func (t *groupPlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, file string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := groupPlayRequest{
+		File:     file,
+		Players:  fromPlayerDevices(players),
+		Channels: dstChannels,
+		Rate:     dstRate,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type groupPlayResponse struct {
+	UUIDs   []string  `json:"uuids"`
+	StartAt time.Time `json:"startAt"`
+}
+
+func (t *groupPlayTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuids []string, startAt time.Time, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response groupPlayResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	uuids, startAt = response.UUIDs, response.StartAt
+	return
+}
+
+// NewGroupPlayTransport ...
+func NewGroupPlayTransport(method, pathTemplate string) GroupPlayTransport {
+	return &groupPlayTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// GroupStopTransport ...
+type GroupStopTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, players []server.PlayerDevice) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type groupStopTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type groupStopRequest struct {
+	Players []playerDevice `json:"players"`
+}
+
+func (t *groupStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, players []server.PlayerDevice) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := groupStopRequest{
+		Players: fromPlayerDevices(players),
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *groupStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewGroupStopTransport ...
+func NewGroupStopTransport(method, pathTemplate string) GroupStopTransport {
+	return &groupStopTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// MulticastPlayTransport ...
+type MulticastPlayTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, file, groupAddr string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuids []string, startAt time.Time, err error)
+}
+
+type multicastPlayTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type multicastPlayRequest struct {
+	File      string         `json:"file"`
+	GroupAddr string         `json:"groupAddr"`
+	Players   []playerDevice `json:"players"`
+	Channels  uint16         `json:"channels,omitempty"`
+	Rate      uint32         `json:"rate,omitempty"`
+}
+
+func (t *multicastPlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, file, groupAddr string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := multicastPlayRequest{
+		File:      file,
+		GroupAddr: groupAddr,
+		Players:   fromPlayerDevices(players),
+		Channels:  dstChannels,
+		Rate:      dstRate,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type multicastPlayResponse struct {
+	UUIDs   []string  `json:"uuids"`
+	StartAt time.Time `json:"startAt"`
+}
+
+func (t *multicastPlayTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuids []string, startAt time.Time, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response multicastPlayResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	uuids, startAt = response.UUIDs, response.StartAt
+	return
+}
+
+// NewMulticastPlayTransport ...
+func NewMulticastPlayTransport(method, pathTemplate string) MulticastPlayTransport {
+	return &multicastPlayTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// MulticastStopTransport ...
+type MulticastStopTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, groupAddr string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type multicastStopTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type multicastStopRequest struct {
+	GroupAddr string `json:"groupAddr"`
+}
+
+func (t *multicastStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, groupAddr string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := multicastStopRequest{
+		GroupAddr: groupAddr,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *multicastStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewMulticastStopTransport ...
+func NewMulticastStopTransport(method, pathTemplate string) MulticastStopTransport {
+	return &multicastStopTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlaylistEnqueueTransport ...
+type PlaylistEnqueueTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error)
+}
+
+type playlistEnqueueTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playlistEnqueueRequest struct {
+	PlayerIP         string   `json:"playerIP"`
+	PlayerPort       string   `json:"playerPort"`
+	PlayerDeviceName string   `json:"playerDeviceName"`
+	Files            []string `json:"files"`
+	Channels         uint32   `json:"channels"`
+	Rate             uint32   `json:"rate"`
+}
+
+func (t *playlistEnqueueTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playlistEnqueueRequest{
+		PlayerIP:         playerIP,
+		PlayerPort:       playerPort,
+		PlayerDeviceName: playerDeviceName,
+		Files:            files,
+		Channels:         channels,
+		Rate:             rate,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type playlistEnqueueResponse struct {
+	UUID string `json:"uuid"`
+}
+
+func (t *playlistEnqueueTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response playlistEnqueueResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	uuid = response.UUID
+	return
+}
+
+// NewPlaylistEnqueueTransport ...
+func NewPlaylistEnqueueTransport(method, pathTemplate string) PlaylistEnqueueTransport {
+	return &playlistEnqueueTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlaylistSkipTransport ...
+type PlaylistSkipTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playlistSkipTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playlistSkipRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistSkipTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playlistSkipRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *playlistSkipTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewPlaylistSkipTransport ...
+func NewPlaylistSkipTransport(method, pathTemplate string) PlaylistSkipTransport {
+	return &playlistSkipTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlaylistClearTransport ...
+type PlaylistClearTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playlistClearTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playlistClearRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistClearTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playlistClearRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *playlistClearTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewPlaylistClearTransport ...
+func NewPlaylistClearTransport(method, pathTemplate string) PlaylistClearTransport {
+	return &playlistClearTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlaylistModeTransport ...
+type PlaylistModeTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string, loop, shuffle bool) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playlistModeTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playlistModeRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	Loop             bool   `json:"loop"`
+	Shuffle          bool   `json:"shuffle"`
+}
+
+func (t *playlistModeTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string, loop, shuffle bool) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playlistModeRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
+		Loop:             loop,
+		Shuffle:          shuffle,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *playlistModeTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewPlaylistModeTransport ...
+func NewPlaylistModeTransport(method, pathTemplate string) PlaylistModeTransport {
+	return &playlistModeTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlaylistStateTransport ...
+type PlaylistStateTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (files []string, current int, loop, shuffle bool, err error)
+}
+
+type playlistStateTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playlistStateRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistStateTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playlistStateRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type playlistStateResponse struct {
+	Files   []string `json:"files"`
+	Current int      `json:"current"`
+	Loop    bool     `json:"loop"`
+	Shuffle bool     `json:"shuffle"`
+}
+
+func (t *playlistStateTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (files []string, current int, loop, shuffle bool, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response playlistStateResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	files, current, loop, shuffle = response.Files, response.Current, response.Loop, response.Shuffle
+	return
+}
+
+// NewPlaylistStateTransport ...
+func NewPlaylistStateTransport(method, pathTemplate string) PlaylistStateTransport {
+	return &playlistStateTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlaylistStopTransport ...
+type PlaylistStopTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playlistStopTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playlistStopRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playlistStopRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *playlistStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewPlaylistStopTransport ...
+func NewPlaylistStopTransport(method, pathTemplate string) PlaylistStopTransport {
+	return &playlistStopTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+type scheduleJob struct {
+	ID      string         `json:"id"`
+	Cron    string         `json:"cron"`
+	At      time.Time      `json:"at"`
+	File    string         `json:"file"`
+	Players []playerDevice `json:"players"`
+	// Duration in milliseconds
+	Duration   int64     `json:"duration"`
+	NextRun    time.Time `json:"nextRun"`
+	LastRun    time.Time `json:"lastRun"`
+	LastResult string    `json:"lastResult"`
+}
+
+func toScheduleJobs(jobs []scheduleJob) []server.ScheduleJob {
+	scheduleJobs := make([]server.ScheduleJob, 0, len(jobs))
+	for _, job := range jobs {
+		scheduleJobs = append(scheduleJobs, server.ScheduleJob{
+			ID:         job.ID,
+			Cron:       job.Cron,
+			At:         job.At,
+			File:       job.File,
+			Players:    toPlayerDevices(job.Players),
+			Duration:   time.Duration(job.Duration) * time.Millisecond,
+			NextRun:    job.NextRun,
+			LastRun:    job.LastRun,
+			LastResult: job.LastResult,
+		})
+	}
+	return scheduleJobs
+}
+
+// ScheduleAddTransport ...
+type ScheduleAddTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, spec string, at time.Time, file string, players []server.PlayerDevice, duration time.Duration) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (id string, nextRun time.Time, err error)
+}
+
+type scheduleAddTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type scheduleAddRequest struct {
+	Cron    string         `json:"cron,omitempty"`
+	At      *time.Time     `json:"at,omitempty"`
+	File    string         `json:"file"`
+	Players []playerDevice `json:"players"`
+	// Duration in milliseconds
+	Duration int64 `json:"duration,omitempty"`
+}
+
+func (t *scheduleAddTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, spec string, at time.Time, file string, players []server.PlayerDevice, duration time.Duration) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := scheduleAddRequest{
+		Cron:     spec,
+		File:     file,
+		Players:  fromPlayerDevices(players),
+		Duration: duration.Milliseconds(),
+	}
+	if !at.IsZero() {
+		request.At = &at
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type scheduleAddResponse struct {
+	ID      string    `json:"id"`
+	NextRun time.Time `json:"nextRun"`
+}
+
+func (t *scheduleAddTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (id string, nextRun time.Time, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response scheduleAddResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	id, nextRun = response.ID, response.NextRun
+	return
+}
+
+// NewScheduleAddTransport ...
+func NewScheduleAddTransport(method, pathTemplate string) ScheduleAddTransport {
+	return &scheduleAddTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// ScheduleRemoveTransport ...
+type ScheduleRemoveTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, id string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type scheduleRemoveTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type scheduleRemoveRequest struct {
+	ID string `json:"id"`
+}
+
+func (t *scheduleRemoveTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, id string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := scheduleRemoveRequest{
+		ID: id,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *scheduleRemoveTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewScheduleRemoveTransport ...
+func NewScheduleRemoveTransport(method, pathTemplate string) ScheduleRemoveTransport {
+	return &scheduleRemoveTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// ScheduleListTransport ...
+type ScheduleListTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (jobs []server.ScheduleJob, err error)
+}
+
+type scheduleListTransport struct {
+	method       string
+	pathTemplate string
+}
+
+func (t *scheduleListTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+	return
+}
+
+type scheduleListResponse struct {
+	Jobs []scheduleJob `json:"jobs"`
+}
+
+func (t *scheduleListTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (jobs []server.ScheduleJob, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response scheduleListResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	jobs = toScheduleJobs(response.Jobs)
+	return
+}
+
+// NewScheduleListTransport ...
+func NewScheduleListTransport(method, pathTemplate string) ScheduleListTransport {
+	return &scheduleListTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// MixPlayTransport ...
+type MixPlayTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, sources []server.MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error)
+}
+
+type mixPlayTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type mixPlayRequest struct {
+	Sources          []mixSource `json:"sources"`
+	PlayerIP         string      `json:"playerIP"`
+	PlayerPort       string      `json:"playerPort"`
+	PlayerDeviceName string      `json:"playerDeviceName"`
+	Channels         uint32      `json:"channels"`
+	Rate             uint32      `json:"rate"`
+}
+
+func (t *mixPlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, sources []server.MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := mixPlayRequest{
+		Sources:          fromMixSources(sources),
+		PlayerIP:         playerIP,
+		PlayerPort:       playerPort,
+		PlayerDeviceName: playerDeviceName,
+		Channels:         channels,
+		Rate:             rate,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type mixPlayResponse struct {
+	UUID string `json:"uuid"`
+}
+
+func (t *mixPlayTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response mixPlayResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	uuid = response.UUID
+	return
+}
+
+// NewMixPlayTransport ...
+func NewMixPlayTransport(method, pathTemplate string) MixPlayTransport {
+	return &mixPlayTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// MixStopTransport ...
+type MixStopTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, uuid string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type mixStopTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type mixStopRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+	UUID       string `json:"uuid"`
+}
+
+func (t *mixStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, uuid string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := mixStopRequest{
+		PlayerIP:   playerIP,
+		PlayerPort: playerPort,
+		UUID:       uuid,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *mixStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewMixStopTransport ...
+func NewMixStopTransport(method, pathTemplate string) MixStopTransport {
+	return &mixStopTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlayerStateTransport ...
+type PlayerStateTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (ports, storages, devices []string, err error)
+}
+
+type playerStateTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playerStateRequest struct {
+	PlayerIP string `json:"playerIP"`
+}
+
+func (t *playerStateTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playerStateRequest{
+		PlayerIP: playerIP,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type playerStateResponse struct {
+	Ports    []string `json:"ports"`
+	Storages []string `json:"storages"`
+	Devices  []string `json:"devices"`
+}
+
+func (t *playerStateTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (ports, storages, devices []string, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response playerStateResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	ports, storages, devices = response.Ports, response.Storages, response.Devices
+	return
+}
+
+// NewPlayerStateTransport ...
+func NewPlayerStateTransport(method, pathTemplate string) PlayerStateTransport {
+	return &playerStateTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlayerReceiveStartTransport ...
+type PlayerReceiveStartTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string, uuid *string, replay bool) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error)
+}
+
+type playerReceiveStartTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playerReceiveStartRequest struct {
+	PlayerIP   string  `json:"playerIP"`
+	PlayerPort string  `json:"playerPort"`
+	UUID       *string `json:"uuid,omitempty"`
+	Replay     bool    `json:"replay,omitempty"`
+}
+
+func (t *playerReceiveStartTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string, uuid *string, replay bool) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playerReceiveStartRequest{
+		PlayerIP:   playerIP,
+		PlayerPort: playerPort,
+		UUID:       uuid,
+		Replay:     replay,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type playerReceiveStartResponse struct {
+	UUID string `json:"uuid"`
+}
+
+func (t *playerReceiveStartTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response playerReceiveStartResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	uuid = response.UUID
+	return
+}
+
+// NewPlayerReceiveStartTransport ...
+func NewPlayerReceiveStartTransport(method, pathTemplate string) PlayerReceiveStartTransport {
+	return &playerReceiveStartTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlayerReceiveStopTransport ...
+type PlayerReceiveStopTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playerReceiveStopTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playerReceiveStopRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+}
+
+func (t *playerReceiveStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playerReceiveStopRequest{
+		PlayerIP:   playerIP,
+		PlayerPort: playerPort,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *playerReceiveStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewPlayerReceiveStopTransport ...
+func NewPlayerReceiveStopTransport(method, pathTemplate string) PlayerReceiveStopTransport {
+	return &playerReceiveStopTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlayerPlayTransport ...
+type PlayerPlayTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playerPlayTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type playerPlayRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	UUID             string `json:"uuid"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	Channels         uint32 `json:"channels"`
+	Rate             uint32 `json:"rate"`
+	BitsPerSample    uint32 `json:"bitsPerSample"`
+	AudioFormat      uint32 `json:"audioFormat"`
+}
+
+func (t *playerPlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := playerPlayRequest{
+		PlayerIP:         playerIP,
+		UUID:             uuid,
+		PlayerDeviceName: playerDeviceName,
+		Channels:         channels,
+		Rate:             rate,
+		BitsPerSample:    bitsPerSample,
+		AudioFormat:      audioFormat,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+func (t *playerPlayTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+	}
+	return
+}
+
+// NewPlayerPlayTransport ...
+func NewPlayerPlayTransport(method, pathTemplate string) PlayerPlayTransport {
+	return &playerPlayTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// PlayerStopTransport ...
+type PlayerStopTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+}
+
+type playerStopTransport struct {
 	method       string
+	pathTemplate string
 }
 
-type fileStopRequest struct {
+type playerStopTransportRequest struct {
 	PlayerIP         string `json:"playerIP"`
-	PlayerPort       string `json:"playerPort"`
 	PlayerDeviceName string `json:"playerDeviceName"`
-	UUID             string `json:"uuid"`
 }
 
-func (t *fileStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName, uuid string) (err error) {
+func (t *playerStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := fileStopRequest{
+	request := playerStopTransportRequest{
 		PlayerIP:         playerIP,
-		PlayerPort:       playerPort,
 		PlayerDeviceName: playerDeviceName,
-		UUID:             uuid,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -114,42 +1484,44 @@ func (t *fileStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Req
 	return
 }
 
-func (t *fileStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+func (t *playerStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
 	}
 	return
 }
 
-// NewFileStopTransport ...
-func NewFileStopTransport(method, pathTemplate string) FileStopTransport {
-	return &fileStopTransport{
+// NewPlayerStopTransport ...
+func NewPlayerStopTransport(method, pathTemplate string) PlayerStopTransport {
+	return &playerStopTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
 }
 
-// PlayerStateTransport ...
-type PlayerStateTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP string) (err error)
-	DecodeResponse(ctx context.Context, res *fasthttp.Response) (ports, storages, devices []string, err error)
+// PlayerRewindTransport ...
+type PlayerRewindTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
-type playerStateTransport struct {
+type playerRewindTransport struct {
 	method       string
 	pathTemplate string
 }
 
-type playerStateRequest struct {
-	PlayerIP string `json:"playerIP"`
+type playerRewindRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
 }
 
-func (t *playerStateTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP string) (err error) {
+func (t *playerRewindTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := playerStateRequest{
-		PlayerIP: playerIP,
+	request := playerRewindRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -160,61 +1532,44 @@ func (t *playerStateTransport) EncodeRequest(ctx context.Context, req *fasthttp.
 	return
 }
 
-type playerStateResponse struct {
-	Ports    []string `json:"ports"`
-	Storages []string `json:"storages"`
-	Devices  []string `json:"devices"`
-}
-
-func (t *playerStateTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (ports, storages, devices []string, err error) {
+func (t *playerRewindTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
-		return
-	}
-
-	var response playerStateResponse
-	err = json.Unmarshal(res.Body(), &response)
-	if err != nil {
-		return
 	}
-
-	ports, storages, devices = response.Ports, response.Storages, response.Devices
 	return
 }
 
-// NewPlayerStateTransport ...
-func NewPlayerStateTransport(method, pathTemplate string) PlayerStateTransport {
-	return &playerStateTransport{
+// NewPlayerRewindTransport ...
+func NewPlayerRewindTransport(method, pathTemplate string) PlayerRewindTransport {
+	return &playerRewindTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
 }
 
-// PlayerReceiveStartTransport ...
-type PlayerReceiveStartTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string, uuid *string) (err error)
-	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error)
+// PlayerReplayTransport ...
+type PlayerReplayTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
-type playerReceiveStartTransport struct {
+type playerReplayTransport struct {
 	method       string
 	pathTemplate string
 }
 
-type playerReceiveStartRequest struct {
-	PlayerIP   string  `json:"playerIP"`
-	PlayerPort string  `json:"playerPort"`
-	UUID       *string `json:"uuid,omitempty"`
+type playerReplayRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
 }
 
-func (t *playerReceiveStartTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string, uuid *string) (err error) {
+func (t *playerReplayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := playerReceiveStartRequest{
-		PlayerIP:   playerIP,
-		PlayerPort: playerPort,
-		UUID:       uuid,
+	request := playerReplayRequest{
+		PlayerIP:         playerIP,
+		PlayerDeviceName: playerDeviceName,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -225,57 +1580,44 @@ func (t *playerReceiveStartTransport) EncodeRequest(ctx context.Context, req *fa
 	return
 }
 
-type playerReceiveStartResponse struct {
-	UUID string `json:"uuid"`
-}
-
-func (t *playerReceiveStartTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error) {
+func (t *playerReplayTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
-		return
-	}
-
-	var response playerReceiveStartResponse
-	err = json.Unmarshal(res.Body(), &response)
-	if err != nil {
-		return
 	}
-
-	uuid = response.UUID
 	return
 }
 
-// NewPlayerReceiveStartTransport ...
-func NewPlayerReceiveStartTransport(method, pathTemplate string) PlayerReceiveStartTransport {
-	return &playerReceiveStartTransport{
+// NewPlayerReplayTransport ...
+func NewPlayerReplayTransport(method, pathTemplate string) PlayerReplayTransport {
+	return &playerReplayTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
 }
 
-// PlayerReceiveStopTransport ...
-type PlayerReceiveStopTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error)
+// PlayerClearStorageTransport ...
+type PlayerClearStorageTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid string) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
-type playerReceiveStopTransport struct {
+type playerClearStorageTransport struct {
 	method       string
 	pathTemplate string
 }
 
-type playerReceiveStopRequest struct {
-	PlayerIP   string `json:"playerIP"`
-	PlayerPort string `json:"playerPort"`
+type playerClearStorageRequest struct {
+	PlayerIP string `json:"playerIP"`
+	UUID     string `json:"uuid"`
 }
 
-func (t *playerReceiveStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort string) (err error) {
+func (t *playerClearStorageTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := playerReceiveStopRequest{
-		PlayerIP:   playerIP,
-		PlayerPort: playerPort,
+	request := playerClearStorageRequest{
+		PlayerIP: playerIP,
+		UUID:     uuid,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -286,52 +1628,46 @@ func (t *playerReceiveStopTransport) EncodeRequest(ctx context.Context, req *fas
 	return
 }
 
-func (t *playerReceiveStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+func (t *playerClearStorageTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
 	}
 	return
 }
 
-// NewPlayerReceiveStopTransport ...
-func NewPlayerReceiveStopTransport(method, pathTemplate string) PlayerReceiveStopTransport {
-	return &playerReceiveStopTransport{
+// NewPlayerClearStorageTransport ...
+func NewPlayerClearStorageTransport(method, pathTemplate string) PlayerClearStorageTransport {
+	return &playerClearStorageTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
 }
 
-// PlayerPlayTransport ...
-type PlayerPlayTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32) (err error)
+// PlayerSetVolumeTransport ...
+type PlayerSetVolumeTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string, volume float32) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
-type playerPlayTransport struct {
+type playerSetVolumeTransport struct {
 	method       string
 	pathTemplate string
 }
 
-type playerPlayRequest struct {
-	PlayerIP         string `json:"playerIP"`
-	UUID             string `json:"uuid"`
-	PlayerDeviceName string `json:"playerDeviceName"`
-	Channels         uint32 `json:"channels"`
-	Rate             uint32 `json:"rate"`
-	BitsPerSample    uint32 `json:"bitsPerSample"`
+type playerSetVolumeRequest struct {
+	PlayerIP         string  `json:"playerIP"`
+	PlayerDeviceName string  `json:"playerDeviceName"`
+	Volume           float32 `json:"volume"`
 }
 
-func (t *playerPlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32) (err error) {
+func (t *playerSetVolumeTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string, volume float32) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := playerPlayRequest{
+	request := playerSetVolumeRequest{
 		PlayerIP:         playerIP,
-		UUID:             uuid,
 		PlayerDeviceName: playerDeviceName,
-		Channels:         channels,
-		Rate:             rate,
-		BitsPerSample:    bitsPerSample,
+		Volume:           volume,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -342,44 +1678,46 @@ func (t *playerPlayTransport) EncodeRequest(ctx context.Context, req *fasthttp.R
 	return
 }
 
-func (t *playerPlayTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+func (t *playerSetVolumeTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
 	}
 	return
 }
 
-// NewPlayerPlayTransport ...
-func NewPlayerPlayTransport(method, pathTemplate string) PlayerPlayTransport {
-	return &playerPlayTransport{
+// NewPlayerSetVolumeTransport ...
+func NewPlayerSetVolumeTransport(method, pathTemplate string) PlayerSetVolumeTransport {
+	return &playerSetVolumeTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
 }
 
-// PlayerStopTransport ...
-type PlayerStopTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error)
+// PlayerMuteTransport ...
+type PlayerMuteTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string, mute bool) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
-type playerStopTransport struct {
+type playerMuteTransport struct {
 	method       string
 	pathTemplate string
 }
 
-type playerStopTransportRequest struct {
+type playerMuteRequest struct {
 	PlayerIP         string `json:"playerIP"`
 	PlayerDeviceName string `json:"playerDeviceName"`
+	Mute             bool   `json:"mute"`
 }
 
-func (t *playerStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string) (err error) {
+func (t *playerMuteTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerDeviceName string, mute bool) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := playerStopTransportRequest{
+	request := playerMuteRequest{
 		PlayerIP:         playerIP,
 		PlayerDeviceName: playerDeviceName,
+		Mute:             mute,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -390,44 +1728,75 @@ func (t *playerStopTransport) EncodeRequest(ctx context.Context, req *fasthttp.R
 	return
 }
 
-func (t *playerStopTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+func (t *playerMuteTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
 	}
 	return
 }
 
-// NewPlayerStopTransport ...
-func NewPlayerStopTransport(method, pathTemplate string) PlayerStopTransport {
-	return &playerStopTransport{
+// NewPlayerMuteTransport ...
+func NewPlayerMuteTransport(method, pathTemplate string) PlayerMuteTransport {
+	return &playerMuteTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
 }
 
-// PlayerClearStorageTransport ...
-type PlayerClearStorageTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid string) (err error)
-	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
+type pcmDevice struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Rates       []int       `json:"rates"`
+	Channels    []int       `json:"channels"`
+	Formats     []pcmFormat `json:"formats"`
 }
 
-type playerClearStorageTransport struct {
+type pcmFormat struct {
+	BitsPerSample int `json:"bitsPerSample"`
+	AudioFormat   int `json:"audioFormat"`
+}
+
+func toPCMDevices(devices []pcmDevice) []pcm.Device {
+	pcmDevices := make([]pcm.Device, 0, len(devices))
+	for _, d := range devices {
+		device := pcm.Device{
+			Name:        d.Name,
+			Description: d.Description,
+			Rates:       d.Rates,
+			Channels:    d.Channels,
+		}
+		for _, f := range d.Formats {
+			device.Formats = append(device.Formats, pcm.Format{
+				BitsPerSample: f.BitsPerSample,
+				AudioFormat:   f.AudioFormat,
+			})
+		}
+		pcmDevices = append(pcmDevices, device)
+	}
+	return pcmDevices
+}
+
+// PlayerListDevicesTransport ...
+type PlayerListDevicesTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (devices []pcm.Device, err error)
+}
+
+type playerListDevicesTransport struct {
 	method       string
 	pathTemplate string
 }
 
-type playerClearStorageRequest struct {
+type playerListDevicesRequest struct {
 	PlayerIP string `json:"playerIP"`
-	UUID     string `json:"uuid"`
 }
 
-func (t *playerClearStorageTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, uuid string) (err error) {
+func (t *playerListDevicesTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
-	request := playerClearStorageRequest{
+	request := playerListDevicesRequest{
 		PlayerIP: playerIP,
-		UUID:     uuid,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -438,16 +1807,29 @@ func (t *playerClearStorageTransport) EncodeRequest(ctx context.Context, req *fa
 	return
 }
 
-func (t *playerClearStorageTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error) {
+type playerListDevicesResponse struct {
+	Devices []pcmDevice `json:"devices"`
+}
+
+func (t *playerListDevicesTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (devices []pcm.Device, err error) {
 	if res.StatusCode() != http.StatusOK {
 		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response playerListDevicesResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
 	}
+
+	devices = toPCMDevices(response.Devices)
 	return
 }
 
-// NewPlayerClearStorageTransport ...
-func NewPlayerClearStorageTransport(method, pathTemplate string) PlayerClearStorageTransport {
-	return &playerClearStorageTransport{
+// NewPlayerListDevicesTransport ...
+func NewPlayerListDevicesTransport(method, pathTemplate string) PlayerListDevicesTransport {
+	return &playerListDevicesTransport{
 		method:       method,
 		pathTemplate: pathTemplate,
 	}
@@ -455,7 +1837,7 @@ func NewPlayerClearStorageTransport(method, pathTemplate string) PlayerClearStor
 
 // StartFileRecordingTransport ...
 type StartFileRecordingTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string) (err error)
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
@@ -465,15 +1847,20 @@ type startFileRecordingTransport struct {
 }
 
 type startFileRecordingRequest struct {
-	RecorderIP         string `json:"playerIP"`
+	RecorderIP         string `json:"recorderIP"`
 	RecorderDeviceName string `json:"recorderDeviceName"`
 	Channels           uint32 `json:"channels"`
 	Rate               uint32 `json:"rate"`
+	BitsPerSample      uint32 `json:"bitsPerSample"`
+	AudioFormat        uint32 `json:"audioFormat"`
 	ReceivePort        string `json:"receivePort"`
 	File               string `json:"file"`
+	FileFormat         string `json:"fileFormat"`
+	SegmentDuration    int64  `json:"segmentDuration"`
+	SegmentSize        int64  `json:"segmentSize"`
 }
 
-func (t *startFileRecordingTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string) (err error) {
+func (t *startFileRecordingTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
@@ -482,8 +1869,13 @@ func (t *startFileRecordingTransport) EncodeRequest(ctx context.Context, req *fa
 		RecorderDeviceName: recorderDeviceName,
 		Channels:           channels,
 		Rate:               rate,
+		BitsPerSample:      bitsPerSample,
+		AudioFormat:        audioFormat,
 		ReceivePort:        receivePort,
 		File:               file,
+		FileFormat:         fileFormat,
+		SegmentDuration:    segmentDuration.Milliseconds(),
+		SegmentSize:        segmentSize,
 	}
 	body, err := json.Marshal(&request)
 	if err != nil {
@@ -561,7 +1953,7 @@ func NewStopFileRecordingTransport(method, pathTemplate string) StopFileRecordin
 
 // PlayFromRecorderTransport ...
 type PlayFromRecorderTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string) (err error)
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (uuid string, err error)
 }
 
@@ -576,11 +1968,13 @@ type playFromRecorderRequest struct {
 	PlayerDeviceName   string `json:"playerDeviceName"`
 	Channels           uint32 `json:"channels"`
 	Rate               uint32 `json:"rate"`
+	BitsPerSample      uint32 `json:"bitsPerSample"`
+	AudioFormat        uint32 `json:"audioFormat"`
 	RecorderIP         string `json:"recorderIP"`
 	RecorderDeviceName string `json:"recorderDeviceName"`
 }
 
-func (t *playFromRecorderTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string) (err error) {
+func (t *playFromRecorderTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
@@ -590,6 +1984,8 @@ func (t *playFromRecorderTransport) EncodeRequest(ctx context.Context, req *fast
 		PlayerDeviceName:   playerDeviceName,
 		Channels:           channels,
 		Rate:               rate,
+		BitsPerSample:      bitsPerSample,
+		AudioFormat:        audioFormat,
 		RecorderIP:         recorderIP,
 		RecorderDeviceName: recorderDeviceName,
 	}
@@ -747,7 +2143,7 @@ func NewRecorderStateTransport(method, pathTemplate string) RecorderStateTranspo
 
 // RecorderStartTransport ...
 type RecorderStartTransport interface {
-	EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string) (err error)
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string) (err error)
 	DecodeResponse(ctx context.Context, res *fasthttp.Response) (err error)
 }
 
@@ -761,10 +2157,12 @@ type recorderStartRequest struct {
 	RecorderDeviceName string `json:"recorderDeviceName"`
 	Channels           uint32 `json:"channels"`
 	Rate               uint32 `json:"rate"`
+	BitsPerSample      uint32 `json:"bitsPerSample"`
+	AudioFormat        uint32 `json:"audioFormat"`
 	DstAddr            string `json:"dstAddr"`
 }
 
-func (t *recorderStartTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string) (err error) {
+func (t *recorderStartTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string) (err error) {
 	req.Header.SetMethod(t.method)
 	req.SetRequestURI(t.pathTemplate)
 
@@ -773,6 +2171,8 @@ func (t *recorderStartTransport) EncodeRequest(ctx context.Context, req *fasthtt
 		RecorderDeviceName: recorderDeviceName,
 		Channels:           channels,
 		Rate:               rate,
+		BitsPerSample:      bitsPerSample,
+		AudioFormat:        audioFormat,
 		DstAddr:            dstAddr,
 	}
 	body, err := json.Marshal(&request)
@@ -846,3 +2246,125 @@ func NewRecorderStopTransport(method, pathTemplate string) RecorderStopTransport
 		pathTemplate: pathTemplate,
 	}
 }
+
+// RecorderListDevicesTransport ...
+type RecorderListDevicesTransport interface {
+	EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP string) (err error)
+	DecodeResponse(ctx context.Context, res *fasthttp.Response) (devices []pcm.Device, err error)
+}
+
+type recorderListDevicesTransport struct {
+	method       string
+	pathTemplate string
+}
+
+type recorderListDevicesRequest struct {
+	RecorderIP string `json:"recorderIP"`
+}
+
+func (t *recorderListDevicesTransport) EncodeRequest(ctx context.Context, req *fasthttp.Request, recorderIP string) (err error) {
+	req.Header.SetMethod(t.method)
+	req.SetRequestURI(t.pathTemplate)
+
+	request := recorderListDevicesRequest{
+		RecorderIP: recorderIP,
+	}
+	body, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	req.SetBody(body)
+	return
+}
+
+type recorderListDevicesResponse struct {
+	Devices []pcmDevice `json:"devices"`
+}
+
+func (t *recorderListDevicesTransport) DecodeResponse(ctx context.Context, res *fasthttp.Response) (devices []pcm.Device, err error) {
+	if res.StatusCode() != http.StatusOK {
+		err = fmt.Errorf(string(res.Body()))
+		return
+	}
+
+	var response recorderListDevicesResponse
+	err = json.Unmarshal(res.Body(), &response)
+	if err != nil {
+		return
+	}
+
+	devices = toPCMDevices(response.Devices)
+	return
+}
+
+// NewRecorderListDevicesTransport ...
+func NewRecorderListDevicesTransport(method, pathTemplate string) RecorderListDevicesTransport {
+	return &recorderListDevicesTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
+
+// EventsTransport ...
+type EventsTransport interface {
+	EncodeRequest(ctx context.Context) (req *http.Request, err error)
+	DecodeEvent(ctx context.Context, line []byte) (e event.Event, isEvent bool, err error)
+}
+
+type eventsTransport struct {
+	method       string
+	pathTemplate string
+}
+
+func (t *eventsTransport) EncodeRequest(ctx context.Context) (req *http.Request, err error) {
+	if req, err = http.NewRequest(t.method, t.pathTemplate, nil); err != nil {
+		return
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+	return
+}
+
+type eventResponse struct {
+	Source      string    `json:"source"`
+	IP          string    `json:"ip"`
+	Type        string    `json:"type"`
+	DeviceName  string    `json:"deviceName"`
+	StorageUUID string    `json:"storageUUID"`
+	Message     string    `json:"message"`
+	Time        time.Time `json:"time"`
+}
+
+// dataPrefix of line of server-sent event with data
+var dataPrefix = []byte("data: ")
+
+// DecodeEvent from line of server-sent events, only data lines contain events
+func (t *eventsTransport) DecodeEvent(ctx context.Context, line []byte) (e event.Event, isEvent bool, err error) {
+	if !bytes.HasPrefix(line, dataPrefix) {
+		return
+	}
+
+	var response eventResponse
+	if err = json.Unmarshal(line[len(dataPrefix):], &response); err != nil {
+		return
+	}
+	e = event.Event{
+		Source:      response.Source,
+		IP:          response.IP,
+		Type:        response.Type,
+		DeviceName:  response.DeviceName,
+		StorageUUID: response.StorageUUID,
+		Message:     response.Message,
+		Time:        response.Time,
+	}
+	return e, true, nil
+}
+
+// NewEventsTransport ...
+func NewEventsTransport(method, pathTemplate string) EventsTransport {
+	return &eventsTransport{
+		method:       method,
+		pathTemplate: pathTemplate,
+	}
+}
@@ -0,0 +1,94 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/valyala/fasthttp"
+)
+
+// HeaderAPIKey header of request with api key
+const HeaderAPIKey = "X-API-Key"
+
+// userValueRole user value of request with role of authorized api key
+const userValueRole = "role"
+
+var (
+	// ErrUnauthorized api key of request is missing or unknown
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden role of api key does not allow request
+	ErrForbidden = errors.New("forbidden")
+	// ErrUnknownRole name of role is not viewer, operator or admin
+	ErrUnknownRole = errors.New("unknown role")
+)
+
+// Role of api key, role has rights of lower roles
+type Role int
+
+const (
+	// RoleViewer can get state of players, recorders, playlists and schedule, list devices and subscribe on events
+	RoleViewer Role = iota + 1
+	// RoleOperator can play on players
+	RoleOperator
+	// RoleAdmin can record from recorders, mix signals of recorders and access profiling
+	RoleAdmin
+)
+
+var roles = map[string]Role{
+	"viewer":   RoleViewer,
+	"operator": RoleOperator,
+	"admin":    RoleAdmin,
+}
+
+// ParseRole by name: viewer, operator or admin
+func ParseRole(name string) (Role, error) {
+	role, isExist := roles[name]
+	if !isExist {
+		return 0, ErrUnknownRole
+	}
+	return role, nil
+}
+
+type middleware func(h fasthttp.RequestHandler) fasthttp.RequestHandler
+
+// authorize requests by api key in header HeaderAPIKey, role of key must be at least role.
+// Requests are not authorized if keys are empty.
+func authorize(keys map[string]Role, role Role) middleware {
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		if len(keys) == 0 {
+			return h
+		}
+		return func(ctx *fasthttp.RequestCtx) {
+			r, isExist := lookup(keys, ctx.Request.Header.Peek(HeaderAPIKey))
+			if !isExist {
+				ErrorProcessing(&ctx.Response, ErrUnauthorized, -1)
+				return
+			}
+			if r < role {
+				ErrorProcessing(&ctx.Response, ErrForbidden, -1)
+				return
+			}
+			ctx.SetUserValue(userValueRole, r)
+			h(ctx)
+		}
+	}
+}
+
+// hasRole check that role of authorized request is at least role, requests are not authorized if keys are empty
+func hasRole(ctx *fasthttp.RequestCtx, role Role) bool {
+	r, isExist := ctx.UserValue(userValueRole).(Role)
+	return !isExist || r >= role
+}
+
+// lookup role of key, keys are compared in constant time
+func lookup(keys map[string]Role, key []byte) (role Role, isExist bool) {
+	if len(key) == 0 {
+		return
+	}
+	for k, r := range keys {
+		if subtle.ConstantTimeCompare([]byte(k), key) == 1 {
+			role, isExist = r, true
+		}
+	}
+	return
+}
@@ -12,10 +12,51 @@ import (
 )
 
 const (
-	methodFilePlay = http.MethodPost
-	uriFilePlay    = "/player/file/play"
-	methodFileStop = http.MethodPost
-	uriFileStop    = "/player/file/stop"
+	methodFilePlay   = http.MethodPost
+	uriFilePlay      = "/player/file/play"
+	methodFileStop   = http.MethodPost
+	uriFileStop      = "/player/file/stop"
+	methodFilePause  = http.MethodPost
+	uriFilePause     = "/player/file/pause"
+	methodFileResume = http.MethodPost
+	uriFileResume    = "/player/file/resume"
+	methodFileSeek   = http.MethodPost
+	uriFileSeek      = "/player/file/seek"
+
+	methodGroupPlay = http.MethodPost
+	uriGroupPlay    = "/player/group/play"
+	methodGroupStop = http.MethodPost
+	uriGroupStop    = "/player/group/stop"
+
+	methodMulticastPlay = http.MethodPost
+	uriMulticastPlay    = "/player/multicast/play"
+	methodMulticastStop = http.MethodPost
+	uriMulticastStop    = "/player/multicast/stop"
+
+	methodPlaylistEnqueue = http.MethodPost
+	uriPlaylistEnqueue    = "/player/playlist/enqueue"
+	methodPlaylistSkip    = http.MethodPost
+	uriPlaylistSkip       = "/player/playlist/skip"
+	methodPlaylistClear   = http.MethodPost
+	uriPlaylistClear      = "/player/playlist/clear"
+	methodPlaylistMode    = http.MethodPost
+	uriPlaylistMode       = "/player/playlist/mode"
+	methodPlaylistState   = http.MethodGet
+	uriPlaylistState      = "/player/playlist/state"
+	methodPlaylistStop    = http.MethodPost
+	uriPlaylistStop       = "/player/playlist/stop"
+
+	methodScheduleAdd    = http.MethodPost
+	uriScheduleAdd       = "/player/schedule/add"
+	methodScheduleRemove = http.MethodPost
+	uriScheduleRemove    = "/player/schedule/remove"
+	methodScheduleList   = http.MethodGet
+	uriScheduleList      = "/player/schedule/list"
+
+	methodMixPlay = http.MethodPost
+	uriMixPlay    = "/player/mix/play"
+	methodMixStop = http.MethodPost
+	uriMixStop    = "/player/mix/stop"
 
 	methodPlayerState        = http.MethodGet
 	uriPlayerState           = "/player/state"
@@ -27,9 +68,20 @@ const (
 	uriPlayerPlay            = "/player/play"
 	methodPlayerStop         = http.MethodPost
 	uriPlayerStop            = "/player/stop"
+	methodPlayerRewind       = http.MethodPost
+	uriPlayerRewind          = "/player/rewind"
+	methodPlayerReplay       = http.MethodPost
+	uriPlayerReplay          = "/player/replay"
 	methodPlayerClearStorage = http.MethodPost
 	uriPlayerClearStorage    = "/player/clearstorage"
 
+	methodPlayerSetVolume   = http.MethodPost
+	uriPlayerSetVolume      = "/player/volume"
+	methodPlayerMute        = http.MethodPost
+	uriPlayerMute           = "/player/mute"
+	methodPlayerListDevices = http.MethodGet
+	uriPlayerListDevices    = "/player/devices"
+
 	methodStartFileRecording = http.MethodPost
 	uriStartFileRecording    = "/recoder/file/start"
 	methodStopFileRecording  = http.MethodPost
@@ -39,39 +91,81 @@ const (
 	methodStopFromRecorder   = http.MethodPost
 	uriStopFromRecorder      = "/recoder/player/stop"
 
-	methodRecorderState = http.MethodGet
-	uriRecorderState    = "/recorder/state"
-	methodRecorderStart = http.MethodPost
-	uriRecorderStart    = "/recoder/start"
-	methodRecorderStop  = http.MethodPost
-	uriRecorderStop     = "/recoder/stop"
+	methodRecorderState       = http.MethodGet
+	uriRecorderState          = "/recorder/state"
+	methodRecorderStart       = http.MethodPost
+	uriRecorderStart          = "/recoder/start"
+	methodRecorderStop        = http.MethodPost
+	uriRecorderStop           = "/recoder/stop"
+	methodRecorderListDevices = http.MethodGet
+	uriRecorderListDevices    = "/recorder/devices"
+
+	methodEvents = http.MethodGet
+	uriEvents    = "/events"
 )
 
-// NewServer return http server
-func NewServer(svc server.Server) *fasthttp.Server {
+// NewServer return http server.
+// keys are api keys with their roles, empty keys - requests are not authorized and profiling is not available.
+func NewServer(svc server.Server, keys map[string]Role) *fasthttp.Server {
 	router := fasthttprouter.New()
+	viewer, operator, admin := authorize(keys, RoleViewer), authorize(keys, RoleOperator), authorize(keys, RoleAdmin)
+
+	router.Handle(methodFilePlay, uriFilePlay, operator(filePlayHandler(svc, newFilePlayTransport(), ErrorProcessing)))
+	router.Handle(methodFileStop, uriFileStop, operator(fileStopHandler(svc, newFileStopTransport(), ErrorProcessing)))
+	router.Handle(methodFilePause, uriFilePause, operator(filePauseHandler(svc, newFilePauseTransport(), ErrorProcessing)))
+	router.Handle(methodFileResume, uriFileResume, operator(fileResumeHandler(svc, newFileResumeTransport(), ErrorProcessing)))
+	router.Handle(methodFileSeek, uriFileSeek, operator(fileSeekHandler(svc, newFileSeekTransport(), ErrorProcessing)))
 
-	router.Handle(methodFilePlay, uriFilePlay, filePlayHandler(svc, newFilePlayTransport(), ErrorProcessing))
-	router.Handle(methodFileStop, uriFileStop, fileStopHandler(svc, newFileStopTransport(), ErrorProcessing))
+	router.Handle(methodGroupPlay, uriGroupPlay, operator(groupPlayHandler(svc, newGroupPlayTransport(), ErrorProcessing)))
+	router.Handle(methodGroupStop, uriGroupStop, operator(groupStopHandler(svc, newGroupStopTransport(), ErrorProcessing)))
 
-	router.Handle(methodPlayerState, uriPlayerState, playerStateHandler(svc, newPlayerStateTransport(), ErrorProcessing))
-	router.Handle(methodPlayerReceiveStart, uriPlayerReceiveStart, playerReceiveStartHandler(svc, newPlayerReceiveStartTransport(), ErrorProcessing))
-	router.Handle(methodPlayerReceiveStop, uriPlayerReceiveStop, playerReceiveStopHandler(svc, newPlayerReceiveStopTransport(), ErrorProcessing))
-	router.Handle(methodPlayerPlay, uriPlayerPlay, playerPlayHandler(svc, newPlayerPlayTransport(), ErrorProcessing))
-	router.Handle(methodPlayerStop, uriPlayerStop, playerStopHandler(svc, newPlayerStopTransport(), ErrorProcessing))
-	router.Handle(methodPlayerClearStorage, uriPlayerClearStorage, playerClearStorageHandler(svc, newPlayerClearStorageTransport(), ErrorProcessing))
+	router.Handle(methodMulticastPlay, uriMulticastPlay, operator(multicastPlayHandler(svc, newMulticastPlayTransport(), ErrorProcessing)))
+	router.Handle(methodMulticastStop, uriMulticastStop, operator(multicastStopHandler(svc, newMulticastStopTransport(), ErrorProcessing)))
 
-	router.Handle(methodStartFileRecording, uriStartFileRecording, startFileRecordingHandler(svc, newStartFileRecordingTransport(), ErrorProcessing))
-	router.Handle(methodStopFileRecording, uriStopFileRecording, stopFileRecordingHandler(svc, newStopFileRecordingTransport(), ErrorProcessing))
-	router.Handle(methodPlayFromRecorder, uriPlayFromRecorder, playFromRecorderHandler(svc, newPlayFromRecorderTransport(), ErrorProcessing))
-	router.Handle(methodStopFromRecorder, uriStopFromRecorder, stopFromRecorderHandler(svc, newStopFromRecorderTransport(), ErrorProcessing))
+	router.Handle(methodPlaylistEnqueue, uriPlaylistEnqueue, operator(playlistEnqueueHandler(svc, newPlaylistEnqueueTransport(), ErrorProcessing)))
+	router.Handle(methodPlaylistSkip, uriPlaylistSkip, operator(playlistSkipHandler(svc, newPlaylistSkipTransport(), ErrorProcessing)))
+	router.Handle(methodPlaylistClear, uriPlaylistClear, operator(playlistClearHandler(svc, newPlaylistClearTransport(), ErrorProcessing)))
+	router.Handle(methodPlaylistMode, uriPlaylistMode, operator(playlistModeHandler(svc, newPlaylistModeTransport(), ErrorProcessing)))
+	router.Handle(methodPlaylistState, uriPlaylistState, viewer(playlistStateHandler(svc, newPlaylistStateTransport(), ErrorProcessing)))
+	router.Handle(methodPlaylistStop, uriPlaylistStop, operator(playlistStopHandler(svc, newPlaylistStopTransport(), ErrorProcessing)))
 
-	router.Handle(methodRecorderState, uriRecorderState, recorderStateHandler(svc, newRecorderStateTransport(), ErrorProcessing))
-	router.Handle(methodRecorderStart, uriRecorderStart, recorderStartHandler(svc, newRecorderStartTransport(), ErrorProcessing))
-	router.Handle(methodRecorderStop, uriRecorderStop, recorderStopHandler(svc, newRecorderStopTransport(), ErrorProcessing))
+	router.Handle(methodScheduleAdd, uriScheduleAdd, operator(scheduleAddHandler(svc, newScheduleAddTransport(), ErrorProcessing)))
+	router.Handle(methodScheduleRemove, uriScheduleRemove, operator(scheduleRemoveHandler(svc, newScheduleRemoveTransport(), ErrorProcessing)))
+	router.Handle(methodScheduleList, uriScheduleList, viewer(scheduleListHandler(svc, newScheduleListTransport(), ErrorProcessing)))
 
-	router.Handle("GET", "/debug/pprof/", fasthttpadaptor.NewFastHTTPHandlerFunc(pprof.Index))
-	router.Handle("GET", "/debug/pprof/profile", fasthttpadaptor.NewFastHTTPHandlerFunc(pprof.Profile))
+	router.Handle(methodMixPlay, uriMixPlay, operator(mixPlayHandler(svc, newMixPlayTransport(), ErrorProcessing)))
+	router.Handle(methodMixStop, uriMixStop, operator(mixStopHandler(svc, newMixStopTransport(), ErrorProcessing)))
+
+	router.Handle(methodPlayerState, uriPlayerState, viewer(playerStateHandler(svc, newPlayerStateTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerReceiveStart, uriPlayerReceiveStart, operator(playerReceiveStartHandler(svc, newPlayerReceiveStartTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerReceiveStop, uriPlayerReceiveStop, operator(playerReceiveStopHandler(svc, newPlayerReceiveStopTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerPlay, uriPlayerPlay, operator(playerPlayHandler(svc, newPlayerPlayTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerStop, uriPlayerStop, operator(playerStopHandler(svc, newPlayerStopTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerRewind, uriPlayerRewind, operator(playerRewindHandler(svc, newPlayerRewindTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerReplay, uriPlayerReplay, operator(playerReplayHandler(svc, newPlayerReplayTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerClearStorage, uriPlayerClearStorage, operator(playerClearStorageHandler(svc, newPlayerClearStorageTransport(), ErrorProcessing)))
+
+	router.Handle(methodPlayerSetVolume, uriPlayerSetVolume, operator(playerSetVolumeHandler(svc, newPlayerSetVolumeTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerMute, uriPlayerMute, operator(playerMuteHandler(svc, newPlayerMuteTransport(), ErrorProcessing)))
+	router.Handle(methodPlayerListDevices, uriPlayerListDevices, viewer(playerListDevicesHandler(svc, newPlayerListDevicesTransport(), ErrorProcessing)))
+
+	router.Handle(methodStartFileRecording, uriStartFileRecording, admin(startFileRecordingHandler(svc, newStartFileRecordingTransport(), ErrorProcessing)))
+	router.Handle(methodStopFileRecording, uriStopFileRecording, admin(stopFileRecordingHandler(svc, newStopFileRecordingTransport(), ErrorProcessing)))
+	router.Handle(methodPlayFromRecorder, uriPlayFromRecorder, admin(playFromRecorderHandler(svc, newPlayFromRecorderTransport(), ErrorProcessing)))
+	router.Handle(methodStopFromRecorder, uriStopFromRecorder, admin(stopFromRecorderHandler(svc, newStopFromRecorderTransport(), ErrorProcessing)))
+
+	router.Handle(methodRecorderState, uriRecorderState, viewer(recorderStateHandler(svc, newRecorderStateTransport(), ErrorProcessing)))
+	router.Handle(methodRecorderStart, uriRecorderStart, admin(recorderStartHandler(svc, newRecorderStartTransport(), ErrorProcessing)))
+	router.Handle(methodRecorderStop, uriRecorderStop, admin(recorderStopHandler(svc, newRecorderStopTransport(), ErrorProcessing)))
+	router.Handle(methodRecorderListDevices, uriRecorderListDevices, viewer(recorderListDevicesHandler(svc, newRecorderListDevicesTransport(), ErrorProcessing)))
+
+	router.Handle(methodEvents, uriEvents, viewer(eventsHandler(svc, newEventsTransport(), ErrorProcessing)))
+
+	// profiling is not open to everyone
+	if len(keys) != 0 {
+		router.Handle("GET", "/debug/pprof/", admin(fasthttpadaptor.NewFastHTTPHandlerFunc(pprof.Index)))
+		router.Handle("GET", "/debug/pprof/profile", admin(fasthttpadaptor.NewFastHTTPHandlerFunc(pprof.Profile)))
+	}
 
 	return &fasthttp.Server{
 		Handler: router.Handler,
@@ -5,14 +5,34 @@ import (
 
 	"github.com/valyala/fasthttp"
 
+	"audio-service/pkg/audio"
+	"audio-service/pkg/cron"
+	"audio-service/pkg/flac"
 	"audio-service/pkg/server"
 )
 
 const (
-	codeDeviceIsBusy   = http.StatusInternalServerError
-	codeDeviceNotFound = http.StatusNotFound
-	codePortIsBusy     = http.StatusInternalServerError
-	codePortNotFound   = http.StatusNotFound
+	codeDeviceIsBusy     = http.StatusInternalServerError
+	codeDeviceNotFound   = http.StatusNotFound
+	codePortIsBusy       = http.StatusInternalServerError
+	codePortNotFound     = http.StatusNotFound
+	codeFileNotFound     = http.StatusNotFound
+	codeFileState        = http.StatusConflict
+	codeWrongPosition    = http.StatusBadRequest
+	codeNoPlayers        = http.StatusBadRequest
+	codeNotMulticast     = http.StatusBadRequest
+	codePlaylistNotFound = http.StatusNotFound
+	codePlaylistIsEmpty  = http.StatusBadRequest
+	codeMixNotFound      = http.StatusNotFound
+	codeJobNotFound      = http.StatusNotFound
+	codeWrongSchedule    = http.StatusBadRequest
+	codeNoScheduler      = http.StatusNotImplemented
+	codeUnknownFormat    = http.StatusUnsupportedMediaType
+	codeFormatNotSupport = http.StatusBadRequest
+	codeFormatMismatch   = http.StatusBadRequest
+	codeCodecMismatch    = http.StatusConflict
+	codeUnauthorized     = http.StatusUnauthorized
+	codeForbidden        = http.StatusForbidden
 )
 
 type errorProcessing func(res *fasthttp.Response, err error, statusCode int)
@@ -31,6 +51,40 @@ func ErrorProcessing(res *fasthttp.Response, err error, statusCode int) {
 		res.SetStatusCode(codePortIsBusy)
 	case server.ErrPortNotFound:
 		res.SetStatusCode(codePortNotFound)
+	case server.ErrFileNotFound:
+		res.SetStatusCode(codeFileNotFound)
+	case server.ErrFileIsPaused, server.ErrFileNotPaused:
+		res.SetStatusCode(codeFileState)
+	case server.ErrFormatMismatch:
+		res.SetStatusCode(codeFormatMismatch)
+	case server.ErrCodecMismatch:
+		res.SetStatusCode(codeCodecMismatch)
+	case server.ErrWrongPosition:
+		res.SetStatusCode(codeWrongPosition)
+	case server.ErrNoPlayers:
+		res.SetStatusCode(codeNoPlayers)
+	case server.ErrNotMulticast:
+		res.SetStatusCode(codeNotMulticast)
+	case server.ErrPlaylistNotFound:
+		res.SetStatusCode(codePlaylistNotFound)
+	case server.ErrPlaylistIsEmpty:
+		res.SetStatusCode(codePlaylistIsEmpty)
+	case server.ErrMixNotFound:
+		res.SetStatusCode(codeMixNotFound)
+	case server.ErrSchedulerDisabled:
+		res.SetStatusCode(codeNoScheduler)
+	case cron.ErrJobNotFound:
+		res.SetStatusCode(codeJobNotFound)
+	case cron.ErrWrongExpression, cron.ErrWrongSchedule:
+		res.SetStatusCode(codeWrongSchedule)
+	case audio.ErrUnknownFormat:
+		res.SetStatusCode(codeUnknownFormat)
+	case flac.ErrFormatNotSupported:
+		res.SetStatusCode(codeFormatNotSupport)
+	case ErrUnauthorized:
+		res.SetStatusCode(codeUnauthorized)
+	case ErrForbidden:
+		res.SetStatusCode(codeForbidden)
 	default:
 		res.SetStatusCode(http.StatusInternalServerError)
 	}
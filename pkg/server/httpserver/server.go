@@ -1,44 +1,683 @@
 package httpserver
 
 import (
+	"bufio"
 	"net/http"
+	"time"
 
 	"github.com/valyala/fasthttp"
 
+	"audio-service/pkg/pcm"
 	"audio-service/pkg/server"
 )
 
+// eventsPing interval of comments in stream of events, it detects closed connections
+const eventsPing = 15 * time.Second
+
 type filePlay struct {
 	svc             server.Server
-	transport       FilePlayTransport
+	transport       FilePlayTransport
+	errorProcessing errorProcessing
+}
+
+func (s *filePlay) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                                                error
+		file, playerIP, playerPort, playerDeviceName, uuid string
+		channels, bitsPerSample, dstChannels               uint16
+		rate, dstRate                                      uint32
+	)
+	if file, playerIP, playerPort, playerDeviceName, dstChannels, dstRate, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if uuid, channels, rate, bitsPerSample, err = s.svc.FilePlay(ctx, file, playerIP, playerPort, playerDeviceName, dstChannels, dstRate); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, uuid, channels, rate, bitsPerSample); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func filePlayHandler(svc server.Server, transport FilePlayTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &filePlay{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type fileStop struct {
+	svc             server.Server
+	transport       FileStopTransport
+	errorProcessing errorProcessing
+}
+
+func (s *fileStop) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                                          error
+		playerIP, playerPort, playerDeviceName, uuid string
+	)
+	if playerIP, playerPort, playerDeviceName, uuid, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.FileStop(ctx, playerIP, playerPort, playerDeviceName, uuid); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func fileStopHandler(svc server.Server, transport FileStopTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &fileStop{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type filePause struct {
+	svc             server.Server
+	transport       FilePauseTransport
+	errorProcessing errorProcessing
+}
+
+func (s *filePause) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                  error
+		playerIP, playerPort string
+		position             time.Duration
+	)
+	if playerIP, playerPort, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if position, err = s.svc.FilePause(ctx, playerIP, playerPort); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, position); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func filePauseHandler(svc server.Server, transport FilePauseTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &filePause{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type fileResume struct {
+	svc             server.Server
+	transport       FileResumeTransport
+	errorProcessing errorProcessing
+}
+
+func (s *fileResume) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                  error
+		playerIP, playerPort string
+	)
+	if playerIP, playerPort, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.FileResume(ctx, playerIP, playerPort); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func fileResumeHandler(svc server.Server, transport FileResumeTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &fileResume{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type fileSeek struct {
+	svc             server.Server
+	transport       FileSeekTransport
+	errorProcessing errorProcessing
+}
+
+func (s *fileSeek) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                  error
+		playerIP, playerPort string
+		position             time.Duration
+	)
+	if playerIP, playerPort, position, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.FileSeek(ctx, playerIP, playerPort, position); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func fileSeekHandler(svc server.Server, transport FileSeekTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &fileSeek{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type groupPlay struct {
+	svc             server.Server
+	transport       GroupPlayTransport
+	errorProcessing errorProcessing
+}
+
+func (s *groupPlay) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err         error
+		file        string
+		players     []server.PlayerDevice
+		dstChannels uint16
+		dstRate     uint32
+		uuids       []string
+		startAt     time.Time
+	)
+	if file, players, dstChannels, dstRate, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if uuids, startAt, err = s.svc.GroupPlay(ctx, file, players, dstChannels, dstRate); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, uuids, startAt); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func groupPlayHandler(svc server.Server, transport GroupPlayTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &groupPlay{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type groupStop struct {
+	svc             server.Server
+	transport       GroupStopTransport
+	errorProcessing errorProcessing
+}
+
+func (s *groupStop) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err     error
+		players []server.PlayerDevice
+	)
+	if players, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.GroupStop(ctx, players); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func groupStopHandler(svc server.Server, transport GroupStopTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &groupStop{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type multicastPlay struct {
+	svc             server.Server
+	transport       MulticastPlayTransport
+	errorProcessing errorProcessing
+}
+
+func (s *multicastPlay) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err             error
+		file, groupAddr string
+		players         []server.PlayerDevice
+		dstChannels     uint16
+		dstRate         uint32
+		uuids           []string
+		startAt         time.Time
+	)
+	if file, groupAddr, players, dstChannels, dstRate, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if uuids, startAt, err = s.svc.MulticastPlay(ctx, file, groupAddr, players, dstChannels, dstRate); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, uuids, startAt); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func multicastPlayHandler(svc server.Server, transport MulticastPlayTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &multicastPlay{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type multicastStop struct {
+	svc             server.Server
+	transport       MulticastStopTransport
+	errorProcessing errorProcessing
+}
+
+func (s *multicastStop) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err       error
+		groupAddr string
+	)
+	if groupAddr, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.MulticastStop(ctx, groupAddr); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func multicastStopHandler(svc server.Server, transport MulticastStopTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &multicastStop{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playlistEnqueue struct {
+	svc             server.Server
+	transport       PlaylistEnqueueTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playlistEnqueue) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                                          error
+		playerIP, playerPort, playerDeviceName, uuid string
+		files                                        []string
+		channels, rate                               uint32
+	)
+	if playerIP, playerPort, playerDeviceName, files, channels, rate, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if uuid, err = s.svc.PlaylistEnqueue(ctx, playerIP, playerPort, playerDeviceName, files, channels, rate); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, uuid); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playlistEnqueueHandler(svc server.Server, transport PlaylistEnqueueTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playlistEnqueue{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playlistSkip struct {
+	svc             server.Server
+	transport       PlaylistSkipTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playlistSkip) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+	)
+	if playerIP, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlaylistSkip(ctx, playerIP, playerDeviceName); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playlistSkipHandler(svc server.Server, transport PlaylistSkipTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playlistSkip{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playlistClear struct {
+	svc             server.Server
+	transport       PlaylistClearTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playlistClear) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+	)
+	if playerIP, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlaylistClear(ctx, playerIP, playerDeviceName); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playlistClearHandler(svc server.Server, transport PlaylistClearTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playlistClear{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playlistMode struct {
+	svc             server.Server
+	transport       PlaylistModeTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playlistMode) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+		loop, shuffle              bool
+	)
+	if playerIP, playerDeviceName, loop, shuffle, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlaylistMode(ctx, playerIP, playerDeviceName, loop, shuffle); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playlistModeHandler(svc server.Server, transport PlaylistModeTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playlistMode{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playlistState struct {
+	svc             server.Server
+	transport       PlaylistStateTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playlistState) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+		files                      []string
+		current                    int
+		loop, shuffle              bool
+	)
+	if playerIP, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if files, current, loop, shuffle, err = s.svc.PlaylistState(ctx, playerIP, playerDeviceName); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, files, current, loop, shuffle); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playlistStateHandler(svc server.Server, transport PlaylistStateTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playlistState{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playlistStop struct {
+	svc             server.Server
+	transport       PlaylistStopTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playlistStop) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+	)
+	if playerIP, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlaylistStop(ctx, playerIP, playerDeviceName); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playlistStopHandler(svc server.Server, transport PlaylistStopTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playlistStop{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type scheduleAdd struct {
+	svc             server.Server
+	transport       ScheduleAddTransport
+	errorProcessing errorProcessing
+}
+
+func (s *scheduleAdd) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err            error
+		spec, file, id string
+		at, nextRun    time.Time
+		players        []server.PlayerDevice
+		duration       time.Duration
+	)
+	if spec, at, file, players, duration, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if id, nextRun, err = s.svc.ScheduleAdd(ctx, spec, at, file, players, duration); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, id, nextRun); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func scheduleAddHandler(svc server.Server, transport ScheduleAddTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &scheduleAdd{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type scheduleRemove struct {
+	svc             server.Server
+	transport       ScheduleRemoveTransport
+	errorProcessing errorProcessing
+}
+
+func (s *scheduleRemove) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err error
+		id  string
+	)
+	if id, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.ScheduleRemove(ctx, id); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func scheduleRemoveHandler(svc server.Server, transport ScheduleRemoveTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &scheduleRemove{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type scheduleList struct {
+	svc             server.Server
+	transport       ScheduleListTransport
 	errorProcessing errorProcessing
 }
 
-func (s *filePlay) handler(ctx *fasthttp.RequestCtx) {
+func (s *scheduleList) handler(ctx *fasthttp.RequestCtx) {
 	var (
-		err                                                error
-		file, playerIP, playerPort, playerDeviceName, uuid string
-		channels, bitsPerSample                            uint16
-		rate                                               uint32
+		err  error
+		jobs []server.ScheduleJob
 	)
-	if file, playerIP, playerPort, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+	if err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if uuid, channels, rate, bitsPerSample, err = s.svc.FilePlay(ctx, file, playerIP, playerPort, playerDeviceName); err != nil {
+	if jobs, err = s.svc.ScheduleList(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
 
-	if err = s.transport.EncodeResponse(&ctx.Response, uuid, channels, rate, bitsPerSample); err != nil {
+	if err = s.transport.EncodeResponse(&ctx.Response, jobs); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
 		return
 	}
 }
 
-func filePlayHandler(svc server.Server, transport FilePlayTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
-	s := &filePlay{
+func scheduleListHandler(svc server.Server, transport ScheduleListTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &scheduleList{
 		svc:             svc,
 		transport:       transport,
 		errorProcessing: errorProcessing,
@@ -46,23 +685,70 @@ func filePlayHandler(svc server.Server, transport FilePlayTransport, errorProces
 	return s.handler
 }
 
-type fileStop struct {
+type mixPlay struct {
 	svc             server.Server
-	transport       FileStopTransport
+	transport       MixPlayTransport
 	errorProcessing errorProcessing
 }
 
-func (s *fileStop) handler(ctx *fasthttp.RequestCtx) {
+func (s *mixPlay) handler(ctx *fasthttp.RequestCtx) {
 	var (
 		err                                          error
+		sources                                      []server.MixSource
 		playerIP, playerPort, playerDeviceName, uuid string
+		channels, rate                               uint32
 	)
-	if playerIP, playerPort, playerDeviceName, uuid, err = s.transport.DecodeRequest(ctx); err != nil {
+	if sources, playerIP, playerPort, playerDeviceName, channels, rate, err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if err = s.svc.FileStop(ctx, playerIP, playerPort, playerDeviceName, uuid); err != nil {
+	// signal of recorder is recorded, so mixing with recorders requires admin
+	for _, source := range sources {
+		if source.RecorderIP != "" && !hasRole(ctx, RoleAdmin) {
+			s.errorProcessing(&ctx.Response, ErrForbidden, -1)
+			return
+		}
+	}
+
+	if uuid, err = s.svc.MixPlay(ctx, sources, playerIP, playerPort, playerDeviceName, channels, rate); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, uuid); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func mixPlayHandler(svc server.Server, transport MixPlayTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &mixPlay{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type mixStop struct {
+	svc             server.Server
+	transport       MixStopTransport
+	errorProcessing errorProcessing
+}
+
+// handler of mix stop does not check role for recorders, server stops only recorders started by the mix
+func (s *mixStop) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerPort, uuid string
+	)
+	if playerIP, playerPort, uuid, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.MixStop(ctx, playerIP, playerPort, uuid); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
@@ -73,8 +759,8 @@ func (s *fileStop) handler(ctx *fasthttp.RequestCtx) {
 	}
 }
 
-func fileStopHandler(svc server.Server, transport FileStopTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
-	s := &fileStop{
+func mixStopHandler(svc server.Server, transport MixStopTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &mixStop{
 		svc:             svc,
 		transport:       transport,
 		errorProcessing: errorProcessing,
@@ -130,13 +816,14 @@ func (s *playerReceiveStart) handler(ctx *fasthttp.RequestCtx) {
 		err                         error
 		playerIP, playerPort, sUUID string
 		uuid                        *string
+		replay                      bool
 	)
-	if playerIP, playerPort, uuid, err = s.transport.DecodeRequest(ctx); err != nil {
+	if playerIP, playerPort, uuid, replay, err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if sUUID, err = s.svc.PlayerReceiveStart(ctx, playerIP, playerPort, uuid); err != nil {
+	if sUUID, err = s.svc.PlayerReceiveStart(ctx, playerIP, playerPort, uuid, replay); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
@@ -200,16 +887,16 @@ type playerPlay struct {
 
 func (s *playerPlay) handler(ctx *fasthttp.RequestCtx) {
 	var (
-		err                              error
-		playerIP, uuid, playerDeviceName string
-		channels, rate, bitsPerSample    uint32
+		err                                        error
+		playerIP, uuid, playerDeviceName           string
+		channels, rate, bitsPerSample, audioFormat uint32
 	)
-	if playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, err = s.transport.DecodeRequest(ctx); err != nil {
+	if playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, audioFormat, err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if err = s.svc.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample); err != nil {
+	if err = s.svc.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, audioFormat); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
@@ -265,6 +952,78 @@ func playerStopHandler(svc server.Server, transport PlayerStopTransport, errorPr
 	return s.handler
 }
 
+type playerRewind struct {
+	svc             server.Server
+	transport       PlayerRewindTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playerRewind) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+	)
+	if playerIP, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlayerRewind(ctx, playerIP, playerDeviceName); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playerRewindHandler(svc server.Server, transport PlayerRewindTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playerRewind{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playerReplay struct {
+	svc             server.Server
+	transport       PlayerReplayTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playerReplay) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+	)
+	if playerIP, playerDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlayerReplay(ctx, playerIP, playerDeviceName); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playerReplayHandler(svc server.Server, transport PlayerReplayTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playerReplay{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
 type playerClearStorage struct {
 	svc             server.Server
 	transport       PlayerClearStorageTransport
@@ -301,6 +1060,117 @@ func playerClearStorageHandler(svc server.Server, transport PlayerClearStorageTr
 	return s.handler
 }
 
+type playerSetVolume struct {
+	svc             server.Server
+	transport       PlayerSetVolumeTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playerSetVolume) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+		volume                     float32
+	)
+	if playerIP, playerDeviceName, volume, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlayerSetVolume(ctx, playerIP, playerDeviceName, volume); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playerSetVolumeHandler(svc server.Server, transport PlayerSetVolumeTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playerSetVolume{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playerMute struct {
+	svc             server.Server
+	transport       PlayerMuteTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playerMute) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err                        error
+		playerIP, playerDeviceName string
+		mute                       bool
+	)
+	if playerIP, playerDeviceName, mute, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.svc.PlayerMute(ctx, playerIP, playerDeviceName, mute); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playerMuteHandler(svc server.Server, transport PlayerMuteTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playerMute{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type playerListDevices struct {
+	svc             server.Server
+	transport       PlayerListDevicesTransport
+	errorProcessing errorProcessing
+}
+
+func (s *playerListDevices) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err      error
+		playerIP string
+		devices  []pcm.Device
+	)
+	if playerIP, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if devices, err = s.svc.PlayerListDevices(ctx, playerIP); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, devices); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func playerListDevicesHandler(svc server.Server, transport PlayerListDevicesTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &playerListDevices{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
 type startFileRecording struct {
 	svc             server.Server
 	transport       StartFileRecordingTransport
@@ -309,16 +1179,18 @@ type startFileRecording struct {
 
 func (s *startFileRecording) handler(ctx *fasthttp.RequestCtx) {
 	var (
-		err                                               error
-		recorderIP, recorderDeviceName, receivePort, file string
-		channels, rate                                    uint32
+		err                                                           error
+		recorderIP, recorderDeviceName, receivePort, file, fileFormat string
+		channels, rate, bitsPerSample, audioFormat                    uint32
+		segmentDuration                                               time.Duration
+		segmentSize                                                   int64
 	)
-	if recorderIP, recorderDeviceName, channels, rate, receivePort, file, err = s.transport.DecodeRequest(ctx); err != nil {
+	if recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, receivePort, file, fileFormat, segmentDuration, segmentSize, err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if err = s.svc.StartFileRecording(ctx, recorderIP, recorderDeviceName, channels, rate, receivePort, file); err != nil {
+	if err = s.svc.StartFileRecording(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, receivePort, file, fileFormat, segmentDuration, segmentSize); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
@@ -384,14 +1256,14 @@ func (s *playFromRecorder) handler(ctx *fasthttp.RequestCtx) {
 	var (
 		err                                                                          error
 		playerIP, playerPort, playerDeviceName, recorderIP, recorderDeviceName, uuid string
-		channels, rate                                                               uint32
+		channels, rate, bitsPerSample, audioFormat                                   uint32
 	)
-	if playerIP, playerPort, playerDeviceName, channels, rate, recorderIP, recorderDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
+	if playerIP, playerPort, playerDeviceName, channels, rate, bitsPerSample, audioFormat, recorderIP, recorderDeviceName, err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if uuid, err = s.svc.PlayFromRecorder(ctx, playerIP, playerPort, playerDeviceName, channels, rate, recorderIP, recorderDeviceName); err != nil {
+	if uuid, err = s.svc.PlayFromRecorder(ctx, playerIP, playerPort, playerDeviceName, channels, rate, bitsPerSample, audioFormat, recorderIP, recorderDeviceName); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
@@ -492,16 +1364,16 @@ type recorderStart struct {
 
 func (s *recorderStart) handler(ctx *fasthttp.RequestCtx) {
 	var (
-		err                                     error
-		recorderIP, recorderDeviceName, dstAddr string
-		channels, rate                          uint32
+		err                                        error
+		recorderIP, recorderDeviceName, dstAddr    string
+		channels, rate, bitsPerSample, audioFormat uint32
 	)
-	if recorderIP, recorderDeviceName, channels, rate, dstAddr, err = s.transport.DecodeRequest(ctx); err != nil {
+	if recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, dstAddr, err = s.transport.DecodeRequest(ctx); err != nil {
 		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
 		return
 	}
 
-	if err = s.svc.RecorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, dstAddr); err != nil {
+	if err = s.svc.RecorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, dstAddr); err != nil {
 		s.errorProcessing(&ctx.Response, err, -1)
 		return
 	}
@@ -556,3 +1428,96 @@ func recorderStopHandler(svc server.Server, transport RecorderStopTransport, err
 	}
 	return s.handler
 }
+
+type recorderListDevices struct {
+	svc             server.Server
+	transport       RecorderListDevicesTransport
+	errorProcessing errorProcessing
+}
+
+func (s *recorderListDevices) handler(ctx *fasthttp.RequestCtx) {
+	var (
+		err        error
+		recorderIP string
+		devices    []pcm.Device
+	)
+	if recorderIP, err = s.transport.DecodeRequest(ctx); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if devices, err = s.svc.RecorderListDevices(ctx, recorderIP); err != nil {
+		s.errorProcessing(&ctx.Response, err, -1)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response, devices); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func recorderListDevicesHandler(svc server.Server, transport RecorderListDevicesTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &recorderListDevices{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
+
+type events struct {
+	svc             server.Server
+	transport       EventsTransport
+	errorProcessing errorProcessing
+}
+
+// handler stream events of players and recorders as server-sent events, events are filtered by source and ip if they are set
+func (s *events) handler(ctx *fasthttp.RequestCtx) {
+	source, ip, err := s.transport.DecodeRequest(ctx)
+	if err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = s.transport.EncodeResponse(&ctx.Response); err != nil {
+		s.errorProcessing(&ctx.Response, err, http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel := s.svc.Events(ctx)
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		ping := time.NewTicker(eventsPing)
+		defer ping.Stop()
+
+		for {
+			select {
+			case e, isOpen := <-events:
+				if !isOpen {
+					return
+				}
+				if (source != "" && e.Source != source) || (ip != "" && e.IP != ip) {
+					continue
+				}
+				if err := s.transport.EncodeEvent(w, e); err != nil {
+					return
+				}
+			case <-ping.C:
+				if err := s.transport.EncodePing(w); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+func eventsHandler(svc server.Server, transport EventsTransport, errorProcessing errorProcessing) fasthttp.RequestHandler {
+	s := &events{
+		svc:             svc,
+		transport:       transport,
+		errorProcessing: errorProcessing,
+	}
+	return s.handler
+}
@@ -1,46 +1,699 @@
 package httpserver
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/valyala/fasthttp"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
+	"audio-service/pkg/server"
 )
 
-// FilePlayTransport ...
-type FilePlayTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (file, playerIP, playerPort, playerDeviceName string, err error)
-	EncodeResponse(res *fasthttp.Response, uuid string, channels uint16, rate uint32, bitsPerSample uint16) (err error)
+// FilePlayTransport ...
+type FilePlayTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32, err error)
+	EncodeResponse(res *fasthttp.Response, uuid string, channels uint16, rate uint32, bitsPerSample uint16) (err error)
+}
+
+type filePlayTransport struct{}
+
+type filePlayRequest struct {
+	File             string `json:"file"`
+	PlayerIP         string `json:"playerIP"`
+	PlayerPort       string `json:"playerPort"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	Channels         uint16 `json:"channels"`
+	Rate             uint32 `json:"rate"`
+}
+
+func (t *filePlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, string, uint16, uint32, error) {
+	var request filePlayRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.File, request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.Channels, request.Rate, err
+}
+
+type filePlayResponse struct {
+	UUID          string `json:"uuid"`
+	Channels      uint16 `json:"channels"`
+	Rate          uint32 `json:"rate"`
+	BitsPerSample uint16 `json:"bitsPerSample "`
+}
+
+func (t *filePlayTransport) EncodeResponse(res *fasthttp.Response, uuid string, channels uint16, rate uint32, bitsPerSample uint16) (err error) {
+	response := &filePlayResponse{
+		UUID:          uuid,
+		Channels:      channels,
+		Rate:          rate,
+		BitsPerSample: bitsPerSample,
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newFilePlayTransport() FilePlayTransport {
+	return &filePlayTransport{}
+}
+
+// FileStopTransport ...
+type FileStopTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort, playerDeviceName, uuid string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type fileStopTransport struct{}
+
+type fileStopRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerPort       string `json:"playerPort"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	UUID             string `json:"uuid"`
+}
+
+func (t *fileStopTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, string, error) {
+	var request fileStopRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.UUID, err
+}
+
+type fileStopResponse struct{}
+
+func (t *fileStopTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &fileStopResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newFileStopTransport() FileStopTransport {
+	return &fileStopTransport{}
+}
+
+type mixSource struct {
+	File               string   `json:"file,omitempty"`
+	RecorderIP         string   `json:"recorderIP,omitempty"`
+	RecorderDeviceName string   `json:"recorderDeviceName,omitempty"`
+	ReceivePort        string   `json:"receivePort,omitempty"`
+	Gain               *float64 `json:"gain,omitempty"`
+}
+
+func toMixSources(sources []mixSource) []server.MixSource {
+	mixSources := make([]server.MixSource, 0, len(sources))
+	for _, source := range sources {
+		gain := 1.0
+		if source.Gain != nil {
+			gain = *source.Gain
+		}
+		mixSources = append(mixSources, server.MixSource{
+			File:               source.File,
+			RecorderIP:         source.RecorderIP,
+			RecorderDeviceName: source.RecorderDeviceName,
+			ReceivePort:        source.ReceivePort,
+			Gain:               gain,
+		})
+	}
+	return mixSources
+}
+
+// FilePauseTransport ...
+type FilePauseTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort string, err error)
+	EncodeResponse(res *fasthttp.Response, position time.Duration) (err error)
+}
+
+type filePauseTransport struct{}
+
+type filePauseRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+}
+
+func (t *filePauseTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request filePauseRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerPort, err
+}
+
+type filePauseResponse struct {
+	// Position in milliseconds
+	Position int64 `json:"position"`
+}
+
+func (t *filePauseTransport) EncodeResponse(res *fasthttp.Response, position time.Duration) (err error) {
+	response := &filePauseResponse{
+		Position: position.Milliseconds(),
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newFilePauseTransport() FilePauseTransport {
+	return &filePauseTransport{}
+}
+
+// FileResumeTransport ...
+type FileResumeTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type fileResumeTransport struct{}
+
+type fileResumeRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+}
+
+func (t *fileResumeTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request fileResumeRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerPort, err
+}
+
+type fileResumeResponse struct{}
+
+func (t *fileResumeTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &fileResumeResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newFileResumeTransport() FileResumeTransport {
+	return &fileResumeTransport{}
+}
+
+// FileSeekTransport ...
+type FileSeekTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort string, position time.Duration, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type fileSeekTransport struct{}
+
+type fileSeekRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+	// Position in milliseconds
+	Position int64 `json:"position"`
+}
+
+func (t *fileSeekTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, time.Duration, error) {
+	var request fileSeekRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerPort, time.Duration(request.Position) * time.Millisecond, err
+}
+
+type fileSeekResponse struct{}
+
+func (t *fileSeekTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &fileSeekResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newFileSeekTransport() FileSeekTransport {
+	return &fileSeekTransport{}
+}
+
+type playerDevice struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerPort       string `json:"playerPort"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func toPlayerDevices(players []playerDevice) []server.PlayerDevice {
+	playerDevices := make([]server.PlayerDevice, 0, len(players))
+	for _, player := range players {
+		playerDevices = append(playerDevices, server.PlayerDevice{
+			PlayerIP:         player.PlayerIP,
+			PlayerPort:       player.PlayerPort,
+			PlayerDeviceName: player.PlayerDeviceName,
+		})
+	}
+	return playerDevices
+}
+
+func fromPlayerDevices(players []server.PlayerDevice) []playerDevice {
+	playerDevices := make([]playerDevice, 0, len(players))
+	for _, player := range players {
+		playerDevices = append(playerDevices, playerDevice{
+			PlayerIP:         player.PlayerIP,
+			PlayerPort:       player.PlayerPort,
+			PlayerDeviceName: player.PlayerDeviceName,
+		})
+	}
+	return playerDevices
+}
+
+// GroupPlayTransport ...
+type GroupPlayTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (file string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32, err error)
+	EncodeResponse(res *fasthttp.Response, uuids []string, startAt time.Time) (err error)
+}
+
+type groupPlayTransport struct{}
+
+type groupPlayRequest struct {
+	File     string         `json:"file"`
+	Players  []playerDevice `json:"players"`
+	Channels uint16         `json:"channels"`
+	Rate     uint32         `json:"rate"`
+}
+
+func (t *groupPlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, []server.PlayerDevice, uint16, uint32, error) {
+	var request groupPlayRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.File, toPlayerDevices(request.Players), request.Channels, request.Rate, err
+}
+
+type groupPlayResponse struct {
+	UUIDs   []string  `json:"uuids"`
+	StartAt time.Time `json:"startAt"`
+}
+
+func (t *groupPlayTransport) EncodeResponse(res *fasthttp.Response, uuids []string, startAt time.Time) (err error) {
+	response := &groupPlayResponse{
+		UUIDs:   uuids,
+		StartAt: startAt,
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newGroupPlayTransport() GroupPlayTransport {
+	return &groupPlayTransport{}
+}
+
+// GroupStopTransport ...
+type GroupStopTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (players []server.PlayerDevice, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type groupStopTransport struct{}
+
+type groupStopRequest struct {
+	Players []playerDevice `json:"players"`
+}
+
+func (t *groupStopTransport) DecodeRequest(ctx *fasthttp.RequestCtx) ([]server.PlayerDevice, error) {
+	var request groupStopRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return toPlayerDevices(request.Players), err
+}
+
+type groupStopResponse struct{}
+
+func (t *groupStopTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &groupStopResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newGroupStopTransport() GroupStopTransport {
+	return &groupStopTransport{}
+}
+
+// MulticastPlayTransport ...
+type MulticastPlayTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (file, groupAddr string, players []server.PlayerDevice, dstChannels uint16, dstRate uint32, err error)
+	EncodeResponse(res *fasthttp.Response, uuids []string, startAt time.Time) (err error)
+}
+
+type multicastPlayTransport struct{}
+
+type multicastPlayRequest struct {
+	File      string         `json:"file"`
+	GroupAddr string         `json:"groupAddr"`
+	Players   []playerDevice `json:"players"`
+	Channels  uint16         `json:"channels"`
+	Rate      uint32         `json:"rate"`
+}
+
+func (t *multicastPlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, []server.PlayerDevice, uint16, uint32, error) {
+	var request multicastPlayRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.File, request.GroupAddr, toPlayerDevices(request.Players), request.Channels, request.Rate, err
+}
+
+type multicastPlayResponse struct {
+	UUIDs   []string  `json:"uuids"`
+	StartAt time.Time `json:"startAt"`
+}
+
+func (t *multicastPlayTransport) EncodeResponse(res *fasthttp.Response, uuids []string, startAt time.Time) (err error) {
+	response := &multicastPlayResponse{
+		UUIDs:   uuids,
+		StartAt: startAt,
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newMulticastPlayTransport() MulticastPlayTransport {
+	return &multicastPlayTransport{}
+}
+
+// MulticastStopTransport ...
+type MulticastStopTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (groupAddr string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type multicastStopTransport struct{}
+
+type multicastStopRequest struct {
+	GroupAddr string `json:"groupAddr"`
+}
+
+func (t *multicastStopTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, error) {
+	var request multicastStopRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.GroupAddr, err
+}
+
+type multicastStopResponse struct{}
+
+func (t *multicastStopTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &multicastStopResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newMulticastStopTransport() MulticastStopTransport {
+	return &multicastStopTransport{}
+}
+
+// PlaylistEnqueueTransport ...
+type PlaylistEnqueueTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32, err error)
+	EncodeResponse(res *fasthttp.Response, uuid string) (err error)
+}
+
+type playlistEnqueueTransport struct{}
+
+type playlistEnqueueRequest struct {
+	PlayerIP         string   `json:"playerIP"`
+	PlayerPort       string   `json:"playerPort"`
+	PlayerDeviceName string   `json:"playerDeviceName"`
+	Files            []string `json:"files"`
+	Channels         uint32   `json:"channels"`
+	Rate             uint32   `json:"rate"`
+}
+
+func (t *playlistEnqueueTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, []string, uint32, uint32, error) {
+	var request playlistEnqueueRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.Files, request.Channels, request.Rate, err
+}
+
+type playlistEnqueueResponse struct {
+	UUID string `json:"uuid"`
+}
+
+func (t *playlistEnqueueTransport) EncodeResponse(res *fasthttp.Response, uuid string) (err error) {
+	response := &playlistEnqueueResponse{
+		UUID: uuid,
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlaylistEnqueueTransport() PlaylistEnqueueTransport {
+	return &playlistEnqueueTransport{}
+}
+
+// PlaylistSkipTransport ...
+type PlaylistSkipTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playlistSkipTransport struct{}
+
+type playlistSkipRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistSkipTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request playlistSkipRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, err
+}
+
+type playlistSkipResponse struct{}
+
+func (t *playlistSkipTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playlistSkipResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlaylistSkipTransport() PlaylistSkipTransport {
+	return &playlistSkipTransport{}
+}
+
+// PlaylistClearTransport ...
+type PlaylistClearTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playlistClearTransport struct{}
+
+type playlistClearRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistClearTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request playlistClearRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, err
+}
+
+type playlistClearResponse struct{}
+
+func (t *playlistClearTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playlistClearResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlaylistClearTransport() PlaylistClearTransport {
+	return &playlistClearTransport{}
+}
+
+// PlaylistModeTransport ...
+type PlaylistModeTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, loop, shuffle bool, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playlistModeTransport struct{}
+
+type playlistModeRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	Loop             bool   `json:"loop"`
+	Shuffle          bool   `json:"shuffle"`
+}
+
+func (t *playlistModeTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, bool, bool, error) {
+	var request playlistModeRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, request.Loop, request.Shuffle, err
+}
+
+type playlistModeResponse struct{}
+
+func (t *playlistModeTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playlistModeResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlaylistModeTransport() PlaylistModeTransport {
+	return &playlistModeTransport{}
+}
+
+// PlaylistStateTransport ...
+type PlaylistStateTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, err error)
+	EncodeResponse(res *fasthttp.Response, files []string, current int, loop, shuffle bool) (err error)
+}
+
+type playlistStateTransport struct{}
+
+type playlistStateRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistStateTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request playlistStateRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, err
+}
+
+type playlistStateResponse struct {
+	Files   []string `json:"files"`
+	Current int      `json:"current"`
+	Loop    bool     `json:"loop"`
+	Shuffle bool     `json:"shuffle"`
+}
+
+func (t *playlistStateTransport) EncodeResponse(res *fasthttp.Response, files []string, current int, loop, shuffle bool) (err error) {
+	response := &playlistStateResponse{
+		Files:   files,
+		Current: current,
+		Loop:    loop,
+		Shuffle: shuffle,
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlaylistStateTransport() PlaylistStateTransport {
+	return &playlistStateTransport{}
+}
+
+// PlaylistStopTransport ...
+type PlaylistStopTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playlistStopTransport struct{}
+
+type playlistStopRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playlistStopTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request playlistStopRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, err
+}
+
+type playlistStopResponse struct{}
+
+func (t *playlistStopTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playlistStopResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlaylistStopTransport() PlaylistStopTransport {
+	return &playlistStopTransport{}
+}
+
+type scheduleJob struct {
+	ID      string         `json:"id"`
+	Cron    string         `json:"cron,omitempty"`
+	At      *time.Time     `json:"at,omitempty"`
+	File    string         `json:"file"`
+	Players []playerDevice `json:"players"`
+	// Duration in milliseconds
+	Duration   int64      `json:"duration,omitempty"`
+	NextRun    *time.Time `json:"nextRun,omitempty"`
+	LastRun    *time.Time `json:"lastRun,omitempty"`
+	LastResult string     `json:"lastResult,omitempty"`
+}
+
+func fromScheduleJobs(jobs []server.ScheduleJob) []scheduleJob {
+	scheduleJobs := make([]scheduleJob, 0, len(jobs))
+	for _, job := range jobs {
+		scheduleJobs = append(scheduleJobs, scheduleJob{
+			ID:         job.ID,
+			Cron:       job.Cron,
+			At:         timeOrNil(job.At),
+			File:       job.File,
+			Players:    fromPlayerDevices(job.Players),
+			Duration:   job.Duration.Milliseconds(),
+			NextRun:    timeOrNil(job.NextRun),
+			LastRun:    timeOrNil(job.LastRun),
+			LastResult: job.LastResult,
+		})
+	}
+	return scheduleJobs
 }
 
-type filePlayTransport struct{}
+// timeOrNil return nil for zero time to omit it in json
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
 
-type filePlayRequest struct {
-	File             string `json:"file"`
-	PlayerIP         string `json:"playerIP"`
-	PlayerPort       string `json:"playerPort"`
-	PlayerDeviceName string `json:"playerDeviceName"`
+// ScheduleAddTransport ...
+type ScheduleAddTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (spec string, at time.Time, file string, players []server.PlayerDevice, duration time.Duration, err error)
+	EncodeResponse(res *fasthttp.Response, id string, nextRun time.Time) (err error)
 }
 
-func (t *filePlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, string, error) {
-	var request filePlayRequest
+type scheduleAddTransport struct{}
+
+type scheduleAddRequest struct {
+	Cron    string         `json:"cron"`
+	At      time.Time      `json:"at"`
+	File    string         `json:"file"`
+	Players []playerDevice `json:"players"`
+	// Duration in milliseconds
+	Duration int64 `json:"duration"`
+}
+
+func (t *scheduleAddTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, time.Time, string, []server.PlayerDevice, time.Duration, error) {
+	var request scheduleAddRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.File, request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, err
+	return request.Cron, request.At, request.File, toPlayerDevices(request.Players), time.Duration(request.Duration) * time.Millisecond, err
 }
 
-type filePlayResponse struct {
-	UUID          string `json:"uuid"`
-	Channels      uint16 `json:"channels"`
-	Rate          uint32 `json:"rate"`
-	BitsPerSample uint16 `json:"bitsPerSample "`
+type scheduleAddResponse struct {
+	ID      string    `json:"id"`
+	NextRun time.Time `json:"nextRun"`
 }
 
-func (t *filePlayTransport) EncodeResponse(res *fasthttp.Response, uuid string, channels uint16, rate uint32, bitsPerSample uint16) (err error) {
-	response := &filePlayResponse{
-		UUID:          uuid,
-		Channels:      channels,
-		Rate:          rate,
-		BitsPerSample: bitsPerSample,
+func (t *scheduleAddTransport) EncodeResponse(res *fasthttp.Response, id string, nextRun time.Time) (err error) {
+	response := &scheduleAddResponse{
+		ID:      id,
+		NextRun: nextRun,
 	}
 	body, err := json.Marshal(response)
 	res.SetBody(body)
@@ -48,43 +701,145 @@ func (t *filePlayTransport) EncodeResponse(res *fasthttp.Response, uuid string,
 	return
 }
 
-func newFilePlayTransport() FilePlayTransport {
-	return &filePlayTransport{}
+func newScheduleAddTransport() ScheduleAddTransport {
+	return &scheduleAddTransport{}
 }
 
-// FileStopTransport ...
-type FileStopTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort, playerDeviceName, uuid string, err error)
+// ScheduleRemoveTransport ...
+type ScheduleRemoveTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (id string, err error)
 	EncodeResponse(res *fasthttp.Response) (err error)
 }
 
-type fileStopTransport struct{}
+type scheduleRemoveTransport struct{}
 
-type fileStopRequest struct {
-	PlayerIP         string `json:"playerIP"`
-	PlayerPort       string `json:"playerPort"`
-	PlayerDeviceName string `json:"playerDeviceName"`
-	UUID             string `json:"uuid"`
+type scheduleRemoveRequest struct {
+	ID string `json:"id"`
 }
 
-func (t *fileStopTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, string, error) {
-	var request fileStopRequest
+func (t *scheduleRemoveTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, error) {
+	var request scheduleRemoveRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.UUID, err
+	return request.ID, err
 }
 
-type fileStopResponse struct{}
+type scheduleRemoveResponse struct{}
 
-func (t *fileStopTransport) EncodeResponse(res *fasthttp.Response) (err error) {
-	response := &fileStopResponse{}
+func (t *scheduleRemoveTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &scheduleRemoveResponse{}
 	body, err := json.Marshal(response)
 	res.SetBody(body)
 	res.SetStatusCode(http.StatusOK)
 	return
 }
 
-func newFileStopTransport() FileStopTransport {
-	return &fileStopTransport{}
+func newScheduleRemoveTransport() ScheduleRemoveTransport {
+	return &scheduleRemoveTransport{}
+}
+
+// ScheduleListTransport ...
+type ScheduleListTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (err error)
+	EncodeResponse(res *fasthttp.Response, jobs []server.ScheduleJob) (err error)
+}
+
+type scheduleListTransport struct{}
+
+func (t *scheduleListTransport) DecodeRequest(ctx *fasthttp.RequestCtx) error {
+	return nil
+}
+
+type scheduleListResponse struct {
+	Jobs []scheduleJob `json:"jobs"`
+}
+
+func (t *scheduleListTransport) EncodeResponse(res *fasthttp.Response, jobs []server.ScheduleJob) (err error) {
+	response := &scheduleListResponse{
+		Jobs: fromScheduleJobs(jobs),
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newScheduleListTransport() ScheduleListTransport {
+	return &scheduleListTransport{}
+}
+
+// MixPlayTransport ...
+type MixPlayTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (sources []server.MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32, err error)
+	EncodeResponse(res *fasthttp.Response, uuid string) (err error)
+}
+
+type mixPlayTransport struct{}
+
+type mixPlayRequest struct {
+	Sources          []mixSource `json:"sources"`
+	PlayerIP         string      `json:"playerIP"`
+	PlayerPort       string      `json:"playerPort"`
+	PlayerDeviceName string      `json:"playerDeviceName"`
+	Channels         uint32      `json:"channels"`
+	Rate             uint32      `json:"rate"`
+}
+
+func (t *mixPlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) ([]server.MixSource, string, string, string, uint32, uint32, error) {
+	var request mixPlayRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return toMixSources(request.Sources), request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.Channels, request.Rate, err
+}
+
+type mixPlayResponse struct {
+	UUID string `json:"uuid"`
+}
+
+func (t *mixPlayTransport) EncodeResponse(res *fasthttp.Response, uuid string) (err error) {
+	response := &mixPlayResponse{
+		UUID: uuid,
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newMixPlayTransport() MixPlayTransport {
+	return &mixPlayTransport{}
+}
+
+// MixStopTransport ...
+type MixStopTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort, uuid string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type mixStopTransport struct{}
+
+type mixStopRequest struct {
+	PlayerIP   string `json:"playerIP"`
+	PlayerPort string `json:"playerPort"`
+	UUID       string `json:"uuid"`
+}
+
+func (t *mixStopTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, error) {
+	var request mixStopRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerPort, request.UUID, err
+}
+
+type mixStopResponse struct{}
+
+func (t *mixStopTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &mixStopResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newMixStopTransport() MixStopTransport {
+	return &mixStopTransport{}
 }
 
 // PlayerStateTransport ...
@@ -129,7 +884,7 @@ func newPlayerStateTransport() PlayerStateTransport {
 
 // PlayerReceiveStartTransport ...
 type PlayerReceiveStartTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort string, uuid *string, err error)
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort string, uuid *string, replay bool, err error)
 	EncodeResponse(res *fasthttp.Response, uuid string) (err error)
 }
 
@@ -139,12 +894,13 @@ type playerReceiveStartRequest struct {
 	PlayerIP   string  `json:"playerIP"`
 	PlayerPort string  `json:"playerPort"`
 	UUID       *string `json:"uuid"`
+	Replay     bool    `json:"replay"`
 }
 
-func (t *playerReceiveStartTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, *string, error) {
+func (t *playerReceiveStartTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, *string, bool, error) {
 	var request playerReceiveStartRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.PlayerIP, request.PlayerPort, request.UUID, err
+	return request.PlayerIP, request.PlayerPort, request.UUID, request.Replay, err
 }
 
 type playerReceiveStartResponse struct {
@@ -200,7 +956,7 @@ func newPlayerReceiveStopTransport() PlayerReceiveStopTransport {
 
 // PlayerPlayTransport ...
 type PlayerPlayTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32, err error)
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, err error)
 	EncodeResponse(res *fasthttp.Response) (err error)
 }
 
@@ -213,12 +969,13 @@ type playerPlayRequest struct {
 	Channels         uint32 `json:"channels"`
 	Rate             uint32 `json:"rate"`
 	BitsPerSample    uint32 `json:"bitsPerSample"`
+	AudioFormat      uint32 `json:"audioFormat"`
 }
 
-func (t *playerPlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, uint32, uint32, uint32, error) {
+func (t *playerPlayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, uint32, uint32, uint32, uint32, error) {
 	var request playerPlayRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.PlayerIP, request.UUID, request.PlayerDeviceName, request.Channels, request.Rate, request.BitsPerSample, err
+	return request.PlayerIP, request.UUID, request.PlayerDeviceName, request.Channels, request.Rate, request.BitsPerSample, request.AudioFormat, err
 }
 
 type playerPlayResponse struct{}
@@ -268,6 +1025,72 @@ func newPlayerStopTransport() PlayerStopTransport {
 	return &playerStopTransport{}
 }
 
+// PlayerRewindTransport ...
+type PlayerRewindTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playerRewindTransport struct{}
+
+type playerRewindRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playerRewindTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request playerRewindRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, err
+}
+
+type playerRewindResponse struct{}
+
+func (t *playerRewindTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playerRewindResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlayerRewindTransport() PlayerRewindTransport {
+	return &playerRewindTransport{}
+}
+
+// PlayerReplayTransport ...
+type PlayerReplayTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playerReplayTransport struct{}
+
+type playerReplayRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+}
+
+func (t *playerReplayTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	var request playerReplayRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, err
+}
+
+type playerReplayResponse struct{}
+
+func (t *playerReplayTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playerReplayResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlayerReplayTransport() PlayerReplayTransport {
+	return &playerReplayTransport{}
+}
+
 // PlayerClearStorageTransport ...
 type PlayerClearStorageTransport interface {
 	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, uuid string, err error)
@@ -301,9 +1124,147 @@ func newPlayerClearStorageTransport() PlayerClearStorageTransport {
 	return &playerClearStorageTransport{}
 }
 
+// PlayerSetVolumeTransport ...
+type PlayerSetVolumeTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, volume float32, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playerSetVolumeTransport struct{}
+
+type playerSetVolumeRequest struct {
+	PlayerIP         string  `json:"playerIP"`
+	PlayerDeviceName string  `json:"playerDeviceName"`
+	Volume           float32 `json:"volume"`
+}
+
+func (t *playerSetVolumeTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, float32, error) {
+	var request playerSetVolumeRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, request.Volume, err
+}
+
+type playerSetVolumeResponse struct{}
+
+func (t *playerSetVolumeTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playerSetVolumeResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlayerSetVolumeTransport() PlayerSetVolumeTransport {
+	return &playerSetVolumeTransport{}
+}
+
+// PlayerMuteTransport ...
+type PlayerMuteTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerDeviceName string, mute bool, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+}
+
+type playerMuteTransport struct{}
+
+type playerMuteRequest struct {
+	PlayerIP         string `json:"playerIP"`
+	PlayerDeviceName string `json:"playerDeviceName"`
+	Mute             bool   `json:"mute"`
+}
+
+func (t *playerMuteTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, bool, error) {
+	var request playerMuteRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, request.PlayerDeviceName, request.Mute, err
+}
+
+type playerMuteResponse struct{}
+
+func (t *playerMuteTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	response := &playerMuteResponse{}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlayerMuteTransport() PlayerMuteTransport {
+	return &playerMuteTransport{}
+}
+
+type pcmDevice struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Rates       []int       `json:"rates"`
+	Channels    []int       `json:"channels"`
+	Formats     []pcmFormat `json:"formats"`
+}
+
+type pcmFormat struct {
+	BitsPerSample int `json:"bitsPerSample"`
+	AudioFormat   int `json:"audioFormat"`
+}
+
+func fromPCMDevices(devices []pcm.Device) []pcmDevice {
+	pcmDevices := make([]pcmDevice, 0, len(devices))
+	for _, d := range devices {
+		device := pcmDevice{
+			Name:        d.Name,
+			Description: d.Description,
+			Rates:       d.Rates,
+			Channels:    d.Channels,
+			Formats:     make([]pcmFormat, 0, len(d.Formats)),
+		}
+		for _, f := range d.Formats {
+			device.Formats = append(device.Formats, pcmFormat{
+				BitsPerSample: f.BitsPerSample,
+				AudioFormat:   f.AudioFormat,
+			})
+		}
+		pcmDevices = append(pcmDevices, device)
+	}
+	return pcmDevices
+}
+
+// PlayerListDevicesTransport ...
+type PlayerListDevicesTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP string, err error)
+	EncodeResponse(res *fasthttp.Response, devices []pcm.Device) (err error)
+}
+
+type playerListDevicesTransport struct{}
+
+type playerListDevicesRequest struct {
+	PlayerIP string `json:"playerIP"`
+}
+
+func (t *playerListDevicesTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, error) {
+	var request playerListDevicesRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.PlayerIP, err
+}
+
+type playerListDevicesResponse struct {
+	Devices []pcmDevice `json:"devices"`
+}
+
+func (t *playerListDevicesTransport) EncodeResponse(res *fasthttp.Response, devices []pcm.Device) (err error) {
+	response := &playerListDevicesResponse{
+		Devices: fromPCMDevices(devices),
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newPlayerListDevicesTransport() PlayerListDevicesTransport {
+	return &playerListDevicesTransport{}
+}
+
 // StartFileRecordingTransport ...
 type StartFileRecordingTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string, err error)
+	DecodeRequest(ctx *fasthttp.RequestCtx) (recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64, err error)
 	EncodeResponse(res *fasthttp.Response) (err error)
 }
 
@@ -314,14 +1275,19 @@ type startFileRecordingRequest struct {
 	RecorderDeviceName string `json:"recorderDeviceName"`
 	Channels           uint32 `json:"channels"`
 	Rate               uint32 `json:"rate"`
+	BitsPerSample      uint32 `json:"bitsPerSample"`
+	AudioFormat        uint32 `json:"audioFormat"`
 	ReceivePort        string `json:"receivePort"`
 	File               string `json:"file"`
+	FileFormat         string `json:"fileFormat"`
+	SegmentDuration    int64  `json:"segmentDuration"`
+	SegmentSize        int64  `json:"segmentSize"`
 }
 
-func (t *startFileRecordingTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, uint32, uint32, string, string, error) {
+func (t *startFileRecordingTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, uint32, uint32, uint32, uint32, string, string, string, time.Duration, int64, error) {
 	var request startFileRecordingRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.RecorderIP, request.RecorderDeviceName, request.Channels, request.Rate, request.ReceivePort, request.File, err
+	return request.RecorderIP, request.RecorderDeviceName, request.Channels, request.Rate, request.BitsPerSample, request.AudioFormat, request.ReceivePort, request.File, request.FileFormat, time.Duration(request.SegmentDuration) * time.Millisecond, request.SegmentSize, err
 }
 
 type startFileRecordingResponse struct{}
@@ -374,7 +1340,7 @@ func newStopFileRecordingTransport() StopFileRecordingTransport {
 
 // PlayFromRecorderTransport ...
 type PlayFromRecorderTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string, err error)
+	DecodeRequest(ctx *fasthttp.RequestCtx) (playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string, err error)
 	EncodeResponse(res *fasthttp.Response, uuid string) (err error)
 }
 
@@ -386,14 +1352,16 @@ type playFromRecorderRequest struct {
 	PlayerDeviceName   string `json:"playerDeviceName"`
 	Channels           uint32 `json:"channels"`
 	Rate               uint32 `json:"rate"`
+	BitsPerSample      uint32 `json:"bitsPerSample"`
+	AudioFormat        uint32 `json:"audioFormat"`
 	RecorderIP         string `json:"recorderIP"`
 	RecorderDeviceName string `json:"recorderDeviceName"`
 }
 
-func (t *playFromRecorderTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, uint32, uint32, string, string, error) {
+func (t *playFromRecorderTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, string, uint32, uint32, uint32, uint32, string, string, error) {
 	var request playFromRecorderRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.Channels, request.Rate, request.RecorderIP, request.RecorderDeviceName, err
+	return request.PlayerIP, request.PlayerPort, request.PlayerDeviceName, request.Channels, request.Rate, request.BitsPerSample, request.AudioFormat, request.RecorderIP, request.RecorderDeviceName, err
 }
 
 type playFromRecorderResponse struct {
@@ -489,7 +1457,7 @@ func newRecorderStateTransport() RecorderStateTransport {
 
 // RecorderStartTransport ...
 type RecorderStartTransport interface {
-	DecodeRequest(ctx *fasthttp.RequestCtx) (recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string, err error)
+	DecodeRequest(ctx *fasthttp.RequestCtx) (recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string, err error)
 	EncodeResponse(res *fasthttp.Response) (err error)
 }
 
@@ -500,13 +1468,15 @@ type recorderStartRequest struct {
 	RecorderDeviceName string `json:"recorderDeviceName"`
 	Channels           uint32 `json:"channels"`
 	Rate               uint32 `json:"rate"`
+	BitsPerSample      uint32 `json:"bitsPerSample"`
+	AudioFormat        uint32 `json:"audioFormat"`
 	DstAddr            string `json:"dstAddr"`
 }
 
-func (t *recorderStartTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, uint32, uint32, string, error) {
+func (t *recorderStartTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, uint32, uint32, uint32, uint32, string, error) {
 	var request recorderStartRequest
 	err := json.Unmarshal(ctx.Request.Body(), &request)
-	return request.RecorderIP, request.RecorderDeviceName, request.Channels, request.Rate, request.DstAddr, err
+	return request.RecorderIP, request.RecorderDeviceName, request.Channels, request.Rate, request.BitsPerSample, request.AudioFormat, request.DstAddr, err
 }
 
 type recorderStartResponse struct{}
@@ -555,3 +1525,105 @@ func (t *recorderStopTransport) EncodeResponse(res *fasthttp.Response) (err erro
 func newRecorderStopTransport() RecorderStopTransport {
 	return &recorderStopTransport{}
 }
+
+// RecorderListDevicesTransport ...
+type RecorderListDevicesTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (recorderIP string, err error)
+	EncodeResponse(res *fasthttp.Response, devices []pcm.Device) (err error)
+}
+
+type recorderListDevicesTransport struct{}
+
+type recorderListDevicesRequest struct {
+	RecorderIP string `json:"recorderIP"`
+}
+
+func (t *recorderListDevicesTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, error) {
+	var request recorderListDevicesRequest
+	err := json.Unmarshal(ctx.Request.Body(), &request)
+	return request.RecorderIP, err
+}
+
+type recorderListDevicesResponse struct {
+	Devices []pcmDevice `json:"devices"`
+}
+
+func (t *recorderListDevicesTransport) EncodeResponse(res *fasthttp.Response, devices []pcm.Device) (err error) {
+	response := &recorderListDevicesResponse{
+		Devices: fromPCMDevices(devices),
+	}
+	body, err := json.Marshal(response)
+	res.SetBody(body)
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+func newRecorderListDevicesTransport() RecorderListDevicesTransport {
+	return &recorderListDevicesTransport{}
+}
+
+// EventsTransport ...
+type EventsTransport interface {
+	DecodeRequest(ctx *fasthttp.RequestCtx) (source, ip string, err error)
+	EncodeResponse(res *fasthttp.Response) (err error)
+	EncodeEvent(w *bufio.Writer, e event.Event) (err error)
+	EncodePing(w *bufio.Writer) (err error)
+}
+
+type eventsTransport struct{}
+
+// DecodeRequest filter of events from query arguments, EventSource of browser can not send body
+func (t *eventsTransport) DecodeRequest(ctx *fasthttp.RequestCtx) (string, string, error) {
+	args := ctx.QueryArgs()
+	return string(args.Peek("source")), string(args.Peek("ip")), nil
+}
+
+func (t *eventsTransport) EncodeResponse(res *fasthttp.Response) (err error) {
+	res.Header.SetContentType("text/event-stream")
+	res.Header.Set("Cache-Control", "no-cache")
+	res.SetStatusCode(http.StatusOK)
+	return
+}
+
+type eventResponse struct {
+	Source      string    `json:"source"`
+	IP          string    `json:"ip"`
+	Type        string    `json:"type"`
+	DeviceName  string    `json:"deviceName"`
+	StorageUUID string    `json:"storageUUID,omitempty"`
+	Message     string    `json:"message,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// EncodeEvent in format of server-sent events, name of event is type
+func (t *eventsTransport) EncodeEvent(w *bufio.Writer, e event.Event) (err error) {
+	response := &eventResponse{
+		Source:      e.Source,
+		IP:          e.IP,
+		Type:        e.Type,
+		DeviceName:  e.DeviceName,
+		StorageUUID: e.StorageUUID,
+		Message:     e.Message,
+		Time:        e.Time,
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, body); err != nil {
+		return
+	}
+	return w.Flush()
+}
+
+// EncodePing comment keeping connection, closed connection is detected by error of writing
+func (t *eventsTransport) EncodePing(w *bufio.Writer) (err error) {
+	if _, err = w.WriteString(": ping\n\n"); err != nil {
+		return
+	}
+	return w.Flush()
+}
+
+func newEventsTransport() EventsTransport {
+	return &eventsTransport{}
+}
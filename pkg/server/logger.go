@@ -2,8 +2,13 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/go-kit/kit/log"
+
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
 )
 
 type loggerMiddleware struct {
@@ -11,15 +16,17 @@ type loggerMiddleware struct {
 	logger log.Logger
 }
 
-func (l *loggerMiddleware) FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error) {
+func (l *loggerMiddleware) FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error) {
 	l.logger.Log("FilePlay", "start")
-	if uuid, channels, rate, bitsPerSample, err = l.server.FilePlay(ctx, file, playerIP, playerPort, playerDeviceName); err != nil {
+	if uuid, channels, rate, bitsPerSample, err = l.server.FilePlay(ctx, file, playerIP, playerPort, playerDeviceName, dstChannels, dstRate); err != nil {
 		l.logger.Log(
 			"FilePlay", "err",
 			"file", file,
 			"playerIP", playerIP,
 			"playerPort", playerPort,
 			"playerDeviceName", playerDeviceName,
+			"dstChannels", dstChannels,
+			"dstRate", dstRate,
 			"err", err,
 		)
 		return
@@ -51,6 +58,321 @@ func (l *loggerMiddleware) FileStop(ctx context.Context, playerIP, playerPort, p
 	return
 }
 
+func (l *loggerMiddleware) FilePause(ctx context.Context, playerIP, playerPort string) (position time.Duration, err error) {
+	l.logger.Log("FilePause", "start")
+	if position, err = l.server.FilePause(ctx, playerIP, playerPort); err != nil {
+		l.logger.Log(
+			"FilePause", "err",
+			"playerIP", playerIP,
+			"playerPort", playerPort,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"FilePause", "end",
+		"position", position,
+	)
+	return
+}
+
+func (l *loggerMiddleware) FileResume(ctx context.Context, playerIP, playerPort string) (err error) {
+	l.logger.Log("FileResume", "start")
+	if err = l.server.FileResume(ctx, playerIP, playerPort); err != nil {
+		l.logger.Log(
+			"FileResume", "err",
+			"playerIP", playerIP,
+			"playerPort", playerPort,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("FileResume", "end")
+	return
+}
+
+func (l *loggerMiddleware) FileSeek(ctx context.Context, playerIP, playerPort string, position time.Duration) (err error) {
+	l.logger.Log("FileSeek", "start")
+	if err = l.server.FileSeek(ctx, playerIP, playerPort, position); err != nil {
+		l.logger.Log(
+			"FileSeek", "err",
+			"playerIP", playerIP,
+			"playerPort", playerPort,
+			"position", position,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("FileSeek", "end")
+	return
+}
+
+func (l *loggerMiddleware) GroupPlay(ctx context.Context, file string, players []PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error) {
+	l.logger.Log("GroupPlay", "start")
+	if uuids, startAt, err = l.server.GroupPlay(ctx, file, players, dstChannels, dstRate); err != nil {
+		l.logger.Log(
+			"GroupPlay", "err",
+			"file", file,
+			"players", fmt.Sprintf("%v", players),
+			"dstChannels", dstChannels,
+			"dstRate", dstRate,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"GroupPlay", "end",
+		"uuids", fmt.Sprintf("%v", uuids),
+		"startAt", startAt,
+	)
+	return
+}
+
+func (l *loggerMiddleware) GroupStop(ctx context.Context, players []PlayerDevice) (err error) {
+	l.logger.Log("GroupStop", "start")
+	if err = l.server.GroupStop(ctx, players); err != nil {
+		l.logger.Log(
+			"GroupStop", "err",
+			"players", fmt.Sprintf("%v", players),
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("GroupStop", "end")
+	return
+}
+
+func (l *loggerMiddleware) MulticastPlay(ctx context.Context, file, groupAddr string, players []PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error) {
+	l.logger.Log("MulticastPlay", "start")
+	if uuids, startAt, err = l.server.MulticastPlay(ctx, file, groupAddr, players, dstChannels, dstRate); err != nil {
+		l.logger.Log(
+			"MulticastPlay", "err",
+			"file", file,
+			"groupAddr", groupAddr,
+			"players", fmt.Sprintf("%v", players),
+			"dstChannels", dstChannels,
+			"dstRate", dstRate,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"MulticastPlay", "end",
+		"uuids", fmt.Sprintf("%v", uuids),
+		"startAt", startAt,
+	)
+	return
+}
+
+func (l *loggerMiddleware) MulticastStop(ctx context.Context, groupAddr string) (err error) {
+	l.logger.Log("MulticastStop", "start")
+	if err = l.server.MulticastStop(ctx, groupAddr); err != nil {
+		l.logger.Log(
+			"MulticastStop", "err",
+			"groupAddr", groupAddr,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("MulticastStop", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlaylistEnqueue(ctx context.Context, playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32) (uuid string, err error) {
+	l.logger.Log("PlaylistEnqueue", "start")
+	if uuid, err = l.server.PlaylistEnqueue(ctx, playerIP, playerPort, playerDeviceName, files, channels, rate); err != nil {
+		l.logger.Log(
+			"PlaylistEnqueue", "err",
+			"playerIP", playerIP,
+			"playerPort", playerPort,
+			"playerDeviceName", playerDeviceName,
+			"files", fmt.Sprintf("%v", files),
+			"channels", channels,
+			"rate", rate,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"PlaylistEnqueue", "end",
+		"uuid", uuid,
+	)
+	return
+}
+
+func (l *loggerMiddleware) PlaylistSkip(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	l.logger.Log("PlaylistSkip", "start")
+	if err = l.server.PlaylistSkip(ctx, playerIP, playerDeviceName); err != nil {
+		l.logger.Log(
+			"PlaylistSkip", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("PlaylistSkip", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlaylistClear(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	l.logger.Log("PlaylistClear", "start")
+	if err = l.server.PlaylistClear(ctx, playerIP, playerDeviceName); err != nil {
+		l.logger.Log(
+			"PlaylistClear", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("PlaylistClear", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlaylistMode(ctx context.Context, playerIP, playerDeviceName string, loop, shuffle bool) (err error) {
+	l.logger.Log("PlaylistMode", "start")
+	if err = l.server.PlaylistMode(ctx, playerIP, playerDeviceName, loop, shuffle); err != nil {
+		l.logger.Log(
+			"PlaylistMode", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"loop", loop,
+			"shuffle", shuffle,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("PlaylistMode", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlaylistState(ctx context.Context, playerIP, playerDeviceName string) (files []string, current int, loop, shuffle bool, err error) {
+	l.logger.Log("PlaylistState", "start")
+	if files, current, loop, shuffle, err = l.server.PlaylistState(ctx, playerIP, playerDeviceName); err != nil {
+		l.logger.Log(
+			"PlaylistState", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"PlaylistState", "end",
+		"files", fmt.Sprintf("%v", files),
+		"current", current,
+		"loop", loop,
+		"shuffle", shuffle,
+	)
+	return
+}
+
+func (l *loggerMiddleware) PlaylistStop(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	l.logger.Log("PlaylistStop", "start")
+	if err = l.server.PlaylistStop(ctx, playerIP, playerDeviceName); err != nil {
+		l.logger.Log(
+			"PlaylistStop", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("PlaylistStop", "end")
+	return
+}
+
+func (l *loggerMiddleware) ScheduleAdd(ctx context.Context, spec string, at time.Time, file string, players []PlayerDevice, duration time.Duration) (id string, nextRun time.Time, err error) {
+	l.logger.Log("ScheduleAdd", "start")
+	if id, nextRun, err = l.server.ScheduleAdd(ctx, spec, at, file, players, duration); err != nil {
+		l.logger.Log(
+			"ScheduleAdd", "err",
+			"spec", spec,
+			"at", at,
+			"file", file,
+			"players", fmt.Sprintf("%v", players),
+			"duration", duration,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"ScheduleAdd", "end",
+		"id", id,
+		"nextRun", nextRun,
+	)
+	return
+}
+
+func (l *loggerMiddleware) ScheduleRemove(ctx context.Context, id string) (err error) {
+	l.logger.Log("ScheduleRemove", "start")
+	if err = l.server.ScheduleRemove(ctx, id); err != nil {
+		l.logger.Log(
+			"ScheduleRemove", "err",
+			"id", id,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("ScheduleRemove", "end")
+	return
+}
+
+func (l *loggerMiddleware) ScheduleList(ctx context.Context) (jobs []ScheduleJob, err error) {
+	l.logger.Log("ScheduleList", "start")
+	if jobs, err = l.server.ScheduleList(ctx); err != nil {
+		l.logger.Log(
+			"ScheduleList", "err",
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"ScheduleList", "end",
+		"jobs", len(jobs),
+	)
+	return
+}
+
+func (l *loggerMiddleware) MixPlay(ctx context.Context, sources []MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32) (uuid string, err error) {
+	l.logger.Log("MixPlay", "start")
+	if uuid, err = l.server.MixPlay(ctx, sources, playerIP, playerPort, playerDeviceName, channels, rate); err != nil {
+		l.logger.Log(
+			"MixPlay", "err",
+			"sources", fmt.Sprintf("%+v", sources),
+			"playerIP", playerIP,
+			"playerPort", playerPort,
+			"playerDeviceName", playerDeviceName,
+			"channels", channels,
+			"rate", rate,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log(
+		"MixPlay", "end",
+		"uuid", uuid,
+	)
+	return
+}
+
+func (l *loggerMiddleware) MixStop(ctx context.Context, playerIP, playerPort, uuid string) (err error) {
+	l.logger.Log("MixStop", "start")
+	if err = l.server.MixStop(ctx, playerIP, playerPort, uuid); err != nil {
+		l.logger.Log(
+			"MixStop", "err",
+			"playerIP", playerIP,
+			"playerPort", playerPort,
+			"uuid", uuid,
+			"err", err,
+		)
+		return
+	}
+	l.logger.Log("MixStop", "end")
+	return
+}
+
 func (l *loggerMiddleware) PlayerState(ctx context.Context, playerIP string) (ports, storages, devices []string, err error) {
 	l.logger.Log("PlayerState", "start")
 	if ports, storages, devices, err = l.server.PlayerState(ctx, playerIP); err != nil {
@@ -69,9 +391,9 @@ func (l *loggerMiddleware) PlayerState(ctx context.Context, playerIP string) (po
 	return
 }
 
-func (l *loggerMiddleware) PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string) (sUUID string, err error) {
+func (l *loggerMiddleware) PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string, replay bool) (sUUID string, err error) {
 	l.logger.Log("PlayerReceiveStart", "start")
-	if sUUID, err = l.server.PlayerReceiveStart(ctx, playerIP, playerPort, uuid); err != nil {
+	if sUUID, err = l.server.PlayerReceiveStart(ctx, playerIP, playerPort, uuid, replay); err != nil {
 		l.logger.Log(
 			"PlayerReceiveStart", "err",
 			"playerIP", playerIP,
@@ -101,9 +423,9 @@ func (l *loggerMiddleware) PlayerReceiveStop(ctx context.Context, playerIP, play
 	return
 }
 
-func (l *loggerMiddleware) PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32) (err error) {
+func (l *loggerMiddleware) PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32) (err error) {
 	l.logger.Log("PlayerPlay", "start")
-	if err = l.server.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample); err != nil {
+	if err = l.server.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, audioFormat); err != nil {
 		l.logger.Log(
 			"PlayerPlay", "err",
 			"playerIP", playerIP,
@@ -112,6 +434,7 @@ func (l *loggerMiddleware) PlayerPlay(ctx context.Context, playerIP, uuid, playe
 			"channels", channels,
 			"rate", rate,
 			"bitsPerSample", bitsPerSample,
+			"audioFormat", audioFormat,
 			"err", err,
 		)
 	}
@@ -133,6 +456,34 @@ func (l *loggerMiddleware) PlayerStop(ctx context.Context, playerIP, playerDevic
 	return
 }
 
+func (l *loggerMiddleware) PlayerRewind(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	l.logger.Log("PlayerRewind", "start")
+	if err = l.server.PlayerRewind(ctx, playerIP, playerDeviceName); err != nil {
+		l.logger.Log(
+			"PlayerRewind", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"err", err,
+		)
+	}
+	l.logger.Log("PlayerRewind", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlayerReplay(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	l.logger.Log("PlayerReplay", "start")
+	if err = l.server.PlayerReplay(ctx, playerIP, playerDeviceName); err != nil {
+		l.logger.Log(
+			"PlayerReplay", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"err", err,
+		)
+	}
+	l.logger.Log("PlayerReplay", "end")
+	return
+}
+
 func (l *loggerMiddleware) PlayerClearStorage(ctx context.Context, playerIP, uuid string) (err error) {
 	l.logger.Log("PlayerClearStorage", "start")
 	if err = l.server.PlayerClearStorage(ctx, playerIP, uuid); err != nil {
@@ -147,17 +498,65 @@ func (l *loggerMiddleware) PlayerClearStorage(ctx context.Context, playerIP, uui
 	return
 }
 
-func (l *loggerMiddleware) StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string) (err error) {
+func (l *loggerMiddleware) PlayerSetVolume(ctx context.Context, playerIP, playerDeviceName string, volume float32) (err error) {
+	l.logger.Log("PlayerSetVolume", "start")
+	if err = l.server.PlayerSetVolume(ctx, playerIP, playerDeviceName, volume); err != nil {
+		l.logger.Log(
+			"PlayerSetVolume", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"volume", volume,
+			"err", err,
+		)
+	}
+	l.logger.Log("PlayerSetVolume", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlayerMute(ctx context.Context, playerIP, playerDeviceName string, mute bool) (err error) {
+	l.logger.Log("PlayerMute", "start")
+	if err = l.server.PlayerMute(ctx, playerIP, playerDeviceName, mute); err != nil {
+		l.logger.Log(
+			"PlayerMute", "err",
+			"playerIP", playerIP,
+			"playerDeviceName", playerDeviceName,
+			"mute", mute,
+			"err", err,
+		)
+	}
+	l.logger.Log("PlayerMute", "end")
+	return
+}
+
+func (l *loggerMiddleware) PlayerListDevices(ctx context.Context, playerIP string) (devices []pcm.Device, err error) {
+	l.logger.Log("PlayerListDevices", "start")
+	if devices, err = l.server.PlayerListDevices(ctx, playerIP); err != nil {
+		l.logger.Log(
+			"PlayerListDevices", "err",
+			"playerIP", playerIP,
+			"err", err,
+		)
+	}
+	l.logger.Log("PlayerListDevices", "end")
+	return
+}
+
+func (l *loggerMiddleware) StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64) (err error) {
 	l.logger.Log("StartFileRecording", "start")
-	if err = l.server.StartFileRecording(ctx, recorderIP, recorderDeviceName, channels, rate, receivePort, file); err != nil {
+	if err = l.server.StartFileRecording(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, receivePort, file, fileFormat, segmentDuration, segmentSize); err != nil {
 		l.logger.Log(
 			"StartFileRecording", "err",
 			"recorderIP", recorderIP,
 			"recorderDeviceName", recorderDeviceName,
 			"channels", channels,
 			"rate", rate,
+			"bitsPerSample", bitsPerSample,
+			"audioFormat", audioFormat,
 			"receivePort", receivePort,
 			"file", file,
+			"fileFormat", fileFormat,
+			"segmentDuration", segmentDuration,
+			"segmentSize", segmentSize,
 			"err", err,
 		)
 	}
@@ -180,9 +579,9 @@ func (l *loggerMiddleware) StopFileRecording(ctx context.Context, recorderIP, re
 	return
 }
 
-func (l *loggerMiddleware) PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string) (uuid string, err error) {
+func (l *loggerMiddleware) PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string) (uuid string, err error) {
 	l.logger.Log("PlayFromRecorder", "start")
-	if uuid, err = l.server.PlayFromRecorder(ctx, playerIP, playerPort, playerDeviceName, channels, rate, recorderIP, recorderDeviceName); err != nil {
+	if uuid, err = l.server.PlayFromRecorder(ctx, playerIP, playerPort, playerDeviceName, channels, rate, bitsPerSample, audioFormat, recorderIP, recorderDeviceName); err != nil {
 		l.logger.Log(
 			"PlayFromRecorder", "err",
 			"playerIP", playerIP,
@@ -190,6 +589,8 @@ func (l *loggerMiddleware) PlayFromRecorder(ctx context.Context, playerIP, playe
 			"playerDeviceName", playerDeviceName,
 			"channels", channels,
 			"rate", rate,
+			"bitsPerSample", bitsPerSample,
+			"audioFormat", audioFormat,
 			"recorderIP", recorderIP,
 			"recorderDeviceName", recorderDeviceName,
 			"err", err,
@@ -236,15 +637,17 @@ func (l *loggerMiddleware) RecorderState(ctx context.Context, recorderIP string)
 	return
 }
 
-func (l *loggerMiddleware) RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string) (err error) {
+func (l *loggerMiddleware) RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string) (err error) {
 	l.logger.Log("RecorderStart", "start")
-	if err = l.server.RecorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, dstAddr); err != nil {
+	if err = l.server.RecorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, dstAddr); err != nil {
 		l.logger.Log(
 			"RecorderStart", "err",
 			"recorderIP", recorderIP,
 			"recorderDeviceName", recorderDeviceName,
 			"channels", channels,
 			"rate", rate,
+			"bitsPerSample", bitsPerSample,
+			"audioFormat", audioFormat,
 			"dstAddr", dstAddr,
 			"err", err,
 		)
@@ -267,6 +670,27 @@ func (l *loggerMiddleware) RecorderStop(ctx context.Context, recorderIP, recorde
 	return
 }
 
+// Events log
+func (l *loggerMiddleware) RecorderListDevices(ctx context.Context, recorderIP string) (devices []pcm.Device, err error) {
+	l.logger.Log("RecorderListDevices", "start")
+	if devices, err = l.server.RecorderListDevices(ctx, recorderIP); err != nil {
+		l.logger.Log(
+			"RecorderListDevices", "err",
+			"recorderIP", recorderIP,
+			"err", err,
+		)
+	}
+	l.logger.Log("RecorderListDevices", "end")
+	return
+}
+
+func (l *loggerMiddleware) Events(ctx context.Context) (events <-chan event.Event, cancel func()) {
+	l.logger.Log("Events", "start")
+	events, cancel = l.server.Events(ctx)
+	l.logger.Log("Events", "end")
+	return
+}
+
 // NewLoggerMiddleware logger middleware for server.
 func NewLoggerMiddleware(server Server, logger log.Logger) Server {
 	return &loggerMiddleware{
@@ -0,0 +1,9 @@
+package server
+
+// mixSession mixed signal of sources streamed to player device
+type mixSession struct {
+	playerDeviceName string
+	uuid             string
+	// sources of mix, recorders of them are stopped with mix
+	sources []MixSource
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"net"
+)
+
+// multicastSession file sent once to multicast group and played on players joined to the group
+type multicastSession struct {
+	players []PlayerDevice
+	uuids   []string
+}
+
+// isMulticast check that groupAddr is host:port of multicast group
+func isMulticast(groupAddr string) bool {
+	host, _, err := net.SplitHostPort(groupAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsMulticast()
+}
+
+// joinMulticast players start receiving from group with codec supported by all players.
+// Codec is chosen by first player from codecs of server, other players must support it.
+func (s *server) joinMulticast(ctx context.Context, groupAddr string, players []PlayerDevice) (uuids []string, codec string, err error) {
+	for i, p := range players {
+		s.watchPlayer(p.PlayerIP)
+		codecs := s.codecs
+		if i != 0 {
+			codecs = []string{codec}
+		}
+		var uuid, chosen string
+		if uuid, chosen, err = s.player.ReceiveStart(ctx, p.PlayerIP, groupAddr, nil, codecs, false); err == nil && i != 0 && chosen != codec {
+			s.PlayerReceiveStop(ctx, p.PlayerIP, groupAddr)
+			s.PlayerClearStorage(ctx, p.PlayerIP, uuid)
+			err = ErrCodecMismatch
+		}
+		if err != nil {
+			s.leaveMulticast(ctx, groupAddr, players[:i], uuids)
+			return nil, "", err
+		}
+		codec = chosen
+		uuids = append(uuids, uuid)
+	}
+	return
+}
+
+// leaveMulticast players stop playing and receiving from group, storages are cleared
+func (s *server) leaveMulticast(ctx context.Context, groupAddr string, players []PlayerDevice, uuids []string) (err error) {
+	for i, p := range players {
+		s.PlayerStop(ctx, p.PlayerIP, p.PlayerDeviceName)
+		if stopErr := s.PlayerReceiveStop(ctx, p.PlayerIP, groupAddr); stopErr != nil {
+			err = stopErr
+		}
+		if clearErr := s.PlayerClearStorage(ctx, p.PlayerIP, uuids[i]); clearErr != nil {
+			err = clearErr
+		}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"io"
+	"io/ioutil"
+
+	"audio-service/pkg/playlist"
+)
+
+// playlistSession playlist streamed to player device
+type playlistSession struct {
+	playlist   *playlist.Playlist
+	playerPort string
+	uuid       string
+}
+
+// newPlaylist return empty playlist with channels, rate and format of samples
+func (s *server) newPlaylist(channels, rate, bitsPerSample, audioFormat uint32) *playlist.Playlist {
+	return playlist.NewPlaylist(
+		s.playlistOpener(channels, rate, bitsPerSample, audioFormat),
+		int(channels*rate*bitsPerSample/8),
+	)
+}
+
+// playlistOpener return opener of files converted to channels, rate and format of samples of playlist
+func (s *server) playlistOpener(channels, rate, bitsPerSample, audioFormat uint32) playlist.Opener {
+	return func(file string) (r io.Reader, err error) {
+		var data []byte
+		if data, err = ioutil.ReadFile(file); err != nil {
+			return
+		}
+		var (
+			fChannels, fBitsPerSample, fAudioFormat uint16
+			fRate                                   uint32
+		)
+		if r, fChannels, fRate, fBitsPerSample, fAudioFormat, err = s.audio.Reader(data); err != nil {
+			return
+		}
+		r = s.resampler.Convert(
+			r,
+			int(fChannels), int(fRate), int(fBitsPerSample), int(fAudioFormat),
+			int(channels), int(rate), int(bitsPerSample), int(audioFormat),
+		)
+		return
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"audio-service/pkg/cron"
+)
+
+// ScheduleJob scheduled playback of file on players
+type ScheduleJob struct {
+	ID string
+	// Cron expression of repeated playback, empty for one-time playback
+	Cron string
+	// At time of one-time playback
+	At       time.Time
+	File     string
+	Players  []PlayerDevice
+	Duration time.Duration
+
+	NextRun    time.Time
+	LastRun    time.Time
+	LastResult string
+}
+
+// scheduleTask playback stored in job of scheduler
+type scheduleTask struct {
+	File     string         `json:"file"`
+	Players  []PlayerDevice `json:"players"`
+	Duration time.Duration  `json:"duration"`
+}
+
+// scheduledRun file playbacks started by run of job
+type scheduledRun struct {
+	players []PlayerDevice
+	uuids   []string
+}
+
+// runJob play file of job on players, playback of previous run of job is stopped
+func (s *server) runJob(id string, task []byte) (err error) {
+	var t scheduleTask
+	if err = json.Unmarshal(task, &t); err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	s.stopScheduled(ctx, id, nil)
+
+	run := &scheduledRun{}
+	var errs []string
+	for _, p := range t.Players {
+		uuid, _, _, _, err := s.FilePlay(ctx, t.File, p.PlayerIP, p.PlayerPort, p.PlayerDeviceName, 0, 0)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", fmt.Sprintf(s.deviceLayout, p.PlayerIP, p.PlayerDeviceName), err))
+			continue
+		}
+		run.players = append(run.players, p)
+		run.uuids = append(run.uuids, uuid)
+	}
+
+	s.mutexScheduled.Lock()
+	s.scheduled[id] = run
+	s.mutexScheduled.Unlock()
+
+	if t.Duration > 0 {
+		time.AfterFunc(t.Duration, func() {
+			s.stopScheduled(context.Background(), id, run)
+		})
+	}
+
+	if len(errs) != 0 {
+		err = errors.New(strings.Join(errs, "; "))
+	}
+	return
+}
+
+// stopScheduled stop file playbacks of job with id started by run, nil run - by any run.
+// Playbacks already stopped or replaced on player are not touched.
+func (s *server) stopScheduled(ctx context.Context, id string, run *scheduledRun) {
+	s.mutexScheduled.Lock()
+	current, isExist := s.scheduled[id]
+	if !isExist || (run != nil && current != run) {
+		s.mutexScheduled.Unlock()
+		return
+	}
+	delete(s.scheduled, id)
+	s.mutexScheduled.Unlock()
+
+	for i, p := range current.players {
+		s.mutexFiles.Lock()
+		f, isExist := s.files[fmt.Sprintf(s.addrLayout, p.PlayerIP, p.PlayerPort)]
+		isPlaying := isExist && f.uuid == current.uuids[i]
+		s.mutexFiles.Unlock()
+
+		if isPlaying {
+			s.FileStop(ctx, p.PlayerIP, p.PlayerPort, p.PlayerDeviceName, current.uuids[i])
+		}
+	}
+}
+
+func toScheduleJob(job cron.Job) (scheduleJob ScheduleJob, err error) {
+	var t scheduleTask
+	err = json.Unmarshal(job.Task, &t)
+	scheduleJob = ScheduleJob{
+		ID:         job.ID,
+		Cron:       job.Cron,
+		At:         job.At,
+		File:       t.File,
+		Players:    t.Players,
+		Duration:   t.Duration,
+		NextRun:    job.NextRun,
+		LastRun:    job.LastRun,
+		LastResult: job.LastResult,
+	}
+	return
+}
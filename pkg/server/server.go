@@ -1,12 +1,21 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 	"sync"
+	"time"
+
+	"audio-service/pkg/cron"
+	"audio-service/pkg/event"
+	"audio-service/pkg/pcm"
+	"audio-service/pkg/segment"
 )
 
 // errors
@@ -15,11 +24,43 @@ var (
 	ErrDeviceNotFound = errors.New("device not found")
 	ErrPortIsBusy     = errors.New("port is busy")
 	ErrPortNotFound   = errors.New("port not found")
+	ErrFormatMismatch = errors.New("audio format mismatch")
+	ErrFileNotFound   = errors.New("file playback not found")
+	ErrFileIsPaused   = errors.New("file playback is paused")
+	ErrFileNotPaused  = errors.New("file playback is not paused")
+	ErrWrongPosition  = errors.New("position is out of file")
+
+	ErrNoPlayers    = errors.New("players are not set")
+	ErrNotMulticast = errors.New("address is not multicast group")
+
+	ErrPlaylistNotFound = errors.New("playlist not found")
+	ErrPlaylistIsEmpty  = errors.New("playlist is empty")
+
+	ErrMixNotFound = errors.New("mix not found")
+
+	ErrSchedulerDisabled = errors.New("scheduler is disabled")
+
+	ErrCodecMismatch = errors.New("codec is not supported")
 )
 
+// delay of start of group playing for setting up players
+const (
+	groupStartDelay  = 500 * time.Millisecond
+	groupPlayerDelay = 100 * time.Millisecond
+)
+
+// default format of audio signal from recorder
+const (
+	defaultBitsPerSample = 16
+	audioFormatPCM       = 1
+)
+
+// codecPCM signal is sent without encoding
+const codecPCM = "pcm"
+
 type audio interface {
-	Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample uint16, err error)
-	Writer(fileName string, channels uint16, rate uint32) (io.WriteCloser, error)
+	Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error)
+	Writer(fileName string, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (io.WriteCloser, error)
 }
 
 type tcp interface {
@@ -27,42 +68,121 @@ type tcp interface {
 	Receive(ctx context.Context, receivePort string, w io.Writer) (err error)
 }
 
+type mixer interface {
+	Reader(sources []io.Reader, gains []float64) io.Reader
+}
+
+type resampler interface {
+	Reader(r io.Reader, channels, rate, bitsPerSample, audioFormat, dstChannels, dstRate int) io.Reader
+	Convert(r io.Reader, channels, rate, bitsPerSample, audioFormat, dstChannels, dstRate, dstBitsPerSample, dstAudioFormat int) io.Reader
+}
+
+type codec interface {
+	Reader(name string, r io.Reader, channels, bitsPerSample, audioFormat int) (io.Reader, error)
+	Decoder(name string, w io.WriteCloser) (io.WriteCloser, error)
+}
+
+type scheduler interface {
+	Start(run cron.Runner)
+	Add(spec string, at time.Time, task []byte) (job cron.Job, err error)
+	Remove(id string) (err error)
+	Jobs() []cron.Job
+}
+
 type player interface {
 	State(ctx context.Context, ip string) (ports, storages, devices []string, err error)
-	ReceiveStart(ctx context.Context, ip, port string, uuid *string) (sUUID string, err error)
+	ReceiveStart(ctx context.Context, ip, port string, uuid *string, codecs []string, replay bool) (sUUID, codec string, err error)
 	ReceiveStop(ctx context.Context, ip, port string) (err error)
-	Play(ctx context.Context, ip, UUID, deviceName string, channels, rate, bitsPerSample uint32) (err error)
+	Play(ctx context.Context, ip, UUID, deviceName string, channels, rate, bitsPerSample, audioFormat uint32, startAt time.Time) (err error)
 	Stop(ctx context.Context, ip, deviceName string) (err error)
+	Wait(ctx context.Context, ip, deviceName, uuid string) (finished bool, err error)
+	Rewind(ctx context.Context, ip, deviceName string) (err error)
+	Replay(ctx context.Context, ip, deviceName string, startAt time.Time) (err error)
 	ClearStorage(ctx context.Context, ip, uuid string) (err error)
+	SetVolume(ctx context.Context, ip, deviceName string, volume float32) (err error)
+	Mute(ctx context.Context, ip, deviceName string, mute bool) (err error)
+	Events(ctx context.Context, ip string) (events <-chan event.Event, err error)
+	ListDevices(ctx context.Context, ip string) (devices []pcm.Device, err error)
 }
 
 type recorder interface {
 	State(ctx context.Context, ip string) (devices []string, err error)
-	Start(ctx context.Context, destAddr, recorderIP, deviceName string, channels, rate uint32) (err error)
+	Start(ctx context.Context, destAddr, recorderIP, deviceName string, channels, rate, bitsPerSample, audioFormat uint32, codecs []string) (codec string, err error)
 	Stop(ctx context.Context, recorderIP, deviceName string) (err error)
+	Events(ctx context.Context, recorderIP string) (events <-chan event.Event, err error)
+	ListDevices(ctx context.Context, recorderIP string) (devices []pcm.Device, err error)
+}
+
+// MixSource audio source for mixing: file on server or device on recorder
+type MixSource struct {
+	File string
+
+	RecorderIP         string
+	RecorderDeviceName string
+	ReceivePort        string
+
+	Gain float64
+}
+
+// PlayerDevice device on player, server sends audio signal to player on PlayerPort
+type PlayerDevice struct {
+	PlayerIP         string
+	PlayerPort       string
+	PlayerDeviceName string
 }
 
 // Server to control recorder and player
 type Server interface {
-	FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error)
+	FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error)
 	FileStop(ctx context.Context, playerIP, playerPort, playerDeviceName, uuid string) (err error)
+	FilePause(ctx context.Context, playerIP, playerPort string) (position time.Duration, err error)
+	FileResume(ctx context.Context, playerIP, playerPort string) (err error)
+	FileSeek(ctx context.Context, playerIP, playerPort string, position time.Duration) (err error)
+
+	GroupPlay(ctx context.Context, file string, players []PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error)
+	GroupStop(ctx context.Context, players []PlayerDevice) (err error)
+
+	MulticastPlay(ctx context.Context, file, groupAddr string, players []PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error)
+	MulticastStop(ctx context.Context, groupAddr string) (err error)
+
+	PlaylistEnqueue(ctx context.Context, playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32) (uuid string, err error)
+	PlaylistSkip(ctx context.Context, playerIP, playerDeviceName string) (err error)
+	PlaylistClear(ctx context.Context, playerIP, playerDeviceName string) (err error)
+	PlaylistMode(ctx context.Context, playerIP, playerDeviceName string, loop, shuffle bool) (err error)
+	PlaylistState(ctx context.Context, playerIP, playerDeviceName string) (files []string, current int, loop, shuffle bool, err error)
+	PlaylistStop(ctx context.Context, playerIP, playerDeviceName string) (err error)
+
+	ScheduleAdd(ctx context.Context, spec string, at time.Time, file string, players []PlayerDevice, duration time.Duration) (id string, nextRun time.Time, err error)
+	ScheduleRemove(ctx context.Context, id string) (err error)
+	ScheduleList(ctx context.Context) (jobs []ScheduleJob, err error)
+
+	MixPlay(ctx context.Context, sources []MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32) (uuid string, err error)
+	MixStop(ctx context.Context, playerIP, playerPort, uuid string) (err error)
 
 	PlayerState(ctx context.Context, playerIP string) (ports, storages, devices []string, err error)
-	PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string) (sUUID string, err error)
+	PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string, replay bool) (sUUID string, err error)
 	PlayerReceiveStop(ctx context.Context, playerIP, playerPort string) (err error)
-	PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32) (err error)
+	PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32) (err error)
 	PlayerStop(ctx context.Context, playerIP, playerDeviceName string) (err error)
+	PlayerRewind(ctx context.Context, playerIP, playerDeviceName string) (err error)
+	PlayerReplay(ctx context.Context, playerIP, playerDeviceName string) (err error)
 	PlayerClearStorage(ctx context.Context, playerIP, uuid string) (err error)
+	PlayerSetVolume(ctx context.Context, playerIP, playerDeviceName string, volume float32) (err error)
+	PlayerMute(ctx context.Context, playerIP, playerDeviceName string, mute bool) (err error)
+	PlayerListDevices(ctx context.Context, playerIP string) (devices []pcm.Device, err error)
 
 	//todo
-	StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string) (err error)
+	StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64) (err error)
 	StopFileRecording(ctx context.Context, recorderIP, recorderDeviceName, receivePort string) (err error)
-	PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string) (uuid string, err error)
+	PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string) (uuid string, err error)
 	StopFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName, uuid, recorderIP, recorderDeviceName string) (err error)
 
 	RecorderState(ctx context.Context, recorderIP string) (devices []string, err error)
-	RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string) (err error)
+	RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string) (err error)
 	RecorderStop(ctx context.Context, recorderIP, recorderDeviceName string) (err error)
+	RecorderListDevices(ctx context.Context, recorderIP string) (devices []pcm.Device, err error)
+
+	Events(ctx context.Context) (events <-chan event.Event, cancel func())
 }
 
 type server struct {
@@ -72,10 +192,38 @@ type server struct {
 	mutexReceiving sync.Mutex
 	receiving      map[string]func()
 
-	audio    audio
-	player   player
-	recorder recorder
-	tcp      tcp
+	mutexFiles sync.Mutex
+	files      map[string]*fileSession
+
+	mutexPlaylists sync.Mutex
+	playlists      map[string]*playlistSession
+
+	mutexMulticasts sync.Mutex
+	multicasts      map[string]*multicastSession
+
+	mutexMixes sync.Mutex
+	mixes      map[string]*mixSession
+
+	mutexScheduled sync.Mutex
+	scheduled      map[string]*scheduledRun
+
+	mutexWatched sync.Mutex
+	// watched players and recorders streaming events
+	watched map[string]struct{}
+	events  *event.Bus
+	// ctx of server, watching is stopped when it is done
+	ctx context.Context
+
+	audio     audio
+	mixer     mixer
+	resampler resampler
+	scheduler scheduler
+	player    player
+	recorder  recorder
+	tcp       tcp
+	codec     codec
+	// codecs of signal in order of preference
+	codecs []string
 
 	serverIP     string
 	addrLayout   string
@@ -83,44 +231,530 @@ type server struct {
 }
 
 // FilePlay send file to player with playerIP on port and play on playerDeviceName
-// channel and rate audio info from file.
+// format of samples audio info from file.
+// Audio is converted to dstChannels and dstRate before sending, 0 - channels or rate from file.
+// Player save audio from server in storage with uuid.
+func (s *server) FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string, dstChannels uint16, dstRate uint32) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error) {
+	f, err := s.readFile(file, uint32(dstChannels), dstRate)
+	if err != nil {
+		return
+	}
+	f.playerDeviceName = playerDeviceName
+	channels, rate, bitsPerSample = uint16(f.channels), f.rate, uint16(f.bitsPerSample)
+	if dstChannels != 0 {
+		channels = dstChannels
+	}
+	if dstRate != 0 {
+		rate = dstRate
+	}
+
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	if err = s.startFile(ctx, playerIP, playerPort, f); err != nil {
+		return
+	}
+	s.files[fmt.Sprintf(s.addrLayout, playerIP, playerPort)] = f
+	uuid = f.uuid
+	return
+}
+
+// FileStop stop send file to player with playerIP on port.
+// Stop play audio on playerDeviceName on player with playerIP
+// Clear storage with uuid on player with playerIP
+func (s *server) FileStop(ctx context.Context, playerIP, playerPort, playerDeviceName, uuid string) (err error) {
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	dstAddr := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
+	if f, isExist := s.files[dstAddr]; isExist && f.paused {
+		delete(s.files, dstAddr)
+		return
+	}
+	delete(s.files, dstAddr)
+
+	if err = s.stopSending(ctx, playerIP, playerPort); err != nil {
+		return
+	}
+	// player stops receiving by itself at end of stream
+	s.PlayerReceiveStop(ctx, playerIP, playerPort)
+	if err = s.PlayerStop(ctx, playerIP, playerDeviceName); err != nil {
+		return
+	}
+	return s.PlayerClearStorage(ctx, playerIP, uuid)
+}
+
+// FilePause pause file playing on player with playerIP on port.
+// Playback, sending and storage on player are stopped, file session is kept to resume from position.
+func (s *server) FilePause(ctx context.Context, playerIP, playerPort string) (position time.Duration, err error) {
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	f, isExist := s.files[fmt.Sprintf(s.addrLayout, playerIP, playerPort)]
+	if !isExist {
+		err = ErrFileNotFound
+		return
+	}
+	if f.paused {
+		err = ErrFileIsPaused
+		return
+	}
+
+	f.offset = f.played(time.Now())
+	f.paused = true
+	s.stopFile(ctx, playerIP, playerPort, f)
+	position = f.position(f.offset)
+	return
+}
+
+// FileResume resume paused file playing on player with playerIP on port from position of pause.
+func (s *server) FileResume(ctx context.Context, playerIP, playerPort string) (err error) {
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	f, isExist := s.files[fmt.Sprintf(s.addrLayout, playerIP, playerPort)]
+	if !isExist {
+		return ErrFileNotFound
+	}
+	if !f.paused {
+		return ErrFileNotPaused
+	}
+	return s.startFile(ctx, playerIP, playerPort, f)
+}
+
+// FileSeek move file playing on player with playerIP on port to position from start of file.
+// Paused file stays paused and is resumed from position.
+func (s *server) FileSeek(ctx context.Context, playerIP, playerPort string, position time.Duration) (err error) {
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	f, isExist := s.files[fmt.Sprintf(s.addrLayout, playerIP, playerPort)]
+	if !isExist {
+		return ErrFileNotFound
+	}
+	offset, err := f.byteOffset(position)
+	if err != nil {
+		return
+	}
+
+	if f.paused {
+		f.offset = offset
+		return
+	}
+	s.stopFile(ctx, playerIP, playerPort, f)
+	f.offset = offset
+	return s.startFile(ctx, playerIP, playerPort, f)
+}
+
+// GroupPlay send file to players and play on their devices synchronously from startAt.
+// Players keep playing in sync with their wall clocks, so clocks of players must be synchronized.
+// Audio is converted to dstChannels and dstRate before sending, 0 - channels or rate from file.
+// Players save audio from server in storages with uuids in order of players,
+// playing on each player is controlled as playing of file.
+func (s *server) GroupPlay(ctx context.Context, file string, players []PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error) {
+	if len(players) == 0 {
+		err = ErrNoPlayers
+		return
+	}
+	group, err := s.readFile(file, uint32(dstChannels), dstRate)
+	if err != nil {
+		return
+	}
+
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	startAt = time.Now().Add(groupStartDelay + time.Duration(len(players))*groupPlayerDelay)
+	started := make([]*fileSession, 0, len(players))
+	for _, p := range players {
+		f := *group
+		f.playerDeviceName = p.PlayerDeviceName
+		f.startAt = startAt
+		if err = s.startFile(ctx, p.PlayerIP, p.PlayerPort, &f); err != nil {
+			for i, startedFile := range started {
+				s.stopFile(ctx, players[i].PlayerIP, players[i].PlayerPort, startedFile)
+			}
+			return nil, time.Time{}, err
+		}
+		started = append(started, &f)
+	}
+
+	for i, p := range players {
+		s.files[fmt.Sprintf(s.addrLayout, p.PlayerIP, p.PlayerPort)] = started[i]
+		uuids = append(uuids, started[i].uuid)
+	}
+	return
+}
+
+// GroupStop stop playing file on players
+func (s *server) GroupStop(ctx context.Context, players []PlayerDevice) (err error) {
+	for _, p := range players {
+		s.mutexFiles.Lock()
+		f, isExist := s.files[fmt.Sprintf(s.addrLayout, p.PlayerIP, p.PlayerPort)]
+		s.mutexFiles.Unlock()
+		if !isExist {
+			err = ErrFileNotFound
+			continue
+		}
+		if stopErr := s.FileStop(ctx, p.PlayerIP, p.PlayerPort, p.PlayerDeviceName, f.uuid); stopErr != nil {
+			err = stopErr
+		}
+	}
+	return
+}
+
+// PlaylistEnqueue add files to playlist on playerDeviceName on player with playerIP.
+// Not existing playlist is created and sent to player on playerPort.
+// Files are played with channels and rate (0 - from first file) and format of samples from first file.
 // Player save audio from server in storage with uuid.
-func (s *server) FilePlay(ctx context.Context, file, playerIP, playerPort, playerDeviceName string) (uuid string, channels uint16, rate uint32, bitsPerSample uint16, err error) {
+func (s *server) PlaylistEnqueue(ctx context.Context, playerIP, playerPort, playerDeviceName string, files []string, channels, rate uint32) (uuid string, err error) {
+	s.mutexPlaylists.Lock()
+	defer s.mutexPlaylists.Unlock()
+
+	key := fmt.Sprintf(s.deviceLayout, playerIP, playerDeviceName)
+	if p, isExist := s.playlists[key]; isExist {
+		p.playlist.Enqueue(files...)
+		uuid = p.uuid
+		return
+	}
+	if len(files) == 0 {
+		err = ErrPlaylistIsEmpty
+		return
+	}
+
 	var data []byte
-	if data, err = ioutil.ReadFile(file); err != nil {
+	if data, err = ioutil.ReadFile(files[0]); err != nil {
 		return
 	}
-	var r io.Reader
-	if r, channels, rate, bitsPerSample, err = s.audio.Reader(data); err != nil {
+	var fChannels, bitsPerSample, audioFormat uint16
+	var fRate uint32
+	if _, fChannels, fRate, bitsPerSample, audioFormat, err = s.audio.Reader(data); err != nil {
 		return
 	}
+	if channels == 0 {
+		channels = uint32(fChannels)
+	}
+	if rate == 0 {
+		rate = fRate
+	}
+
+	p := &playlistSession{
+		playlist:   s.newPlaylist(channels, rate, uint32(bitsPerSample), uint32(audioFormat)),
+		playerPort: playerPort,
+	}
+	p.playlist.Enqueue(files...)
 
-	if uuid, err = s.PlayerReceiveStart(ctx, playerIP, playerPort, nil); err != nil {
+	var codec string
+	if p.uuid, codec, err = s.receiveStart(ctx, playerIP, playerPort, nil); err != nil {
+		return
+	}
+	r := &formatReader{
+		Reader:        p.playlist,
+		channels:      int(channels),
+		rate:          int(rate),
+		bitsPerSample: int(bitsPerSample),
+		audioFormat:   int(audioFormat),
+	}
+	if err = s.startSending(ctx, playerIP, playerPort, r, codec); err != nil {
+		s.PlayerReceiveStop(ctx, playerIP, playerPort)
+		s.PlayerClearStorage(ctx, playerIP, p.uuid)
+		return
+	}
+	if err = s.PlayerPlay(ctx, playerIP, p.uuid, playerDeviceName, channels, rate, uint32(bitsPerSample), uint32(audioFormat)); err != nil {
+		p.playlist.Close()
+		s.stopSending(ctx, playerIP, playerPort)
+		s.PlayerReceiveStop(ctx, playerIP, playerPort)
+		s.PlayerClearStorage(ctx, playerIP, p.uuid)
 		return
 	}
+	s.playlists[key] = p
+	uuid = p.uuid
+	return
+}
+
+// PlaylistSkip skip playing file of playlist on playerDeviceName on player with playerIP and play next one
+func (s *server) PlaylistSkip(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	p, err := s.findPlaylist(playerIP, playerDeviceName)
+	if err == nil {
+		p.playlist.Skip()
+	}
+	return
+}
+
+// PlaylistClear remove all files from playlist on playerDeviceName on player with playerIP.
+// Player device stays opened and plays files enqueued later.
+func (s *server) PlaylistClear(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	p, err := s.findPlaylist(playerIP, playerDeviceName)
+	if err == nil {
+		p.playlist.Clear()
+	}
+	return
+}
 
-	if err = s.startSending(ctx, playerIP, playerPort, r); err != nil {
+// PlaylistMode set playing playlist on playerDeviceName on player with playerIP in loop and in random order
+func (s *server) PlaylistMode(ctx context.Context, playerIP, playerDeviceName string, loop, shuffle bool) (err error) {
+	p, err := s.findPlaylist(playerIP, playerDeviceName)
+	if err == nil {
+		p.playlist.Mode(loop, shuffle)
+	}
+	return
+}
+
+// PlaylistState return files of playlist on playerDeviceName on player with playerIP,
+// index of playing file (-1 if nothing is playing) and mode of playlist
+func (s *server) PlaylistState(ctx context.Context, playerIP, playerDeviceName string) (files []string, current int, loop, shuffle bool, err error) {
+	p, err := s.findPlaylist(playerIP, playerDeviceName)
+	if err == nil {
+		files, current, loop, shuffle = p.playlist.State()
+	}
+	return
+}
+
+// PlaylistStop stop sending playlist to player with playerIP and playing on playerDeviceName, clear storage on player
+func (s *server) PlaylistStop(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	s.mutexPlaylists.Lock()
+	defer s.mutexPlaylists.Unlock()
+
+	key := fmt.Sprintf(s.deviceLayout, playerIP, playerDeviceName)
+	p, isExist := s.playlists[key]
+	if !isExist {
+		return ErrPlaylistNotFound
+	}
+	delete(s.playlists, key)
+
+	p.playlist.Close()
+	s.PlayerStop(ctx, playerIP, playerDeviceName)
+	s.stopSending(ctx, playerIP, p.playerPort)
+	s.PlayerReceiveStop(ctx, playerIP, p.playerPort)
+	return s.PlayerClearStorage(ctx, playerIP, p.uuid)
+}
+
+// MulticastPlay send file once to multicast group with groupAddr and play it on players joined to the group
+// synchronously from startAt, PlayerPort of players is not used.
+// Audio transport must be udp. Players choose the same codec, ErrCodecMismatch is returned if they cannot.
+// Audio is converted to dstChannels and dstRate before sending, 0 - channels or rate from file.
+// Players save audio from group in storages with uuids in order of players.
+func (s *server) MulticastPlay(ctx context.Context, file, groupAddr string, players []PlayerDevice, dstChannels uint16, dstRate uint32) (uuids []string, startAt time.Time, err error) {
+	if len(players) == 0 {
+		err = ErrNoPlayers
+		return
+	}
+	if !isMulticast(groupAddr) {
+		err = ErrNotMulticast
+		return
+	}
+	f, err := s.readFile(file, uint32(dstChannels), dstRate)
+	if err != nil {
+		return
+	}
+	channels, rate := f.channels, f.rate
+	if f.dstChannels != 0 {
+		channels = f.dstChannels
+	}
+	if f.dstRate != 0 {
+		rate = f.dstRate
+	}
+
+	s.mutexMulticasts.Lock()
+	defer s.mutexMulticasts.Unlock()
+
+	if _, isExist := s.multicasts[groupAddr]; isExist {
+		err = ErrDeviceIsBusy
+		return
+	}
+	var codec string
+	if uuids, codec, err = s.joinMulticast(ctx, groupAddr, players); err != nil {
 		return
 	}
 
-	if err = s.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, uint32(channels), rate, uint32(bitsPerSample)); err != nil {
+	startAt = time.Now().Add(groupStartDelay + time.Duration(len(players))*groupPlayerDelay)
+	for i, p := range players {
+		if err = s.player.Play(ctx, p.PlayerIP, uuids[i], p.PlayerDeviceName, channels, rate, f.bitsPerSample, f.audioFormat, startAt); err != nil {
+			s.leaveMulticast(ctx, groupAddr, players, uuids)
+			return nil, time.Time{}, err
+		}
+	}
+
+	r := &formatReader{
+		Reader: s.resampler.Reader(
+			bytes.NewReader(f.data),
+			int(f.channels), int(f.rate), int(f.bitsPerSample), int(f.audioFormat),
+			int(f.dstChannels), int(f.dstRate),
+		),
+		channels:      int(channels),
+		rate:          int(rate),
+		bitsPerSample: int(f.bitsPerSample),
+		audioFormat:   int(f.audioFormat),
+	}
+	if err = s.send(ctx, groupAddr, r, codec); err != nil {
+		s.leaveMulticast(ctx, groupAddr, players, uuids)
+		return nil, time.Time{}, err
+	}
+	s.multicasts[groupAddr] = &multicastSession{
+		players: players,
+		uuids:   uuids,
+	}
+	return
+}
+
+// MulticastStop stop sending to multicast group with groupAddr and playing on players joined to the group
+func (s *server) MulticastStop(ctx context.Context, groupAddr string) (err error) {
+	s.mutexMulticasts.Lock()
+	defer s.mutexMulticasts.Unlock()
+
+	m, isExist := s.multicasts[groupAddr]
+	if !isExist {
+		err = ErrDeviceNotFound
+		return
+	}
+	delete(s.multicasts, groupAddr)
+	s.stopSend(ctx, groupAddr)
+	return s.leaveMulticast(ctx, groupAddr, m.players, m.uuids)
+}
+
+// ScheduleAdd plan playing file on players by cron expression spec or once at time at if spec is empty.
+// Playback of previous run is stopped on next run, playback is stopped after duration if it is not 0.
+func (s *server) ScheduleAdd(ctx context.Context, spec string, at time.Time, file string, players []PlayerDevice, duration time.Duration) (id string, nextRun time.Time, err error) {
+	if s.scheduler == nil {
+		err = ErrSchedulerDisabled
+		return
+	}
+	task, err := json.Marshal(
+		scheduleTask{
+			File:     file,
+			Players:  players,
+			Duration: duration,
+		},
+	)
+	if err != nil {
+		return
+	}
+
+	job, err := s.scheduler.Add(spec, at, task)
+	return job.ID, job.NextRun, err
+}
+
+// ScheduleRemove remove scheduled playback with id, playback started by it is stopped
+func (s *server) ScheduleRemove(ctx context.Context, id string) (err error) {
+	if s.scheduler == nil {
+		return ErrSchedulerDisabled
+	}
+	if err = s.scheduler.Remove(id); err != nil {
+		return
+	}
+	s.stopScheduled(ctx, id, nil)
+	return
+}
+
+// ScheduleList return all scheduled playbacks with time of next and last run and result of last run
+func (s *server) ScheduleList(ctx context.Context) (jobs []ScheduleJob, err error) {
+	if s.scheduler == nil {
+		err = ErrSchedulerDisabled
+		return
+	}
+	for _, job := range s.scheduler.Jobs() {
+		var scheduleJob ScheduleJob
+		if scheduleJob, err = toScheduleJob(job); err != nil {
+			return
+		}
+		jobs = append(jobs, scheduleJob)
+	}
+	return
+}
+
+// MixPlay mix sources sample by sample with gain of each source and send mixed signal to player with playerIP on port and play on playerDeviceName.
+// Files must be 16 bits per sample and are converted to channels and rate, recorders start recording with channels and rate.
+// Player save audio from server in storage with uuid, mix is stopped by MixStop with player address and uuid.
+func (s *server) MixPlay(ctx context.Context, sources []MixSource, playerIP, playerPort, playerDeviceName string, channels, rate uint32) (uuid string, err error) {
+	s.mutexMixes.Lock()
+	defer s.mutexMixes.Unlock()
+
+	key := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
+	if _, isExist := s.mixes[key]; isExist {
+		err = ErrPortIsBusy
+		return
+	}
+
+	readers := make([]io.Reader, 0, len(sources))
+	gains := make([]float64, 0, len(sources))
+	started := make([]MixSource, 0, len(sources))
+	defer func() {
+		if err != nil {
+			s.stopMixSources(ctx, started)
+		}
+	}()
+
+	for _, source := range sources {
+		var r io.Reader
+		if source.File != "" {
+			if r, err = s.mixFileSource(source.File, channels, rate); err != nil {
+				return
+			}
+		} else {
+			pr, pw := io.Pipe()
+			if err = s.startRecording(ctx, source.RecorderIP, source.RecorderDeviceName, channels, rate, defaultBitsPerSample, audioFormatPCM, source.ReceivePort, pw); err != nil {
+				return
+			}
+			started = append(started, source)
+			r = pr
+		}
+		readers = append(readers, r)
+		gains = append(gains, source.Gain)
+	}
+
+	var codec string
+	if uuid, codec, err = s.receiveStart(ctx, playerIP, playerPort, nil); err != nil {
+		return
+	}
+
+	r := &formatReader{
+		Reader:        s.mixer.Reader(readers, gains),
+		channels:      int(channels),
+		rate:          int(rate),
+		bitsPerSample: defaultBitsPerSample,
+		audioFormat:   audioFormatPCM,
+	}
+	if err = s.startSending(ctx, playerIP, playerPort, r, codec); err != nil {
 		s.PlayerReceiveStop(ctx, playerIP, playerPort)
+		s.PlayerClearStorage(ctx, playerIP, uuid)
+		return
+	}
+
+	if err = s.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, defaultBitsPerSample, audioFormatPCM); err != nil {
 		s.stopSending(ctx, playerIP, playerPort)
+		s.PlayerReceiveStop(ctx, playerIP, playerPort)
+		s.PlayerClearStorage(ctx, playerIP, uuid)
+		return
+	}
+	s.mixes[key] = &mixSession{
+		playerDeviceName: playerDeviceName,
+		uuid:             uuid,
+		sources:          sources,
 	}
 	return
 }
 
-// FileStop stop send file to player with playerIP on port.
-// Stop play audio on playerDeviceName on player with playerIP
+// MixStop stop send mixed signal to player with playerIP on port and stop recorders of sources of mix.
+// Stop play audio on device of mix on player with playerIP
 // Clear storage with uuid on player with playerIP
-func (s *server) FileStop(ctx context.Context, playerIP, playerPort, playerDeviceName, uuid string) (err error) {
-	if err = s.stopSending(ctx, playerIP, playerPort); err != nil {
-		return
+func (s *server) MixStop(ctx context.Context, playerIP, playerPort, uuid string) (err error) {
+	s.mutexMixes.Lock()
+	defer s.mutexMixes.Unlock()
+
+	key := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
+	m, isExist := s.mixes[key]
+	if !isExist || m.uuid != uuid {
+		return ErrMixNotFound
 	}
-	if err = s.PlayerReceiveStop(ctx, playerIP, playerPort); err != nil {
+	delete(s.mixes, key)
+
+	if err = s.stopSending(ctx, playerIP, playerPort); err != nil {
 		return
 	}
-	if err = s.PlayerStop(ctx, playerIP, playerDeviceName); err != nil {
+	s.stopMixSources(ctx, m.sources)
+	// player stops receiving by itself at end of stream
+	s.PlayerReceiveStop(ctx, playerIP, playerPort)
+	if err = s.PlayerStop(ctx, playerIP, m.playerDeviceName); err != nil {
 		return
 	}
 	return s.PlayerClearStorage(ctx, playerIP, uuid)
@@ -134,9 +768,11 @@ func (s *server) PlayerState(ctx context.Context, playerIP string) (ports, stora
 // PlayerReceiveStart player with playerIP start receive signal from server on playerPort.
 // uuid of the storage existing on the player
 // if the storage with uuid does not exist or the uuid is nil, a new storage will be created on the player
-// The signal will be stored in the storage sUUID
-func (s *server) PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string) (sUUID string, err error) {
-	return s.player.ReceiveStart(ctx, playerIP, playerPort, uuid)
+// The signal will be stored in the storage sUUID, new storage keeps content for rewind and replay if replay is set
+func (s *server) PlayerReceiveStart(ctx context.Context, playerIP, playerPort string, uuid *string, replay bool) (sUUID string, err error) {
+	s.watchPlayer(playerIP)
+	sUUID, _, err = s.player.ReceiveStart(ctx, playerIP, playerPort, uuid, nil, replay)
+	return
 }
 
 // PlayerReceiveStop player with playerIP stop receive signal from server on playerPort.
@@ -145,9 +781,10 @@ func (s *server) PlayerReceiveStop(ctx context.Context, playerIP, playerPort str
 }
 
 // PlayerPlay play audio from storage with uuid on player with playerIP on playerDeviceName
-// channels, rate - params audio
-func (s *server) PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample uint32) (err error) {
-	return s.player.Play(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample)
+// channels, rate, bitsPerSample, audioFormat - params audio
+func (s *server) PlayerPlay(ctx context.Context, playerIP, uuid, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32) (err error) {
+	s.watchPlayer(playerIP)
+	return s.player.Play(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, audioFormat, time.Time{})
 }
 
 // PlayerStop pause audio on player with playerIP on playerDeviceName
@@ -155,27 +792,56 @@ func (s *server) PlayerStop(ctx context.Context, playerIP, playerDeviceName stri
 	return s.player.Stop(ctx, playerIP, playerDeviceName)
 }
 
+// PlayerRewind play audio on player with playerIP on playerDeviceName from beginning of storage
+func (s *server) PlayerRewind(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	return s.player.Rewind(ctx, playerIP, playerDeviceName)
+}
+
+// PlayerReplay play again last played storage on player with playerIP on playerDeviceName without sending audio
+func (s *server) PlayerReplay(ctx context.Context, playerIP, playerDeviceName string) (err error) {
+	return s.player.Replay(ctx, playerIP, playerDeviceName, time.Time{})
+}
+
 // PlayerClearStorage clear storage with uuid on player with playerIP
 func (s *server) PlayerClearStorage(ctx context.Context, playerIP, uuid string) (err error) {
 	return s.player.ClearStorage(ctx, playerIP, uuid)
 }
 
+// PlayerSetVolume set volume level on playerDeviceName on player with playerIP
+// 1 - original loudness
+func (s *server) PlayerSetVolume(ctx context.Context, playerIP, playerDeviceName string, volume float32) (err error) {
+	return s.player.SetVolume(ctx, playerIP, playerDeviceName, volume)
+}
+
+// PlayerMute mute or unmute playerDeviceName on player with playerIP
+func (s *server) PlayerMute(ctx context.Context, playerIP, playerDeviceName string, mute bool) (err error) {
+	return s.player.Mute(ctx, playerIP, playerDeviceName, mute)
+}
+
+// PlayerListDevices return playback devices on player with playerIP with supported rates, channels and formats
+func (s *server) PlayerListDevices(ctx context.Context, playerIP string) (devices []pcm.Device, err error) {
+	return s.player.ListDevices(ctx, playerIP)
+}
+
 // StartFileRecording start receive on receivePort audio signal from recorder with recorderIP from recordeDeviceName and write in file
-// channels, rate - params audio
-func (s *server) StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, receivePort, file string) (err error) {
-	var wc io.WriteCloser
-	if wc, err = s.audio.Writer(file, uint16(channels), rate); err != nil {
-		return
+// channels, rate, bitsPerSample, audioFormat - params audio
+// fileFormat - extension of file without dot (wav, flac), format is chosen by extension of file if it is empty
+// segmentDuration, segmentSize - limits of file, recording is continued in next file, zero disables the limit.
+// Name of file can contain {n} - number of segment and {time} - start of segment.
+func (s *server) StartFileRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort, file, fileFormat string, segmentDuration time.Duration, segmentSize int64) (err error) {
+	bitsPerSample, audioFormat = sampleFormat(bitsPerSample, audioFormat)
+	if fileFormat != "" && !strings.HasSuffix(file, "."+fileFormat) {
+		file += "." + fileFormat
 	}
-	if err = s.startReceive(ctx, recorderIP, receivePort, wc); err != nil {
-		return
+	open := func(fileName string) (io.WriteCloser, error) {
+		return s.audio.Writer(fileName, uint16(channels), rate, uint16(bitsPerSample), uint16(audioFormat))
 	}
-
-	receiveAddr := fmt.Sprintf(s.addrLayout, s.serverIP, receivePort)
-	if err = s.RecorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, receiveAddr); err != nil {
-		s.stopReceive(ctx, receivePort)
+	frameSize := int(channels * bitsPerSample / 8)
+	var wc io.WriteCloser
+	if wc, err = segment.NewWriter(open, file, frameSize, frameSize*int(rate), segmentDuration, segmentSize); err != nil {
+		return
 	}
-	return
+	return s.startRecording(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, receivePort, wc)
 }
 
 // StopFileRecording stop receive on receivePort audio signal from recorder with recorderIP from recordeDeviceName
@@ -186,19 +852,22 @@ func (s *server) StopFileRecording(ctx context.Context, recorderIP, recorderDevi
 }
 
 // PlayFromRecorder play audio on player with playerIP from recorder with recorderIP
-func (s *server) PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate uint32, recorderIP, recorderDeviceName string) (uuid string, err error) {
-	if uuid, err = s.PlayerReceiveStart(ctx, playerIP, playerPort, nil); err != nil {
+// channels, rate, bitsPerSample, audioFormat - params audio
+func (s *server) PlayFromRecorder(ctx context.Context, playerIP, playerPort, playerDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, recorderIP, recorderDeviceName string) (uuid string, err error) {
+	bitsPerSample, audioFormat = sampleFormat(bitsPerSample, audioFormat)
+	var codec string
+	if uuid, codec, err = s.receiveStart(ctx, playerIP, playerPort, nil); err != nil {
 		return
 	}
 
-	if err = s.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, 16); err != nil {
+	if err = s.PlayerPlay(ctx, playerIP, uuid, playerDeviceName, channels, rate, bitsPerSample, audioFormat); err != nil {
 		s.PlayerReceiveStop(ctx, playerIP, playerPort)
 		s.PlayerClearStorage(ctx, playerIP, uuid)
 		return
 	}
 
 	dstAddr := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
-	if err = s.RecorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, dstAddr); err != nil {
+	if _, err = s.recorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, dstAddr, []string{codec}); err != nil {
 		s.PlayerReceiveStop(ctx, playerIP, playerPort)
 		s.PlayerStop(ctx, playerIP, playerDeviceName)
 		s.PlayerClearStorage(ctx, playerIP, uuid)
@@ -221,9 +890,12 @@ func (s *server) RecorderState(ctx context.Context, recorderIP string) (devices
 }
 
 // RecorderStart start recording audio on recorder with recorderIP from recorderDeviceName and receive on dstAddr
-// channels, rate - recording param
-func (s *server) RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate uint32, dstAddr string) error {
-	return s.recorder.Start(ctx, dstAddr, recorderIP, recorderDeviceName, channels, rate)
+// channels, rate, bitsPerSample, audioFormat - recording param
+func (s *server) RecorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string) error {
+	s.watchRecorder(recorderIP)
+	bitsPerSample, audioFormat = sampleFormat(bitsPerSample, audioFormat)
+	_, err := s.recorder.Start(ctx, dstAddr, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, nil)
+	return err
 }
 
 // RecorderStop stop recording audio on recorder with recorderIP from recorderDeviceName
@@ -231,14 +903,181 @@ func (s *server) RecorderStop(ctx context.Context, recorderIP, recorderDeviceNam
 	return s.recorder.Stop(ctx, recorderIP, recorderDeviceName)
 }
 
-func (s *server) startSending(ctx context.Context, playerIP, playerPort string, r io.Reader) (err error) {
+// RecorderListDevices return capture devices on recorder with recorderIP with supported rates, channels and formats
+func (s *server) RecorderListDevices(ctx context.Context, recorderIP string) (devices []pcm.Device, err error) {
+	return s.recorder.ListDevices(ctx, recorderIP)
+}
+
+func (s *server) mixFileSource(file string, channels, rate uint32) (r io.Reader, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	var (
+		fChannels, fBitsPerSample, fAudioFormat uint16
+		fRate                                   uint32
+	)
+	if r, fChannels, fRate, fBitsPerSample, fAudioFormat, err = s.audio.Reader(data); err != nil {
+		return
+	}
+	if fBitsPerSample != defaultBitsPerSample || fAudioFormat != audioFormatPCM {
+		err = ErrFormatMismatch
+		return
+	}
+	r = s.resampler.Reader(r, int(fChannels), int(fRate), int(fBitsPerSample), int(fAudioFormat), int(channels), int(rate))
+	return
+}
+
+func (s *server) stopMixSources(ctx context.Context, sources []MixSource) {
+	for _, source := range sources {
+		if source.File == "" {
+			s.RecorderStop(ctx, source.RecorderIP, source.RecorderDeviceName)
+			s.stopReceive(ctx, source.ReceivePort)
+		}
+	}
+}
+
+// readFile read file for playing with audio converted to dstChannels and dstRate
+func (s *server) readFile(file string, dstChannels, dstRate uint32) (f *fileSession, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(file); err != nil {
+		return
+	}
+	var (
+		r                                    io.Reader
+		channels, bitsPerSample, audioFormat uint16
+		rate                                 uint32
+	)
+	if r, channels, rate, bitsPerSample, audioFormat, err = s.audio.Reader(data); err != nil {
+		return
+	}
+	f = &fileSession{
+		channels:      uint32(channels),
+		rate:          rate,
+		bitsPerSample: uint32(bitsPerSample),
+		audioFormat:   uint32(audioFormat),
+		dstChannels:   dstChannels,
+		dstRate:       dstRate,
+	}
+	f.data, err = ioutil.ReadAll(r)
+	return
+}
+
+// startFile start sending file data from offset and playing on player.
+// Storage on player is created with uuid of file session if it is set.
+func (s *server) startFile(ctx context.Context, playerIP, playerPort string, f *fileSession) (err error) {
+	var uuid *string
+	if f.uuid != "" {
+		uuid = &f.uuid
+	}
+	var codec string
+	if f.uuid, codec, err = s.receiveStart(ctx, playerIP, playerPort, uuid); err != nil {
+		return
+	}
+
+	channels, rate := f.channels, f.rate
+	if f.dstChannels != 0 {
+		channels = f.dstChannels
+	}
+	if f.dstRate != 0 {
+		rate = f.dstRate
+	}
+
+	r := &formatReader{
+		Reader: s.resampler.Reader(
+			bytes.NewReader(f.data[f.offset:]),
+			int(f.channels), int(f.rate), int(f.bitsPerSample), int(f.audioFormat),
+			int(f.dstChannels), int(f.dstRate),
+		),
+		channels:      int(channels),
+		rate:          int(rate),
+		bitsPerSample: int(f.bitsPerSample),
+		audioFormat:   int(f.audioFormat),
+	}
+	if err = s.startSending(ctx, playerIP, playerPort, r, codec); err != nil {
+		s.PlayerReceiveStop(ctx, playerIP, playerPort)
+		s.PlayerClearStorage(ctx, playerIP, f.uuid)
+		return
+	}
+	if err = s.player.Play(ctx, playerIP, f.uuid, f.playerDeviceName, channels, rate, f.bitsPerSample, f.audioFormat, f.startAt); err != nil {
+		s.stopSending(ctx, playerIP, playerPort)
+		s.PlayerReceiveStop(ctx, playerIP, playerPort)
+		s.PlayerClearStorage(ctx, playerIP, f.uuid)
+		return
+	}
+	f.startTime = time.Now()
+	if !f.startAt.IsZero() {
+		// playing after pause or seek is not synchronized
+		f.startTime, f.startAt = f.startAt, time.Time{}
+	}
+	f.paused = false
+	go s.waitFile(playerIP, playerPort, f)
+	return
+}
+
+// waitFile wait end of playing file on player, file session played to end is released
+func (s *server) waitFile(playerIP, playerPort string, f *fileSession) {
+	ctx := context.Background()
+	if finished, err := s.player.Wait(ctx, playerIP, f.playerDeviceName, f.uuid); err != nil || !finished {
+		return
+	}
+
+	s.mutexFiles.Lock()
+	defer s.mutexFiles.Unlock()
+
+	dstAddr := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
+	if s.files[dstAddr] != f || f.paused {
+		return
+	}
+	delete(s.files, dstAddr)
+	s.stopSending(ctx, playerIP, playerPort)
+	s.PlayerReceiveStop(ctx, playerIP, playerPort)
+	s.PlayerClearStorage(ctx, playerIP, f.uuid)
+}
+
+// stopFile stop playing, sending and clear storage of file session on player
+func (s *server) stopFile(ctx context.Context, playerIP, playerPort string, f *fileSession) {
+	s.PlayerStop(ctx, playerIP, f.playerDeviceName)
+	s.stopSending(ctx, playerIP, playerPort)
+	s.PlayerReceiveStop(ctx, playerIP, playerPort)
+	s.PlayerClearStorage(ctx, playerIP, f.uuid)
+}
+
+func (s *server) findPlaylist(playerIP, playerDeviceName string) (p *playlistSession, err error) {
+	s.mutexPlaylists.Lock()
+	defer s.mutexPlaylists.Unlock()
+
+	p, isExist := s.playlists[fmt.Sprintf(s.deviceLayout, playerIP, playerDeviceName)]
+	if !isExist {
+		err = ErrPlaylistNotFound
+	}
+	return
+}
+
+// startSending signal encoded by codec to player, format of samples is sent only with pcm
+func (s *server) startSending(ctx context.Context, playerIP, playerPort string, r *formatReader, codec string) (err error) {
+	return s.send(ctx, fmt.Sprintf(s.addrLayout, playerIP, playerPort), r, codec)
+}
+
+func (s *server) stopSending(ctx context.Context, playerIP, playerPort string) (err error) {
+	return s.stopSend(ctx, fmt.Sprintf(s.addrLayout, playerIP, playerPort))
+}
+
+// send signal encoded by codec to dstAddr, dstAddr can be address of multicast group
+func (s *server) send(ctx context.Context, dstAddr string, r *formatReader, codec string) (err error) {
 	s.mutexSending.Lock()
 	defer s.mutexSending.Unlock()
 
-	dstAddr := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
+	var src io.Reader = r
+	if codec != codecPCM {
+		if src, err = s.codec.Reader(codec, r, r.channels, r.bitsPerSample, r.audioFormat); err != nil {
+			return
+		}
+	}
+
 	if _, isExist := s.sending[dstAddr]; !isExist {
 		c, stop := context.WithCancel(context.Background())
-		if err = s.tcp.Send(c, dstAddr, r); err == nil {
+		if err = s.tcp.Send(c, dstAddr, src); err == nil {
 			s.sending[dstAddr] = stop
 			return
 		}
@@ -249,11 +1088,10 @@ func (s *server) startSending(ctx context.Context, playerIP, playerPort string,
 	return
 }
 
-func (s *server) stopSending(ctx context.Context, playerIP, playerPort string) (err error) {
+func (s *server) stopSend(ctx context.Context, dstAddr string) (err error) {
 	s.mutexSending.Lock()
 	defer s.mutexSending.Unlock()
 
-	dstAddr := fmt.Sprintf(s.addrLayout, playerIP, playerPort)
 	if stop, isExist := s.sending[dstAddr]; isExist {
 		stop()
 		delete(s.sending, dstAddr)
@@ -263,6 +1101,7 @@ func (s *server) stopSending(ctx context.Context, playerIP, playerPort string) (
 	return
 }
 
+// startReceive signal on receivePort, received signal is written to wc
 func (s *server) startReceive(ctx context.Context, recorderIP, receivePort string, wc io.WriteCloser) (err error) {
 	s.mutexReceiving.Lock()
 	defer s.mutexReceiving.Unlock()
@@ -283,6 +1122,50 @@ func (s *server) startReceive(ctx context.Context, recorderIP, receivePort strin
 	return
 }
 
+// receiveStart player starts receiving signal encoded by first codec of server supported by player
+func (s *server) receiveStart(ctx context.Context, playerIP, playerPort string, uuid *string) (sUUID, codec string, err error) {
+	s.watchPlayer(playerIP)
+	return s.player.ReceiveStart(ctx, playerIP, playerPort, uuid, s.codecs, false)
+}
+
+// recorderStart recorder starts sending signal encoded by codec chosen by recorder from codecs
+func (s *server) recorderStart(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, dstAddr string, codecs []string) (codec string, err error) {
+	s.watchRecorder(recorderIP)
+	bitsPerSample, audioFormat = sampleFormat(bitsPerSample, audioFormat)
+	if codec, err = s.recorder.Start(ctx, dstAddr, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, codecs); err != nil {
+		return
+	}
+	for _, offered := range codecs {
+		if codec == offered {
+			return
+		}
+	}
+	// recorder sends pcm if it supports no offered codec
+	s.recorder.Stop(ctx, recorderIP, recorderDeviceName)
+	return "", ErrCodecMismatch
+}
+
+// startRecording recorder starts sending signal to receivePort of server, codec is chosen by recorder
+// from codecs of server, decoded signal is written to wc
+func (s *server) startRecording(ctx context.Context, recorderIP, recorderDeviceName string, channels, rate, bitsPerSample, audioFormat uint32, receivePort string, wc io.WriteCloser) (err error) {
+	d := newDecoderWriter(s.codec, wc)
+	if err = s.startReceive(ctx, recorderIP, receivePort, d); err != nil {
+		return
+	}
+
+	receiveAddr := fmt.Sprintf(s.addrLayout, s.serverIP, receivePort)
+	var codec string
+	if codec, err = s.recorderStart(ctx, recorderIP, recorderDeviceName, channels, rate, bitsPerSample, audioFormat, receiveAddr, s.codecs); err == nil {
+		if err = d.choose(codec); err != nil {
+			s.recorder.Stop(ctx, recorderIP, recorderDeviceName)
+		}
+	}
+	if err != nil {
+		s.stopReceive(ctx, receivePort)
+	}
+	return
+}
+
 func (s *server) stopReceive(ctx context.Context, receivePort string) (err error) {
 	s.mutexReceiving.Lock()
 	defer s.mutexReceiving.Unlock()
@@ -296,28 +1179,128 @@ func (s *server) stopReceive(ctx context.Context, receivePort string) (err error
 	return
 }
 
-// NewServer ...
+// NewServer streaming of events from players and recorders is stopped when ctx is done
 func NewServer(
+	ctx context.Context,
 	audio audio,
+	mixer mixer,
+	resampler resampler,
+	scheduler scheduler,
 	recorder recorder,
 	player player,
 	tcp tcp,
+	codec codec,
+	codecs []string,
 
 	serverIP string,
 	addrLayout string,
 	deviceLayout string,
 ) Server {
-	return &server{
-		receiving: make(map[string]func()),
-		sending:   make(map[string]func()),
+	s := &server{
+		receiving:  make(map[string]func()),
+		sending:    make(map[string]func()),
+		files:      make(map[string]*fileSession),
+		playlists:  make(map[string]*playlistSession),
+		multicasts: make(map[string]*multicastSession),
+		mixes:      make(map[string]*mixSession),
+		scheduled:  make(map[string]*scheduledRun),
+		watched:    make(map[string]struct{}),
+		events:     event.NewBus(eventsBuffSize),
+		ctx:        ctx,
 
-		audio:    audio,
-		recorder: recorder,
-		player:   player,
-		tcp:      tcp,
+		audio:     audio,
+		mixer:     mixer,
+		resampler: resampler,
+		scheduler: scheduler,
+		recorder:  recorder,
+		player:    player,
+		tcp:       tcp,
+		codec:     codec,
+		codecs:    codecs,
 
 		serverIP:     serverIP,
 		addrLayout:   addrLayout,
 		deviceLayout: deviceLayout,
 	}
+	if scheduler != nil {
+		scheduler.Start(s.runJob)
+	}
+	return s
+}
+
+// formatReader audio signal with format of samples, format is sent by transports supporting it
+type formatReader struct {
+	io.Reader
+
+	channels, rate             int
+	bitsPerSample, audioFormat int
+}
+
+// Format of samples
+func (r *formatReader) Format() (channels, rate, bitsPerSample, audioFormat int) {
+	return r.channels, r.rate, r.bitsPerSample, r.audioFormat
+}
+
+// decoderWriter signal decoded by codec chosen by recorder.
+// Receiving is started before recorder chooses codec, so writing waits for choice.
+type decoderWriter struct {
+	codec codec
+	wc    io.WriteCloser
+
+	once    sync.Once
+	ready   chan struct{}
+	decoder io.WriteCloser
+}
+
+func (d *decoderWriter) Write(data []byte) (int, error) {
+	<-d.ready
+	if d.decoder == nil {
+		return 0, ErrCodecMismatch
+	}
+	return d.decoder.Write(data)
+}
+
+// Close decoder, waiting writing is failed if codec is not chosen
+func (d *decoderWriter) Close() error {
+	d.set(nil)
+	if d.decoder == nil {
+		return d.wc.Close()
+	}
+	return d.decoder.Close()
+}
+
+// choose codec of signal
+func (d *decoderWriter) choose(name string) (err error) {
+	decoder, err := d.codec.Decoder(name, d.wc)
+	if err != nil {
+		err = ErrCodecMismatch
+	}
+	d.set(decoder)
+	return
+}
+
+func (d *decoderWriter) set(decoder io.WriteCloser) {
+	d.once.Do(func() {
+		d.decoder = decoder
+		close(d.ready)
+	})
+}
+
+func newDecoderWriter(codec codec, wc io.WriteCloser) *decoderWriter {
+	return &decoderWriter{
+		codec: codec,
+		wc:    wc,
+		ready: make(chan struct{}),
+	}
+}
+
+// sampleFormat return format of samples with default values for not set params
+func sampleFormat(bitsPerSample, audioFormat uint32) (uint32, uint32) {
+	if bitsPerSample == 0 {
+		bitsPerSample = defaultBitsPerSample
+	}
+	if audioFormat == 0 {
+		audioFormat = audioFormatPCM
+	}
+	return bitsPerSample, audioFormat
 }
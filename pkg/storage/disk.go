@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrWrongUUID uuid can not be name of file
+var ErrWrongUUID = errors.New("wrong uuid of storage")
+
+const (
+	// extensions of files of storage: samples and format
+	dataExtension   = ".pcm"
+	formatExtension = ".json"
+)
+
+// Disk creator of storages persisted in directory, storages survive restart of player.
+// Content of storage is kept after reading and can be played again.
+type Disk struct {
+	dir string
+}
+
+// Create storage with uuid, existing storage is opened with its content
+func (d *Disk) Create(uuid string) (io.ReadWriteCloser, error) {
+	if uuid == "" || uuid != filepath.Base(uuid) || strings.HasPrefix(uuid, ".") {
+		return nil, ErrWrongUUID
+	}
+	return openFile(filepath.Join(d.dir, uuid))
+}
+
+// CreateReplay storage with uuid, content of disk storages is always kept
+func (d *Disk) CreateReplay(uuid string) (io.ReadWriteCloser, error) {
+	return d.Create(uuid)
+}
+
+// Storages return uuids of storages in directory
+func (d *Disk) Storages() (uuids []string) {
+	files, _ := ioutil.ReadDir(d.dir)
+	for _, f := range files {
+		if name := f.Name(); !f.IsDir() && strings.HasSuffix(name, dataExtension) {
+			uuids = append(uuids, strings.TrimSuffix(name, dataExtension))
+		}
+	}
+	return
+}
+
+// NewDisk storages are kept in dir, dir is created if it does not exist
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Disk{
+		dir: dir,
+	}, nil
+}
+
+type format struct {
+	Channels      int `json:"channels"`
+	Rate          int `json:"rate"`
+	BitsPerSample int `json:"bitsPerSample"`
+	AudioFormat   int `json:"audioFormat"`
+}
+
+// file storage, data is appended to file, format of samples is kept next to it
+type file struct {
+	mutex sync.Mutex
+	// path of files without extension
+	path   string
+	file   *os.File
+	size   int64
+	format format
+	closed bool
+	// finished writing, reading at end of file returns io.EOF
+	finished bool
+	// written is closed and replaced on each write to wake up readers
+	written chan struct{}
+
+	// offset of Read
+	offset int64
+}
+
+// Write append data to file
+func (f *file) Write(data []byte) (n int, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, err = f.file.WriteAt(data, f.size)
+	f.size += int64(n)
+	f.finished = false
+	f.wakeUp()
+	return
+}
+
+// Finish writing, readers get io.EOF at end of file until next write
+func (f *file) Finish() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !f.closed {
+		f.finished = true
+		f.wakeUp()
+	}
+}
+
+// wakeUp readers waiting for data
+func (f *file) wakeUp() {
+	close(f.written)
+	f.written = make(chan struct{})
+}
+
+// Read data from last read position, content of file is kept
+func (f *file) Read(data []byte) (n int, err error) {
+	n, err = f.readAt(data, f.offset)
+	f.offset += int64(n)
+	return
+}
+
+// readAt read data from offset.
+// Reading at end of file waits for data readWait, io.EOF is returned at end of finished file.
+func (f *file) readAt(data []byte, offset int64) (n int, err error) {
+	var timer *time.Timer
+	for {
+		f.mutex.Lock()
+		if f.closed {
+			f.mutex.Unlock()
+			return 0, io.EOF
+		}
+		if offset < f.size {
+			if size := f.size - offset; int64(len(data)) > size {
+				data = data[:size]
+			}
+			n, err = f.file.ReadAt(data, offset)
+			f.mutex.Unlock()
+			return
+		}
+		written, finished := f.written, f.finished
+		f.mutex.Unlock()
+		if finished {
+			return 0, io.EOF
+		}
+
+		if timer == nil {
+			timer = time.NewTimer(readWait)
+			defer timer.Stop()
+		}
+		select {
+		case <-written:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// Close storage, files of storage are removed
+func (f *file) Close() (err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.closed {
+		return
+	}
+	f.closed = true
+	close(f.written)
+	err = f.file.Close()
+	os.Remove(f.path + dataExtension)
+	os.Remove(f.path + formatExtension)
+	return
+}
+
+// Cursor independent reader of storage from beginning, storage can be played several times
+func (f *file) Cursor() io.Reader {
+	return &cursor{
+		file: f,
+	}
+}
+
+// SetFormat of samples in storage, format is saved for duration of storage
+func (f *file) SetFormat(channels, rate, bitsPerSample, audioFormat int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.format = format{
+		Channels:      channels,
+		Rate:          rate,
+		BitsPerSample: bitsPerSample,
+		AudioFormat:   audioFormat,
+	}
+	if data, err := json.Marshal(f.format); err == nil {
+		ioutil.WriteFile(f.path+formatExtension, data, 0644)
+	}
+}
+
+// Fill level of storage: size of file, duration of audio if format is known, capacity is unbounded
+func (f *file) Fill() (size, capacity int, duration time.Duration, dropped uint64) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if byteRate := f.format.Channels * f.format.Rate * f.format.BitsPerSample / 8; byteRate > 0 {
+		duration = time.Duration(f.size) * time.Second / time.Duration(byteRate)
+	}
+	return int(f.size), 0, duration, 0
+}
+
+type cursor struct {
+	mutex  sync.Mutex
+	file   *file
+	offset int64
+}
+
+// Read data from cursor position
+func (c *cursor) Read(data []byte) (n int, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	n, err = c.file.readAt(data, c.offset)
+	c.offset += int64(n)
+	return
+}
+
+// Rewind cursor to beginning of file
+func (c *cursor) Rewind() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.offset = 0
+}
+
+func openFile(path string) (f *file, err error) {
+	osFile, err := os.OpenFile(path+dataExtension, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return
+	}
+	info, err := osFile.Stat()
+	if err != nil {
+		osFile.Close()
+		return
+	}
+	// content of existing storage was received before restart
+	f = &file{
+		path:     path,
+		file:     osFile,
+		size:     info.Size(),
+		finished: info.Size() != 0,
+		written:  make(chan struct{}),
+	}
+	if data, err := ioutil.ReadFile(path + formatExtension); err == nil {
+		json.Unmarshal(data, &f.format)
+	}
+	return
+}
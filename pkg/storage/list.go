@@ -2,6 +2,8 @@ package storage
 
 import (
 	"io"
+	"sync"
+	"time"
 )
 
 type element struct {
@@ -9,10 +11,22 @@ type element struct {
 	next *element
 }
 
-// Queue FIFO data struct.
+// Queue FIFO data struct, read elements are dropped.
+// Writing and reading are safe from different goroutines.
 type queue struct {
-	top  *element
-	back *element
+	mutex  sync.Mutex
+	top    *element
+	back   *element
+	closed bool
+	// keep content after reading until Close
+	keep bool
+	// finished writing, reading at end of queue returns io.EOF
+	finished bool
+	// written is closed and replaced on each write to wake up readers
+	written chan struct{}
+
+	// cursor of Read
+	cursor *listCursor
 }
 
 // Write on back element
@@ -22,6 +36,13 @@ func (q *queue) Write(data []byte) (n int, err error) {
 		data: data,
 	}
 
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.closed {
+		return 0, io.ErrClosedPipe
+	}
+
 	if q.back != nil {
 		q.back.next = element
 	}
@@ -31,21 +52,129 @@ func (q *queue) Write(data []byte) (n int, err error) {
 	if q.top == nil {
 		q.top = element
 	}
-	return
+	q.finished = false
+	q.wakeUp()
+	return len(data), nil
 }
 
-// Read return and delete element from top
-func (q *queue) Read(data []byte) (n int, err error) {
-	if q.top != nil {
-		n = len(q.top.data)
-		copy(data, q.top.data)
-		q.top = q.top.next
-		return n, nil
+// Finish writing, readers get io.EOF at end of queue until next write
+func (q *queue) Finish() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if !q.closed {
+		q.finished = true
+		q.wakeUp()
 	}
-	return 0, io.EOF
+}
+
+// wakeUp readers waiting for data
+func (q *queue) wakeUp() {
+	close(q.written)
+	q.written = make(chan struct{})
+}
+
+// Read from last read position
+func (q *queue) Read(data []byte) (n int, err error) {
+	return q.cursor.Read(data)
 }
 
 func (q *queue) Close() (err error) {
-	q.top, q.back = nil, nil
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if !q.closed {
+		q.top, q.back = nil, nil
+		q.closed = true
+		close(q.written)
+	}
 	return
 }
+
+func newQueue(keep bool) *queue {
+	q := &queue{
+		keep:    keep,
+		written: make(chan struct{}),
+	}
+	q.cursor = &listCursor{
+		queue: q,
+	}
+	return q
+}
+
+// replayQueue queue keeping content after reading until Close, each cursor reads it from beginning
+type replayQueue struct {
+	*queue
+}
+
+// Cursor independent reader of queue from beginning, queue can be played several times
+func (q *replayQueue) Cursor() io.Reader {
+	return &listCursor{
+		queue: q.queue,
+	}
+}
+
+func newReplayQueue() *replayQueue {
+	return &replayQueue{
+		queue: newQueue(true),
+	}
+}
+
+// listCursor position in queue, it is protected by mutex of queue
+type listCursor struct {
+	queue *queue
+	// element is current element, nil before first element
+	element *element
+	offset  int
+}
+
+// Read data from cursor position.
+// Reading at end of queue waits for data readWait, io.EOF is returned at end of finished queue.
+func (c *listCursor) Read(data []byte) (n int, err error) {
+	var timer *time.Timer
+	for {
+		c.queue.mutex.Lock()
+		if c.queue.closed {
+			c.queue.mutex.Unlock()
+			return 0, io.EOF
+		}
+		if c.element == nil {
+			c.element = c.queue.top
+		}
+		for c.element != nil && c.offset == len(c.element.data) && c.element.next != nil {
+			c.element, c.offset = c.element.next, 0
+			if !c.queue.keep {
+				c.queue.top = c.element
+			}
+		}
+		if c.element != nil && c.offset < len(c.element.data) {
+			n = copy(data, c.element.data[c.offset:])
+			c.offset += n
+			c.queue.mutex.Unlock()
+			return
+		}
+		written, finished := c.queue.written, c.queue.finished
+		c.queue.mutex.Unlock()
+		if finished {
+			return 0, io.EOF
+		}
+
+		if timer == nil {
+			timer = time.NewTimer(readWait)
+			defer timer.Stop()
+		}
+		select {
+		case <-written:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// Rewind cursor to beginning of queue
+func (c *listCursor) Rewind() {
+	c.queue.mutex.Lock()
+	defer c.queue.mutex.Unlock()
+
+	c.element, c.offset = nil, 0
+}
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Overflow policies of ring storage
+const (
+	// OverflowBlock writer waits until reader frees space
+	OverflowBlock = "block"
+	// OverflowDrop oldest samples are dropped for new ones
+	OverflowDrop = "drop"
+)
+
+const (
+	// readWait of data in empty storage, reading returns nothing after it until writing is finished
+	readWait = 100 * time.Millisecond
+	// defaultByteRate of samples before format is known: 44100 Hz, 2 channels, 16 bits
+	defaultByteRate = 44100 * 2 * 2
+	// defaultAlign of dropping before format is known, it is multiple of frame sizes of 1 and 2 channels of 8, 16, 24 and 32 bits
+	defaultAlign = 24
+)
+
+// ring bounded storage, capacity is duration of audio.
+// Writing and reading are safe from different goroutines.
+type ring struct {
+	mutex    sync.Mutex
+	duration time.Duration
+	overflow string
+	byteRate int
+	// align of dropped data, size of sample frame
+	align int
+
+	buffer []byte
+	start  int
+	size   int
+	// dropped bytes on overflow
+	dropped uint64
+	closed  bool
+	// finished writing, reading of empty storage returns io.EOF
+	finished bool
+
+	written chan struct{}
+	read    chan struct{}
+	done    chan struct{}
+}
+
+// Write data to storage, on overflow writer waits or oldest data is dropped by policy
+func (r *ring) Write(data []byte) (n int, err error) {
+	for len(data) != 0 {
+		r.mutex.Lock()
+		if r.closed {
+			r.mutex.Unlock()
+			return n, io.ErrClosedPipe
+		}
+		if r.overflow == OverflowDrop {
+			skip := r.drop(len(data))
+			n += skip
+			data = data[skip:]
+		}
+		l := r.write(data)
+		if l != 0 {
+			r.finished = false
+		}
+		r.mutex.Unlock()
+
+		n += l
+		data = data[l:]
+		if l != 0 {
+			notify(r.written)
+			continue
+		}
+		select {
+		case <-r.read:
+		case <-r.done:
+		}
+	}
+	return
+}
+
+// Read data from storage, empty storage waits for data readWait.
+// io.EOF is returned when storage is empty and writing is finished.
+func (r *ring) Read(data []byte) (n int, err error) {
+	var timer *time.Timer
+	for {
+		r.mutex.Lock()
+		if r.size != 0 {
+			n = r.copyTo(data)
+			r.mutex.Unlock()
+			notify(r.read)
+			return
+		}
+		end := r.closed || r.finished
+		r.mutex.Unlock()
+		if end {
+			return 0, io.EOF
+		}
+
+		if timer == nil {
+			timer = time.NewTimer(readWait)
+			defer timer.Stop()
+		}
+		select {
+		case <-r.written:
+		case <-r.done:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
+// Finish writing, reader gets io.EOF after buffered data until next write
+func (r *ring) Finish() {
+	r.mutex.Lock()
+	r.finished = true
+	r.mutex.Unlock()
+	notify(r.written)
+}
+
+// Close storage, waiting writer and reader are released
+func (r *ring) Close() (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.closed {
+		r.closed = true
+		r.buffer, r.size = nil, 0
+		close(r.done)
+	}
+	return
+}
+
+// SetFormat of samples in storage, capacity is recalculated from duration
+func (r *ring) SetFormat(channels, rate, bitsPerSample, audioFormat int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	frameSize := channels * bitsPerSample / 8
+	if r.closed || frameSize <= 0 || rate <= 0 {
+		return
+	}
+	r.byteRate, r.align = frameSize*rate, frameSize
+
+	// buffered data is kept if it is bigger than new capacity
+	capacity := r.capacity()
+	if capacity < r.size {
+		capacity = r.size
+	}
+	buffer := make([]byte, capacity)
+	size := r.copyTo(buffer)
+	r.buffer, r.start, r.size = buffer, 0, size
+}
+
+// Fill level of storage: buffered and maximum bytes, duration of buffered audio and dropped bytes on overflow
+func (r *ring) Fill() (size, capacity int, duration time.Duration, dropped uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	duration = time.Duration(r.size) * time.Second / time.Duration(r.byteRate)
+	return r.size, len(r.buffer), duration, r.dropped
+}
+
+// capacity in bytes of duration with current format, at least one frame
+func (r *ring) capacity() int {
+	capacity := int(int64(r.duration) * int64(r.byteRate) / int64(time.Second))
+	capacity -= capacity % r.align
+	if capacity < r.align {
+		capacity = r.align
+	}
+	return capacity
+}
+
+// drop oldest data to free space for size bytes, dropped data is aligned to sample frames.
+// Return bytes to skip from beginning of written data if it does not fit in storage.
+func (r *ring) drop(size int) (skip int) {
+	over := r.size + size - len(r.buffer)
+	if over <= 0 {
+		return
+	}
+	if rest := over % r.align; rest != 0 {
+		over += r.align - rest
+	}
+	if over > r.size {
+		skip, over = over-r.size, r.size
+	}
+	r.start = (r.start + over) % len(r.buffer)
+	r.size -= over
+	r.dropped += uint64(over + skip)
+	return
+}
+
+// write data to free space, return written bytes
+func (r *ring) write(data []byte) (n int) {
+	for n < len(data) && r.size < len(r.buffer) {
+		end := (r.start + r.size) % len(r.buffer)
+		free := len(r.buffer) - r.size
+		if end+free > len(r.buffer) {
+			free = len(r.buffer) - end
+		}
+		l := copy(r.buffer[end:end+free], data[n:])
+		r.size += l
+		n += l
+	}
+	return
+}
+
+// copyTo data buffered bytes, return read bytes
+func (r *ring) copyTo(data []byte) (n int) {
+	for n < len(data) && r.size != 0 {
+		end := r.start + r.size
+		if end > len(r.buffer) {
+			end = len(r.buffer)
+		}
+		l := copy(data[n:], r.buffer[r.start:end])
+		r.start = (r.start + l) % len(r.buffer)
+		r.size -= l
+		n += l
+	}
+	return
+}
+
+// notify waiting side without blocking
+func notify(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
+
+func newRing(duration time.Duration, overflow string) *ring {
+	r := &ring{
+		duration: duration,
+		overflow: overflow,
+		byteRate: defaultByteRate,
+		align:    defaultAlign,
+
+		written: make(chan struct{}, 1),
+		read:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	r.buffer = make([]byte, r.capacity())
+	return r
+}
@@ -2,17 +2,44 @@ package storage
 
 import (
 	"io"
+	"time"
 )
 
 // Storage ...
 type Storage struct{}
 
-// List storage
-func (s *Storage) List() io.ReadWriteCloser {
-	return &queue{}
+// Create unbounded storage, read content is dropped, uuid is not used
+func (s *Storage) Create(uuid string) (io.ReadWriteCloser, error) {
+	return newQueue(false), nil
+}
+
+// CreateReplay unbounded storage keeping content until Close to play it several times, uuid is not used
+func (s *Storage) CreateReplay(uuid string) (io.ReadWriteCloser, error) {
+	return newReplayQueue(), nil
 }
 
 // NewStorage ...
 func NewStorage() *Storage {
 	return &Storage{}
 }
+
+// Ring creator of bounded storages
+type Ring struct {
+	capacity time.Duration
+	overflow string
+}
+
+// Create storage with capacity of audio duration.
+// Capacity in bytes is calculated by format of samples set by SetFormat(channels, rate, bitsPerSample, audioFormat int),
+// before it format is 44100 Hz, 2 channels, 16 bits.
+func (r *Ring) Create(uuid string) (io.ReadWriteCloser, error) {
+	return newRing(r.capacity, r.overflow), nil
+}
+
+// NewRing overflow is policy of full storage: OverflowBlock or OverflowDrop
+func NewRing(capacity time.Duration, overflow string) *Ring {
+	return &Ring{
+		capacity: capacity,
+		overflow: overflow,
+	}
+}
@@ -0,0 +1,123 @@
+package stream
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrWrongPacket packet header can not be parsed
+var ErrWrongPacket = errors.New("wrong packet")
+
+const (
+	version    = 1
+	headerSize = 32
+	// maxPayload protects from allocating memory for broken length
+	maxPayload = 1 << 20
+)
+
+// Format of samples in payload, zero format if sender does not know it
+type Format struct {
+	Channels      int
+	Rate          int
+	BitsPerSample int
+	// AudioFormat as in wav header: 1 - PCM, 3 - IEEE float
+	AudioFormat int
+}
+
+// FrameSize return size of sample frame, 1 if format is not set
+func (f Format) FrameSize() int {
+	if size := f.Channels * f.BitsPerSample / 8; size > 0 {
+		return size
+	}
+	return 1
+}
+
+// Silence return size bytes of silence in format, 8 bits samples are unsigned
+func (f Format) Silence(size int) []byte {
+	data := make([]byte, size)
+	if f.BitsPerSample == 8 && f.AudioFormat != 3 {
+		for i := range data {
+			data[i] = 128
+		}
+	}
+	return data
+}
+
+// Packet of audio stream.
+// Header is 32 bytes big-endian:
+// version, audioFormat, bitsPerSample, channels (1 byte each), rate (4), sequence (4),
+// timestamp (8), sentAt (8), payload length (4).
+type Packet struct {
+	Format Format
+	// Sequence number of packet, increased by 1 for each packet
+	Sequence uint32
+	// Timestamp position of first sample frame of payload in stream, in bytes if format is not set
+	Timestamp uint64
+	// SentAt wall clock of sender
+	SentAt  time.Time
+	Payload []byte
+}
+
+// Marshal packet to bytes
+func (p *Packet) Marshal() []byte {
+	data := make([]byte, headerSize+len(p.Payload))
+	data[0] = version
+	data[1] = byte(p.Format.AudioFormat)
+	data[2] = byte(p.Format.BitsPerSample)
+	data[3] = byte(p.Format.Channels)
+	binary.BigEndian.PutUint32(data[4:], uint32(p.Format.Rate))
+	binary.BigEndian.PutUint32(data[8:], p.Sequence)
+	binary.BigEndian.PutUint64(data[12:], p.Timestamp)
+	binary.BigEndian.PutUint64(data[20:], uint64(p.SentAt.UnixNano()))
+	binary.BigEndian.PutUint32(data[28:], uint32(len(p.Payload)))
+	copy(data[headerSize:], p.Payload)
+	return data
+}
+
+// Unmarshal packet from data, payload refers to data
+func (p *Packet) Unmarshal(data []byte) (err error) {
+	if len(data) < headerSize || data[0] != version {
+		return ErrWrongPacket
+	}
+	length := int(binary.BigEndian.Uint32(data[28:]))
+	if len(data) != headerSize+length {
+		return ErrWrongPacket
+	}
+	p.unmarshalHeader(data)
+	p.Payload = data[headerSize:]
+	return
+}
+
+// Decode read one packet from stream r
+func (p *Packet) Decode(r io.Reader) (err error) {
+	header := make([]byte, headerSize)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return
+	}
+	if header[0] != version {
+		return ErrWrongPacket
+	}
+	length := binary.BigEndian.Uint32(header[28:])
+	if length > maxPayload {
+		return ErrWrongPacket
+	}
+
+	p.unmarshalHeader(header)
+	p.Payload = make([]byte, length)
+	_, err = io.ReadFull(r, p.Payload)
+	return
+}
+
+func (p *Packet) unmarshalHeader(header []byte) {
+	p.Format = Format{
+		AudioFormat:   int(header[1]),
+		BitsPerSample: int(header[2]),
+		Channels:      int(header[3]),
+		Rate:          int(binary.BigEndian.Uint32(header[4:])),
+	}
+	p.Sequence = binary.BigEndian.Uint32(header[8:])
+	p.Timestamp = binary.BigEndian.Uint64(header[12:])
+	p.SentAt = time.Unix(0, int64(binary.BigEndian.Uint64(header[20:])))
+}
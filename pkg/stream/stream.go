@@ -0,0 +1,238 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// ErrPortNotFound nothing was received on port
+var ErrPortNotFound = errors.New("port not found")
+
+// formatter reader of audio signal with known format of samples
+type formatter interface {
+	Format() (channels, rate, bitsPerSample, audioFormat int)
+}
+
+// finisher writer which is notified that receiving is finished
+type finisher interface {
+	Finish()
+}
+
+// Stats of receiving stream
+type Stats struct {
+	Format  Format
+	Packets uint64
+	// Lost packets detected by gaps in sequence numbers, gaps are filled with silence
+	Lost uint64
+	// Late packets received after their place was played, they are dropped
+	Late uint64
+	// Latency from sending to receiving of last packet, clocks of sender and receiver must be synchronized
+	Latency time.Duration
+}
+
+// Stream send and receive audio signal over tcp in packets with sequence number, timestamp and format of samples
+type Stream struct {
+	buffSize int
+
+	mutex sync.Mutex
+	stats map[string]*Stats
+}
+
+type sender struct {
+	connection io.WriteCloser
+	format     Format
+	sequence   uint32
+	// sent bytes
+	offset uint64
+}
+
+// SetFormat of samples written to sender
+func (s *sender) SetFormat(channels, rate, bitsPerSample, audioFormat int) {
+	s.format = Format{
+		Channels:      channels,
+		Rate:          rate,
+		BitsPerSample: bitsPerSample,
+		AudioFormat:   audioFormat,
+	}
+}
+
+// Write data in one packet
+func (s *sender) Write(data []byte) (n int, err error) {
+	packet := Packet{
+		Format:    s.format,
+		Sequence:  s.sequence,
+		Timestamp: s.offset / uint64(s.format.FrameSize()),
+		SentAt:    time.Now(),
+		Payload:   data,
+	}
+	if _, err = s.connection.Write(packet.Marshal()); err != nil {
+		return
+	}
+	s.sequence++
+	s.offset += uint64(len(data))
+	return len(data), nil
+}
+
+// Close connection
+func (s *sender) Close() error {
+	return s.connection.Close()
+}
+
+// TurnOnSender stream sender, data written to sender is sent in packets
+func (st *Stream) TurnOnSender(dstAddr string) (io.WriteCloser, error) {
+	return st.turnOnSender(dstAddr)
+}
+
+func (st *Stream) turnOnSender(dstAddr string) (s *sender, err error) {
+	connection, err := net.Dial("tcp", dstAddr)
+	if err != nil {
+		return
+	}
+	s = &sender{
+		connection: connection,
+	}
+	return
+}
+
+// Send start sending data over port.
+// Format of samples is sent if r has method Format() (channels, rate, bitsPerSample, audioFormat int).
+func (st *Stream) Send(ctx context.Context, dstAddr string, r io.Reader) (err error) {
+	s, err := st.turnOnSender(dstAddr)
+	if err != nil {
+		return
+	}
+	if f, isFormatter := r.(formatter); isFormatter {
+		s.SetFormat(f.Format())
+	}
+
+	go func() {
+		defer s.Close()
+
+		// packets contain whole sample frames
+		frameSize := s.format.FrameSize()
+		outputBytes := make([]byte, st.buffSize+frameSize)
+		rest := 0
+		for ctx.Err() == nil {
+			l, err := r.Read(outputBytes[rest:])
+			if err != nil {
+				return
+			}
+			l += rest
+			size := l - l%frameSize
+			if size != 0 {
+				if _, err = s.Write(outputBytes[:size]); err != nil {
+					return
+				}
+			}
+			rest = copy(outputBytes, outputBytes[size:l])
+		}
+	}()
+	return
+}
+
+// Receive start receiving data over port, payload of packets is written to w.
+// w is finished if it has method Finish() when connection is closed or receiving is stopped.
+func (st *Stream) Receive(ctx context.Context, receivePort string, w io.Writer) (err error) {
+	ln, err := net.Listen("tcp", ":"+receivePort)
+	if err != nil {
+		return
+	}
+
+	stats := &Stats{}
+	st.mutex.Lock()
+	st.stats[receivePort] = stats
+	st.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	go func() {
+		defer finish(w)
+
+		connection, err := ln.Accept()
+		// only one connection is received, port is released for next receiving
+		ln.Close()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			<-ctx.Done()
+			connection.Close()
+		}()
+
+		var sequence uint32
+		var timestamp uint64
+		for {
+			var packet Packet
+			if err := packet.Decode(connection); err != nil {
+				return
+			}
+			frameSize := packet.Format.FrameSize()
+
+			st.mutex.Lock()
+			if stats.Packets != 0 && packet.Sequence != sequence {
+				stats.Lost += uint64(packet.Sequence - sequence)
+			}
+			stats.Packets++
+			stats.Format = packet.Format
+			stats.Latency = time.Since(packet.SentAt)
+			isFirst := stats.Packets == 1
+			st.mutex.Unlock()
+
+			if !isFirst && packet.Timestamp > timestamp {
+				w.Write(packet.Format.Silence(gapSize(packet.Format, packet.Timestamp-timestamp)))
+			}
+			w.Write(packet.Payload)
+
+			sequence = packet.Sequence + 1
+			timestamp = packet.Timestamp + uint64(len(packet.Payload)/frameSize)
+		}
+	}()
+	return
+}
+
+func finish(w io.Writer) {
+	if f, isFinisher := w.(finisher); isFinisher {
+		f.Finish()
+	}
+}
+
+// gapSize return size of silence in bytes filling gap of frames, gap is limited by one second
+func gapSize(format Format, frames uint64) int {
+	if max := uint64(format.Rate); max != 0 && frames > max {
+		frames = max
+	}
+	size := frames * uint64(format.FrameSize())
+	if size > maxPayload {
+		size = maxPayload
+	}
+	return int(size)
+}
+
+// Stats of receiving on receivePort
+func (st *Stream) Stats(receivePort string) (stats Stats, err error) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	s, isExist := st.stats[receivePort]
+	if !isExist {
+		err = ErrPortNotFound
+		return
+	}
+	return *s, nil
+}
+
+// NewStream ...
+func NewStream(buffSize int) *Stream {
+	return &Stream{
+		buffSize: buffSize,
+		stats:    make(map[string]*Stats),
+	}
+}
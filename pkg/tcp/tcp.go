@@ -6,6 +6,11 @@ import (
 	"net"
 )
 
+// finisher writer which is notified that receiving is finished
+type finisher interface {
+	Finish()
+}
+
 // TCP receive and send
 type TCP struct {
 	buffSize int
@@ -45,7 +50,8 @@ func (u *TCP) Send(ctx context.Context, dstAddr string, r io.Reader) (err error)
 	return
 }
 
-// Receive start receiving data over port
+// Receive start receiving data over port.
+// w is finished if it has method Finish() when connection is closed or receiving is stopped.
 func (u *TCP) Receive(ctx context.Context, receivePort string, w io.Writer) (err error) {
 	ln, err := net.Listen("tcp", ":"+receivePort)
 	if err != nil {
@@ -53,7 +59,19 @@ func (u *TCP) Receive(ctx context.Context, receivePort string, w io.Writer) (err
 	}
 
 	go func() {
-		connection, _ := ln.Accept()
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	go func() {
+		defer finish(w)
+
+		connection, err := ln.Accept()
+		// only one connection is received, port is released for next receiving
+		ln.Close()
+		if err != nil {
+			return
+		}
 
 		go func() {
 			<-ctx.Done()
@@ -72,6 +90,12 @@ func (u *TCP) Receive(ctx context.Context, receivePort string, w io.Writer) (err
 	return
 }
 
+func finish(w io.Writer) {
+	if f, isFinisher := w.(finisher); isFinisher {
+		f.Finish()
+	}
+}
+
 // NewTCP ...
 func NewTCP(buffSize int) *TCP {
 	return &TCP{
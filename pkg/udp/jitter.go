@@ -0,0 +1,197 @@
+package udp
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"audio-service/pkg/stream"
+)
+
+const (
+	// idleWait of empty buffer, arrived packet wakes up playing earlier
+	idleWait = time.Second
+	// maxReorder packets, packet further behind is from restarted sender
+	maxReorder = 1024
+)
+
+// jitter buffer reorders packets and conceals lost packets.
+// Packets are held for delay after the first packet to absorb jitter,
+// missing packet is lost if a later packet has waited for it longer than delay.
+type jitter struct {
+	// mutex is shared with stats of receiving
+	mutex   *sync.Mutex
+	packets map[uint32]*arrival
+	arrived chan struct{}
+
+	delay time.Duration
+	w     io.Writer
+
+	started bool
+	next    uint32
+
+	// payload of last written packet, repeated once in place of lost packet
+	last      []byte
+	format    stream.Format
+	concealed bool
+
+	stats *stream.Stats
+}
+
+type arrival struct {
+	packet stream.Packet
+	time   time.Time
+}
+
+// push received packet
+func (j *jitter) push(packet stream.Packet, now time.Time) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.stats.Packets++
+	j.stats.Format = packet.Format
+	j.stats.Latency = now.Sub(packet.SentAt)
+
+	if behind := int32(packet.Sequence - j.next); j.started && behind < 0 {
+		if behind > -maxReorder {
+			j.stats.Late++
+			return
+		}
+		// sender is restarted, buffer is primed again
+		j.packets, j.started = make(map[uint32]*arrival), false
+	}
+	j.packets[packet.Sequence] = &arrival{
+		packet: packet,
+		time:   now,
+	}
+
+	select {
+	case j.arrived <- struct{}{}:
+	default:
+	}
+}
+
+// play write packets in order of sequence numbers to w until ctx is done
+func (j *jitter) play(ctx context.Context) {
+	timer := time.NewTimer(idleWait)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.finish()
+			return
+		case <-j.arrived:
+		case <-timer.C:
+		}
+
+		wait, finished := j.flush(time.Now())
+		if finished {
+			j.finish()
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// flush write all packets ready to playing, return time to wait for next packet and true if end of stream is flushed.
+// Writer is finished by caller without mutex, as finishing locks receivers of writer.
+func (j *jitter) flush(now time.Time) (wait time.Duration, finished bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if !j.started {
+		first, isExist := j.first()
+		if !isExist {
+			return idleWait, false
+		}
+		if wait = j.packets[first].time.Add(j.delay).Sub(now); wait > 0 {
+			return
+		}
+		j.next, j.started = first, true
+	}
+
+	for len(j.packets) != 0 {
+		if a, isExist := j.packets[j.next]; isExist {
+			delete(j.packets, j.next)
+			j.next++
+			// empty packet marks end of stream
+			if len(a.packet.Payload) == 0 {
+				finished = true
+				continue
+			}
+			j.w.Write(a.packet.Payload)
+			j.last, j.format, j.concealed = a.packet.Payload, a.packet.Format, false
+			continue
+		}
+
+		// packet is lost when later packet waits for it longer than delay
+		if wait = j.oldest().Add(j.delay).Sub(now); wait > 0 {
+			return
+		}
+		j.conceal()
+		j.next++
+	}
+	return idleWait, finished
+}
+
+// conceal lost packet by repeating last packet, silence is played in place of next lost packets
+func (j *jitter) conceal() {
+	j.stats.Lost++
+	if j.last == nil {
+		return
+	}
+	if j.concealed {
+		j.w.Write(j.format.Silence(len(j.last)))
+		return
+	}
+	repeat := make([]byte, len(j.last))
+	copy(repeat, j.last)
+	j.w.Write(repeat)
+	j.concealed = true
+}
+
+// finish writing to w
+func (j *jitter) finish() {
+	if f, isFinisher := j.w.(finisher); isFinisher {
+		f.Finish()
+	}
+}
+
+// first return sequence number of first packet in buffer
+func (j *jitter) first() (sequence uint32, isExist bool) {
+	for s := range j.packets {
+		if !isExist || int32(s-sequence) < 0 {
+			sequence, isExist = s, true
+		}
+	}
+	return
+}
+
+// oldest return time of arrival of earliest arrived packet in buffer
+func (j *jitter) oldest() (t time.Time) {
+	for _, a := range j.packets {
+		if t.IsZero() || a.time.Before(t) {
+			t = a.time
+		}
+	}
+	return
+}
+
+func newJitter(w io.Writer, delay time.Duration, mutex *sync.Mutex, stats *stream.Stats) *jitter {
+	return &jitter{
+		mutex:   mutex,
+		packets: make(map[uint32]*arrival),
+		arrived: make(chan struct{}, 1),
+
+		delay: delay,
+		w:     w,
+		stats: stats,
+	}
+}
@@ -0,0 +1,260 @@
+package udp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"audio-service/pkg/stream"
+)
+
+const (
+	// maxDatagram size of udp datagram
+	maxDatagram = 65507
+	// maxPayload of packet in one datagram, header of packet is 32 bytes
+	maxPayload = maxDatagram - 32
+	// maxLead of sending before real time, udp has no flow control and receiver buffer is limited
+	maxLead = 500 * time.Millisecond
+	// readBuffer of socket holds burst of maxLead, system limit of buffer size is applied
+	readBuffer = 4 << 20
+)
+
+// ErrPortNotFound nothing was received on port
+var ErrPortNotFound = errors.New("port not found")
+
+// formatter reader of audio signal with known format of samples
+type formatter interface {
+	Format() (channels, rate, bitsPerSample, audioFormat int)
+}
+
+// finisher writer which is notified that receiving is finished
+type finisher interface {
+	Finish()
+}
+
+// UDP send and receive audio signal over udp unicast or multicast in packets of stream.
+// One sender can feed any number of receivers joined to multicast group.
+type UDP struct {
+	buffSize    int
+	jitterDelay time.Duration
+
+	mutex sync.Mutex
+	stats map[string]*stream.Stats
+}
+
+type sender struct {
+	connection net.Conn
+	format     stream.Format
+	sequence   uint32
+	// sent bytes
+	offset uint64
+	start  time.Time
+}
+
+// SetFormat of samples written to sender
+func (s *sender) SetFormat(channels, rate, bitsPerSample, audioFormat int) {
+	s.format = stream.Format{
+		Channels:      channels,
+		Rate:          rate,
+		BitsPerSample: bitsPerSample,
+		AudioFormat:   audioFormat,
+	}
+}
+
+// Write data in one packet, data bigger than datagram is split in packets of whole sample frames.
+// Encoded blocks written at once are not split between packets.
+func (s *sender) Write(data []byte) (n int, err error) {
+	frameSize := s.format.FrameSize()
+	size := maxPayload - maxPayload%frameSize
+	for n < len(data) {
+		end := n + size
+		if end > len(data) {
+			end = len(data)
+		}
+		if err = s.send(data[n:end]); err != nil {
+			return
+		}
+		n = end
+	}
+	return
+}
+
+func (s *sender) send(payload []byte) (err error) {
+	frameSize := uint64(s.format.FrameSize())
+	s.pace()
+	packet := stream.Packet{
+		Format:    s.format,
+		Sequence:  s.sequence,
+		Timestamp: s.offset / frameSize,
+		SentAt:    time.Now(),
+		Payload:   payload,
+	}
+	if _, err = s.connection.Write(packet.Marshal()); err != nil {
+		return
+	}
+	s.sequence++
+	s.offset += uint64(len(payload))
+	return
+}
+
+// pace sending to real time if rate of samples is known
+func (s *sender) pace() {
+	if s.format.Rate == 0 {
+		return
+	}
+	if s.start.IsZero() {
+		s.start = time.Now()
+	}
+	frames := s.offset / uint64(s.format.FrameSize())
+	sent := time.Duration(frames) * time.Second / time.Duration(s.format.Rate)
+	if lead := sent - time.Since(s.start); lead > maxLead {
+		time.Sleep(lead - maxLead)
+	}
+}
+
+// Close connection, empty packet marks end of stream for receivers
+func (s *sender) Close() error {
+	s.send(nil)
+	return s.connection.Close()
+}
+
+// TurnOnSender udp sender, dstAddr can be address of multicast group
+func (u *UDP) TurnOnSender(dstAddr string) (io.WriteCloser, error) {
+	return u.turnOnSender(dstAddr)
+}
+
+func (u *UDP) turnOnSender(dstAddr string) (s *sender, err error) {
+	connection, err := net.Dial("udp", dstAddr)
+	if err != nil {
+		return
+	}
+	s = &sender{
+		connection: connection,
+	}
+	return
+}
+
+// Send start sending data over port.
+// Format of samples is sent if r has method Format() (channels, rate, bitsPerSample, audioFormat int).
+func (u *UDP) Send(ctx context.Context, dstAddr string, r io.Reader) (err error) {
+	s, err := u.turnOnSender(dstAddr)
+	if err != nil {
+		return
+	}
+	if f, isFormatter := r.(formatter); isFormatter {
+		s.SetFormat(f.Format())
+	}
+
+	go func() {
+		defer s.Close()
+
+		// packets contain whole sample frames
+		frameSize := s.format.FrameSize()
+		outputBytes := make([]byte, u.buffSize+frameSize)
+		rest := 0
+		for ctx.Err() == nil {
+			l, err := r.Read(outputBytes[rest:])
+			if err != nil {
+				return
+			}
+			l += rest
+			size := l - l%frameSize
+			if size != 0 {
+				if _, err = s.Write(outputBytes[:size]); err != nil {
+					return
+				}
+			}
+			rest = copy(outputBytes, outputBytes[size:l])
+		}
+	}()
+	return
+}
+
+// Receive start receiving data, payload of packets is written to w in order of sequence numbers.
+// receivePort is port for unicast or group:port to join multicast group.
+// w is finished if it has method Finish() at end of stream or when receiving is stopped.
+func (u *UDP) Receive(ctx context.Context, receivePort string, w io.Writer) (err error) {
+	connection, err := listen(receivePort)
+	if err != nil {
+		return
+	}
+
+	stats := &stream.Stats{}
+	u.mutex.Lock()
+	u.stats[receivePort] = stats
+	u.mutex.Unlock()
+
+	j := newJitter(w, u.jitterDelay, &u.mutex, stats)
+	go j.play(ctx)
+
+	go func() {
+		<-ctx.Done()
+		connection.Close()
+	}()
+
+	go func() {
+		data := make([]byte, maxDatagram)
+		for {
+			l, err := connection.Read(data)
+			if err != nil {
+				return
+			}
+			var packet stream.Packet
+			if err := packet.Unmarshal(data[:l]); err != nil {
+				continue
+			}
+			packet.Payload = append([]byte(nil), packet.Payload...)
+			j.push(packet, time.Now())
+		}
+	}()
+	return
+}
+
+// listen unicast port or join multicast group if receivePort is group:port
+func listen(receivePort string) (*net.UDPConn, error) {
+	if !strings.Contains(receivePort, ":") {
+		receivePort = ":" + receivePort
+	}
+	addr, err := net.ResolveUDPAddr("udp", receivePort)
+	if err != nil {
+		return nil, err
+	}
+	var connection *net.UDPConn
+	if addr.IP != nil && addr.IP.IsMulticast() {
+		connection, err = net.ListenMulticastUDP("udp", nil, addr)
+	} else {
+		connection, err = net.ListenUDP("udp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	connection.SetReadBuffer(readBuffer)
+	return connection, nil
+}
+
+// Stats of receiving on receivePort, lost packets are not received in jitter delay,
+// the first one is replaced by previous packet, next ones by silence
+func (u *UDP) Stats(receivePort string) (stats stream.Stats, err error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	s, isExist := u.stats[receivePort]
+	if !isExist {
+		err = ErrPortNotFound
+		return
+	}
+	return *s, nil
+}
+
+// NewUDP jitterDelay is time of waiting for reordered packets before packet is lost
+func NewUDP(buffSize int, jitterDelay time.Duration) *UDP {
+	return &UDP{
+		buffSize:    buffSize,
+		jitterDelay: jitterDelay,
+		stats:       make(map[string]*stream.Stats),
+	}
+}
@@ -0,0 +1,82 @@
+package volume
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rampDuration time of smooth change gain to avoid clicks
+const rampDuration = 20 * time.Millisecond
+
+// Volume gain of audio signal on device
+type Volume struct {
+	mutex sync.Mutex
+	level float64
+	mute  bool
+
+	gain float64
+}
+
+// Set volume level, 1 - original loudness
+func (v *Volume) Set(level float64) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.level = level
+}
+
+// Mute or unmute signal
+func (v *Volume) Mute(mute bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.mute = mute
+}
+
+// Passthrough return true if signal is not changed by volume
+func (v *Volume) Passthrough() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.gain == 1 && v.level == 1 && !v.mute
+}
+
+// Apply gain to interleaved samples in range [-1, 1].
+// Gain changes linearly from previous to new level during rampDuration.
+func (v *Volume) Apply(samples []float64, channels, rate int) {
+	v.mutex.Lock()
+	target, gain := v.level, v.gain
+	if v.mute {
+		target = 0
+	}
+	v.mutex.Unlock()
+
+	if channels < 1 {
+		channels = 1
+	}
+	step := 1 / (float64(rate) * rampDuration.Seconds())
+	for frame := 0; frame < len(samples); frame += channels {
+		switch {
+		case gain < target:
+			gain = math.Min(gain+step, target)
+		case gain > target:
+			gain = math.Max(gain-step, target)
+		}
+		if gain == 1 {
+			continue
+		}
+		for i := frame; i < frame+channels && i < len(samples); i++ {
+			samples[i] *= gain
+		}
+	}
+
+	v.mutex.Lock()
+	v.gain = gain
+	v.mutex.Unlock()
+}
+
+// NewVolume with original loudness
+func NewVolume() *Volume {
+	return &Volume{
+		level: 1,
+		gain:  1,
+	}
+}
@@ -8,11 +8,22 @@ import (
 	"github.com/geoirb/wav"
 )
 
+const (
+	// offset of audio format in wav header
+	audioFormatOffset = 20
+	extension         = ".wav"
+)
+
 // WAV audio file
 type WAV struct{}
 
+// Match data starts with riff header of wave file
+func (w *WAV) Match(data []byte) bool {
+	return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE"
+}
+
 // Reader wav file
-func (w *WAV) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample uint16, err error) {
+func (w *WAV) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bitsPerSample, audioFormat uint16, err error) {
 	wav, err := wav.NewReader(data)
 	if err != nil {
 		return
@@ -20,24 +31,32 @@ func (w *WAV) Reader(data []byte) (r io.Reader, channels uint16, rate uint32, bi
 	channels = wav.GetNumChannels()
 	rate = wav.GetSampleRate()
 	bitsPerSample = wav.GetBitsPerSample()
+	audioFormat = wav.GetAudioFormat()
 	r = wav
 	return
 }
 
-// Writer wav file
-func (w *WAV) Writer(fileName string, channels uint16, rate uint32) (wc io.WriteCloser, err error) {
-	if !strings.HasSuffix(fileName, ".wav") {
-		fileName += ".wav"
+// Writer wav file with samples in bitsPerSample and audioFormat (1 - PCM, 3 - IEEE float).
+// Samples are written to file as they come, header is valid after each write.
+func (w *WAV) Writer(fileName string, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (wc io.WriteCloser, err error) {
+	if !strings.HasSuffix(fileName, extension) {
+		fileName += extension
 	}
 	file, err := os.Create(fileName)
 	if err != nil {
 		return
 	}
-
-	wc = wav.NewWriter(file, channels, rate, wav.S16LE)
+	if wc, err = newWriter(file, channels, rate, bitsPerSample, audioFormat); err != nil {
+		file.Close()
+	}
 	return
 }
 
+// Extension of wav files
+func (w *WAV) Extension() string {
+	return extension
+}
+
 // NewWAV return handler wav file
 func NewWAV() *WAV {
 	return &WAV{}
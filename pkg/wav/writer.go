@@ -0,0 +1,70 @@
+package wav
+
+import (
+	"encoding/binary"
+	"os"
+)
+
+const (
+	headerSize = 44
+	// offsets of sizes in header
+	riffSizeOffset = 4
+	dataSizeOffset = 40
+)
+
+// writer of wav file, samples are written to file as they come.
+// Sizes in header are updated after each write, so file is valid if process dies before closing.
+type writer struct {
+	file *os.File
+	size uint32
+}
+
+// Write samples to file
+func (w *writer) Write(data []byte) (n int, err error) {
+	if n, err = w.file.Write(data); err != nil {
+		return
+	}
+	w.size += uint32(n)
+	err = w.updateHeader()
+	return
+}
+
+// Close file
+func (w *writer) Close() error {
+	return w.file.Close()
+}
+
+func (w *writer) updateHeader() (err error) {
+	sizes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sizes, w.size+headerSize-8)
+	if _, err = w.file.WriteAt(sizes, riffSizeOffset); err != nil {
+		return
+	}
+	binary.LittleEndian.PutUint32(sizes, w.size)
+	_, err = w.file.WriteAt(sizes, dataSizeOffset)
+	return
+}
+
+func newWriter(file *os.File, channels uint16, rate uint32, bitsPerSample, audioFormat uint16) (w *writer, err error) {
+	header := make([]byte, headerSize)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[riffSizeOffset:], headerSize-8)
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[audioFormatOffset:], audioFormat)
+	binary.LittleEndian.PutUint16(header[22:], channels)
+	binary.LittleEndian.PutUint32(header[24:], rate)
+	binary.LittleEndian.PutUint32(header[28:], uint32(channels)*rate*uint32(bitsPerSample)/8)
+	binary.LittleEndian.PutUint16(header[32:], bitsPerSample/8*channels)
+	binary.LittleEndian.PutUint16(header[34:], bitsPerSample)
+	copy(header[36:], "data")
+
+	if _, err = file.Write(header); err != nil {
+		return
+	}
+	w = &writer{
+		file: file,
+	}
+	return
+}